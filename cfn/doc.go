@@ -5,7 +5,8 @@
 // making it easier to implement custom resource handlers.
 //
 // The LambdaWrap helper catches errors and ensures proper responses are sent to CloudFormation's
-// pre-signed URL, preventing stack operations from hanging.
+// pre-signed URL, preventing stack operations from hanging. Operations that outlast a single
+// invocation can be implemented with a PollingResource.
 //
 // See https://docs.aws.amazon.com/AWSCloudFormation/latest/UserGuide/template-custom-resources.html
 package cfn
@@ -2,6 +2,10 @@ package cfn_test
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/aws/aws-lambda-go/cfn"
 	"github.com/aws/aws-lambda-go/lambda"
@@ -23,3 +27,108 @@ func Example() {
 		return
 	}))
 }
+
+// Custom resources that return secrets in Data can set NoEcho, so CloudFormation masks the values.
+func ExampleLambdaWrapV2() {
+	lambda.Start(cfn.LambdaWrapV2(func(ctx context.Context, event cfn.Event) (*cfn.Result, error) {
+		return &cfn.Result{
+			PhysicalResourceID: "generated-password",
+			Data: map[string]interface{}{
+				"Password": "not-a-real-password",
+			},
+			NoEcho: true,
+		}, nil
+	}))
+}
+
+// The response to CloudFormation is retried on transient failures. The retry policy can be tuned with WithRetryPolicy.
+func ExampleLambdaWrapWithOptions() {
+	lambda.Start(cfn.LambdaWrapWithOptions(func(ctx context.Context, event cfn.Event) (physicalResourceID string, data map[string]interface{}, err error) {
+		return
+	}, cfn.WithRetryPolicy(8, 250*time.Millisecond)))
+}
+
+// The response to CloudFormation can be sent with a custom HTTP client, for example through a proxy.
+func ExampleLambdaWrapWithHTTPClient() {
+	proxyURL, _ := url.Parse("http://proxy.internal:3128")
+	client := &http.Client{
+		Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)},
+		Timeout:   10 * time.Second,
+	}
+	lambda.Start(cfn.LambdaWrapWithHTTPClient(func(ctx context.Context, event cfn.Event) (physicalResourceID string, data map[string]interface{}, err error) {
+		return
+	}, client))
+}
+
+// WithTimeoutGrace reports a failure to CloudFormation shortly before the function would time out,
+// so the stack operation fails fast instead of waiting for CloudFormation's own timeout.
+func ExampleWithTimeoutGrace() {
+	lambda.Start(cfn.LambdaWrapWithOptions(func(ctx context.Context, event cfn.Event) (physicalResourceID string, data map[string]interface{}, err error) {
+		<-ctx.Done() // a function that hangs
+		return
+	}, cfn.WithTimeoutGrace(5*time.Second)))
+}
+
+// Operations that take longer than an invocation, such as creating an OpenSearch domain, can be polled
+// with a PollingResource. The scheduler invokes the function again with the PollingEvent after the delay,
+// here with a delayed SQS message whose queue triggers the same function.
+func ExamplePollingResource() {
+	resource := &cfn.PollingResource{
+		OnEvent: func(ctx context.Context, event cfn.Event) (*cfn.Result, error) {
+			domainName, _ := event.ResourceProperties["DomainName"].(string)
+			// start creating the domain...
+			return &cfn.Result{
+				PhysicalResourceID: domainName,
+				Data:               map[string]interface{}{"DomainName": domainName},
+			}, nil
+		},
+		IsComplete: func(ctx context.Context, event cfn.PollingEvent) (bool, map[string]interface{}, error) {
+			// describe the domain named event.Data["DomainName"]...
+			processing := event.Attempt < 3
+			if processing {
+				return false, nil, nil
+			}
+			return true, map[string]interface{}{"Endpoint": "search-example.us-east-1.es.amazonaws.com"}, nil
+		},
+		Scheduler:    sqsScheduler{},
+		Interval:     time.Minute,
+		TotalTimeout: 45 * time.Minute,
+	}
+
+	lambda.Start(resource.Handler())
+}
+
+type sqsScheduler struct{}
+
+func (sqsScheduler) Schedule(ctx context.Context, event cfn.PollingEvent, delay time.Duration) error {
+	// send event as the body of a message with DelaySeconds set from delay...
+	return nil
+}
+
+// CloudFormation Hooks validate resources before they are provisioned. Returning a *cfn.HookError
+// with the NonCompliant code blocks the operation, other errors are reported as an InternalFailure.
+func ExampleHookWrap() {
+	lambda.Start(cfn.HookWrap(func(ctx context.Context, event cfn.HookEvent) (cfn.HookResponse, error) {
+		if event.RequestData.TargetType != "AWS::S3::Bucket" {
+			return cfn.HookResponse{}, nil
+		}
+		if _, ok := event.RequestData.TargetModel.ResourceProperties["BucketEncryption"]; !ok {
+			return cfn.HookResponse{}, &cfn.HookError{Code: cfn.HookErrorCodeNonCompliant, Message: "bucket must be encrypted"}
+		}
+		return cfn.HookResponse{Message: "bucket is encrypted"}, nil
+	}))
+}
+
+// CloudFormation macros transform templates. Starting from event.CopyFragment sends the
+// parts of the fragment the macro does not change back as they were received.
+func ExampleMacroWrap() {
+	lambda.Start(cfn.MacroWrap(func(ctx context.Context, event cfn.MacroEvent) (map[string]interface{}, error) {
+		owner, ok := event.Params["Owner"].(string)
+		if !ok {
+			return nil, errors.New("Owner parameter is required")
+		}
+		fragment := event.CopyFragment()
+		fragment["Description"] = "owned by " + owner
+		return fragment, nil
+	}))
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package cfn
+
+import (
+	"context"
+	"errors"
+	"log"
+)
+
+// HookInvocationPoint is the stack operation a CloudFormation Hook is invoked for.
+type HookInvocationPoint string
+
+const (
+	HookInvocationPointCreatePreProvision HookInvocationPoint = "CREATE_PRE_PROVISION"
+	HookInvocationPointUpdatePreProvision HookInvocationPoint = "UPDATE_PRE_PROVISION"
+	HookInvocationPointDeletePreProvision HookInvocationPoint = "DELETE_PRE_PROVISION"
+)
+
+// HookStatus is the outcome of a CloudFormation Hook invocation.
+type HookStatus string
+
+const (
+	HookStatusSuccess    HookStatus = "SUCCESS"
+	HookStatusFailed     HookStatus = "FAILED"
+	HookStatusInProgress HookStatus = "IN_PROGRESS"
+)
+
+// HookErrorCode tells CloudFormation why a Hook reported HookStatusFailed.
+type HookErrorCode string
+
+const (
+	// HookErrorCodeNonCompliant means the target does not pass the Hook's checks.
+	HookErrorCodeNonCompliant HookErrorCode = "NonCompliant"
+	// HookErrorCodeInternalFailure means the Hook could not complete its checks.
+	HookErrorCodeInternalFailure HookErrorCode = "InternalFailure"
+)
+
+// HookEvent is a representation of a CloudFormation Lambda Hook invocation
+// See https://docs.aws.amazon.com/cloudformation-cli/latest/hooks-userguide/lambda-hooks.html
+type HookEvent struct {
+	ClientRequestToken    string                 `json:"clientRequestToken"`
+	AWSAccountID          string                 `json:"awsAccountId"`
+	StackID               string                 `json:"stackId"`
+	ChangeSetID           string                 `json:"changeSetId,omitempty"`
+	HookTypeName          string                 `json:"hookTypeName"`
+	HookTypeVersion       string                 `json:"hookTypeVersion"`
+	HookModel             map[string]interface{} `json:"hookModel,omitempty"`
+	ActionInvocationPoint HookInvocationPoint    `json:"actionInvocationPoint"`
+	RequestData           HookRequestData        `json:"requestData"`
+	RequestContext        HookRequestContext     `json:"requestContext"`
+}
+
+// HookRequestData describes the target the Hook is invoked for.
+type HookRequestData struct {
+	TargetName      string          `json:"targetName"`
+	TargetType      string          `json:"targetType"`
+	TargetLogicalID string          `json:"targetLogicalId"`
+	TargetModel     HookTargetModel `json:"targetModel"`
+}
+
+// HookTargetModel holds the properties of the target. PreviousResourceProperties is only set for updates.
+type HookTargetModel struct {
+	ResourceProperties         map[string]interface{} `json:"resourceProperties,omitempty"`
+	PreviousResourceProperties map[string]interface{} `json:"previousResourceProperties,omitempty"`
+}
+
+// HookRequestContext holds the CallbackContext returned by the previous IN_PROGRESS response, if any.
+type HookRequestContext struct {
+	Invocation      int                    `json:"invocation"`
+	CallbackContext map[string]interface{} `json:"callbackContext,omitempty"`
+}
+
+// HookResponse is a representation of the response expected by CloudFormation from a Lambda Hook.
+// When HookStatus is IN_PROGRESS, the Hook is invoked again after CallbackDelaySeconds, with CallbackContext.
+type HookResponse struct {
+	HookStatus           HookStatus             `json:"hookStatus"`
+	ErrorCode            HookErrorCode          `json:"errorCode,omitempty"`
+	Message              string                 `json:"message,omitempty"`
+	ClientRequestToken   string                 `json:"clientRequestToken,omitempty"`
+	CallbackContext      map[string]interface{} `json:"callbackContext,omitempty"`
+	CallbackDelaySeconds int                    `json:"callbackDelaySeconds,omitempty"`
+}
+
+// HookError is returned by a HookFunction to choose the ErrorCode of the FAILED response.
+type HookError struct {
+	Code    HookErrorCode
+	Message string
+}
+
+func (e *HookError) Error() string {
+	return e.Message
+}
+
+// HookFunction is a representation of the customer's Hook function, and of the standard form Lambda
+// for a Hook returned by HookWrap.
+type HookFunction func(context.Context, HookEvent) (HookResponse, error)
+
+// HookWrap returns a HookFunction which is something lambda.Start() will understand.
+// An error returned by the Hook function is reported to CloudFormation as FAILED with the error as the
+// message, and the InternalFailure error code, unless the error is a *HookError. A response without
+// HookStatus is reported as SUCCESS. The ClientRequestToken of the event is copied to the response.
+//
+//	func myHook(ctx context.Context, event cfn.HookEvent) (cfn.HookResponse, error) {
+//		if _, ok := event.RequestData.TargetModel.ResourceProperties["BucketEncryption"]; !ok {
+//			return cfn.HookResponse{}, &cfn.HookError{Code: cfn.HookErrorCodeNonCompliant, Message: "bucket must be encrypted"}
+//		}
+//		return cfn.HookResponse{}, nil
+//	}
+//
+//	func main() {
+//		lambda.Start(cfn.HookWrap(myHook))
+//	}
+func HookWrap(hookFunction HookFunction) HookFunction {
+	return func(ctx context.Context, event HookEvent) (HookResponse, error) {
+		response, err := hookFunction(ctx, event)
+		if err != nil {
+			log.Printf("hook failed: %s\n", err.Error())
+			response = HookResponse{
+				HookStatus: HookStatusFailed,
+				ErrorCode:  HookErrorCodeInternalFailure,
+				Message:    err.Error(),
+			}
+			var hookErr *HookError
+			if errors.As(err, &hookErr) {
+				response.ErrorCode = hookErr.Code
+			}
+		}
+		if response.HookStatus == "" {
+			response.HookStatus = HookStatusSuccess
+		}
+		if response.ClientRequestToken == "" {
+			response.ClientRequestToken = event.ClientRequestToken
+		}
+		return response, nil
+	}
+}
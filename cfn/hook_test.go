@@ -0,0 +1,108 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package cfn
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil" //nolint: staticcheck
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHookEventMarshaling(t *testing.T) {
+	for _, sampleFile := range []string{"hook-event-create.json", "hook-event-update.json"} {
+		t.Run(sampleFile, func(t *testing.T) {
+			inputJSON, err := ioutil.ReadFile("./testdata/" + sampleFile)
+			require.NoError(t, err)
+
+			var inputEvent HookEvent
+			require.NoError(t, json.Unmarshal(inputJSON, &inputEvent))
+
+			outputJSON, err := json.Marshal(inputEvent)
+			require.NoError(t, err)
+			test.AssertJsonsEqual(t, inputJSON, outputJSON)
+		})
+	}
+}
+
+func TestHookEventFields(t *testing.T) {
+	inputJSON, err := ioutil.ReadFile("./testdata/hook-event-update.json")
+	require.NoError(t, err)
+
+	var event HookEvent
+	require.NoError(t, json.Unmarshal(inputJSON, &event))
+	assert.Equal(t, "MyOrg::Security::BucketEncryption", event.HookTypeName)
+	assert.Equal(t, HookInvocationPointUpdatePreProvision, event.ActionInvocationPoint)
+	assert.Equal(t, "AWS::S3::Bucket", event.RequestData.TargetName)
+	assert.Equal(t, "MyBucket", event.RequestData.TargetLogicalID)
+	assert.Equal(t, map[string]interface{}{"Status": "Enabled"}, event.RequestData.TargetModel.PreviousResourceProperties["VersioningConfiguration"])
+	assert.Equal(t, 2, event.RequestContext.Invocation)
+	assert.Equal(t, "scan-42", event.RequestContext.CallbackContext["scanId"])
+}
+
+func TestHookResponseMarshaling(t *testing.T) {
+	for _, sampleFile := range []string{"hook-response-failed.json", "hook-response-in-progress.json"} {
+		t.Run(sampleFile, func(t *testing.T) {
+			inputJSON, err := ioutil.ReadFile("./testdata/" + sampleFile)
+			require.NoError(t, err)
+
+			var response HookResponse
+			require.NoError(t, json.Unmarshal(inputJSON, &response))
+
+			outputJSON, err := json.Marshal(response)
+			require.NoError(t, err)
+			test.AssertJsonsEqual(t, inputJSON, outputJSON)
+		})
+	}
+}
+
+func TestHookMarshalingMalformedJson(t *testing.T) {
+	test.TestMalformedJson(t, HookEvent{})
+}
+
+func TestHookWrap(t *testing.T) {
+	event := HookEvent{
+		ClientRequestToken:    "token",
+		ActionInvocationPoint: HookInvocationPointCreatePreProvision,
+	}
+
+	for name, test := range map[string]struct {
+		response HookResponse
+		err      error
+		expected HookResponse
+	}{
+		"success": {
+			expected: HookResponse{HookStatus: HookStatusSuccess, ClientRequestToken: "token"},
+		},
+		"in progress": {
+			response: HookResponse{HookStatus: HookStatusInProgress, CallbackContext: map[string]interface{}{"scanId": "scan-42"}, CallbackDelaySeconds: 30},
+			expected: HookResponse{HookStatus: HookStatusInProgress, ClientRequestToken: "token", CallbackContext: map[string]interface{}{"scanId": "scan-42"}, CallbackDelaySeconds: 30},
+		},
+		"error": {
+			response: HookResponse{HookStatus: HookStatusInProgress, CallbackDelaySeconds: 30},
+			err:      errors.New("describe bucket: throttled"),
+			expected: HookResponse{HookStatus: HookStatusFailed, ErrorCode: HookErrorCodeInternalFailure, Message: "describe bucket: throttled", ClientRequestToken: "token"},
+		},
+		"hook error": {
+			err:      fmt.Errorf("checking MyBucket: %w", &HookError{Code: HookErrorCodeNonCompliant, Message: "bucket must be encrypted"}),
+			expected: HookResponse{HookStatus: HookStatusFailed, ErrorCode: HookErrorCodeNonCompliant, Message: "checking MyBucket: bucket must be encrypted", ClientRequestToken: "token"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fn := HookWrap(func(ctx context.Context, received HookEvent) (HookResponse, error) {
+				assert.Equal(t, event, received)
+				return test.response, test.err
+			})
+
+			response, err := fn(context.TODO(), event)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, response)
+		})
+	}
+}
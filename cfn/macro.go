@@ -0,0 +1,91 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package cfn
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+)
+
+// MacroStatus is the outcome of a CloudFormation macro invocation. CloudFormation
+// treats any status other than success as a failure.
+type MacroStatus string
+
+const (
+	MacroStatusSuccess MacroStatus = "SUCCESS"
+	MacroStatusFailure MacroStatus = "FAILURE"
+)
+
+// MacroEvent is a representation of a CloudFormation macro (template transform) invocation.
+// The values of Fragment are kept as received, so that they can be returned unchanged, see CopyFragment.
+// See https://docs.aws.amazon.com/AWSCloudFormation/latest/UserGuide/template-macros.html
+type MacroEvent struct {
+	Region                  string                     `json:"region"`
+	AccountID               string                     `json:"accountId"`
+	Fragment                map[string]json.RawMessage `json:"fragment"`
+	TransformID             string                     `json:"transformId"`
+	Params                  map[string]interface{}     `json:"params"`
+	RequestID               string                     `json:"requestId"`
+	TemplateParameterValues map[string]interface{}     `json:"templateParameterValues"`
+}
+
+// CopyFragment returns a copy of Fragment to modify and return from a MacroFunction.
+// The values are json.RawMessage, so the keys the function does not replace are sent
+// back to CloudFormation as they were received.
+func (e MacroEvent) CopyFragment() map[string]interface{} {
+	fragment := make(map[string]interface{}, len(e.Fragment))
+	for k, v := range e.Fragment {
+		fragment[k] = v
+	}
+	return fragment
+}
+
+// MacroResponse is a representation of the response expected by CloudFormation from a macro.
+type MacroResponse struct {
+	RequestID    string                 `json:"requestId"`
+	Status       MacroStatus            `json:"status"`
+	Fragment     map[string]interface{} `json:"fragment,omitempty"`
+	ErrorMessage string                 `json:"errorMessage,omitempty"`
+}
+
+// MacroFunction is a representation of the customer's macro function, which returns the processed fragment.
+type MacroFunction func(ctx context.Context, event MacroEvent) (fragment map[string]interface{}, err error)
+
+// MacroLambdaFunction is a standard form Lambda for a CloudFormation macro.
+type MacroLambdaFunction func(context.Context, MacroEvent) (MacroResponse, error)
+
+// MacroWrap returns a MacroLambdaFunction which is something lambda.Start() will understand.
+// The RequestID of the event is copied to the response. An error returned by the macro function is
+// reported to CloudFormation as FAILURE with the error as the message. A nil fragment leaves
+// the fragment of the event unchanged.
+//
+//	func myMacro(ctx context.Context, event cfn.MacroEvent) (map[string]interface{}, error) {
+//		fragment := event.CopyFragment()
+//		fragment["Description"] = "processed by my macro"
+//		return fragment, nil
+//	}
+//
+//	func main() {
+//		lambda.Start(cfn.MacroWrap(myMacro))
+//	}
+func MacroWrap(macroFunction MacroFunction) MacroLambdaFunction {
+	return func(ctx context.Context, event MacroEvent) (MacroResponse, error) {
+		response := MacroResponse{RequestID: event.RequestID}
+
+		fragment, err := macroFunction(ctx, event)
+		if err != nil {
+			log.Printf("macro failed: %s\n", err.Error())
+			response.Status = MacroStatusFailure
+			response.ErrorMessage = err.Error()
+			return response, nil
+		}
+		if fragment == nil {
+			fragment = event.CopyFragment()
+		}
+
+		response.Status = MacroStatusSuccess
+		response.Fragment = fragment
+		return response, nil
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package cfn
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil" //nolint: staticcheck
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readMacroEvent(t *testing.T) ([]byte, MacroEvent) {
+	inputJSON, err := ioutil.ReadFile("./testdata/macro-event.json")
+	require.NoError(t, err)
+	var event MacroEvent
+	require.NoError(t, json.Unmarshal(inputJSON, &event))
+	return inputJSON, event
+}
+
+func TestMacroEventMarshaling(t *testing.T) {
+	inputJSON, event := readMacroEvent(t)
+	assert.Equal(t, "123456789012::AddTags", event.TransformID)
+	assert.Equal(t, "team-orders", event.Params["Owner"])
+	assert.Equal(t, "prod", event.TemplateParameterValues["Environment"])
+
+	outputJSON, err := json.Marshal(event)
+	require.NoError(t, err)
+	test.AssertJsonsEqual(t, inputJSON, outputJSON)
+}
+
+func TestMacroResponseMarshaling(t *testing.T) {
+	for _, sampleFile := range []string{"macro-response-success.json", "macro-response-failure.json"} {
+		t.Run(sampleFile, func(t *testing.T) {
+			inputJSON, err := ioutil.ReadFile("./testdata/" + sampleFile)
+			require.NoError(t, err)
+
+			var response MacroResponse
+			require.NoError(t, json.Unmarshal(inputJSON, &response))
+
+			outputJSON, err := json.Marshal(response)
+			require.NoError(t, err)
+			test.AssertJsonsEqual(t, inputJSON, outputJSON)
+		})
+	}
+}
+
+func TestMacroMarshalingMalformedJson(t *testing.T) {
+	test.TestMalformedJson(t, MacroEvent{})
+}
+
+func TestMacroWrapSuccess(t *testing.T) {
+	_, event := readMacroEvent(t)
+
+	fn := MacroWrap(func(ctx context.Context, event MacroEvent) (map[string]interface{}, error) {
+		var resources map[string]map[string]interface{}
+		if err := json.Unmarshal(event.Fragment["Resources"], &resources); err != nil {
+			return nil, err
+		}
+		properties := resources["Topic"]["Properties"].(map[string]interface{})
+		properties["Tags"] = []map[string]interface{}{{"Key": "Owner", "Value": event.Params["Owner"]}}
+
+		fragment := event.CopyFragment()
+		fragment["Resources"] = resources
+		return fragment, nil
+	})
+
+	response, err := fn(context.TODO(), event)
+	require.NoError(t, err)
+	assert.Equal(t, "f4e3ae1a-7a51-4d74-a2d7-b3d8ce5e1f30", response.RequestID)
+	assert.Equal(t, MacroStatusSuccess, response.Status)
+	assert.Empty(t, response.ErrorMessage)
+
+	outputJSON, err := json.Marshal(response)
+	require.NoError(t, err)
+
+	// the keys the function did not touch are sent back as received, including numbers
+	// that do not fit a float64 and the order of nested keys
+	var untouched bytes.Buffer
+	require.NoError(t, json.Compact(&untouched, event.Fragment["Mappings"]))
+	assert.Contains(t, string(outputJSON), `"Mappings":`+untouched.String())
+	assert.Contains(t, string(outputJSON), `"MaxId":12345678901234567890,"Ratio":1.000000000000000000001`)
+
+	var output, expected struct {
+		Fragment struct {
+			Resources json.RawMessage
+		} `json:"fragment"`
+	}
+	require.NoError(t, json.Unmarshal(outputJSON, &output))
+	expectedJSON, err := ioutil.ReadFile("./testdata/macro-response-success.json")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(expectedJSON, &expected))
+	assert.JSONEq(t, string(expected.Fragment.Resources), string(output.Fragment.Resources))
+}
+
+func TestMacroWrapNilFragment(t *testing.T) {
+	_, event := readMacroEvent(t)
+	fn := MacroWrap(func(ctx context.Context, event MacroEvent) (map[string]interface{}, error) {
+		return nil, nil
+	})
+
+	response, err := fn(context.TODO(), event)
+	require.NoError(t, err)
+	assert.Equal(t, MacroStatusSuccess, response.Status)
+
+	fragment, err := json.Marshal(response.Fragment)
+	require.NoError(t, err)
+	original, err := json.Marshal(event.Fragment)
+	require.NoError(t, err)
+	assert.Equal(t, string(original), string(fragment))
+}
+
+func TestMacroWrapFailure(t *testing.T) {
+	_, event := readMacroEvent(t)
+	fn := MacroWrap(func(ctx context.Context, event MacroEvent) (map[string]interface{}, error) {
+		return event.CopyFragment(), errors.New("Owner parameter is required")
+	})
+
+	response, err := fn(context.TODO(), event)
+	require.NoError(t, err)
+
+	outputJSON, err := json.Marshal(response)
+	require.NoError(t, err)
+	expectedJSON, err := ioutil.ReadFile("./testdata/macro-response-failure.json")
+	require.NoError(t, err)
+	test.AssertJsonsEqual(t, expectedJSON, outputJSON)
+}
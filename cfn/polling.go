@@ -0,0 +1,203 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package cfn
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"runtime/debug"
+	"time"
+)
+
+const (
+	defaultPollingInterval     = 30 * time.Second
+	defaultPollingTotalTimeout = time.Hour
+)
+
+// timeNow is replaced in tests, to simulate the time passing between checks.
+var timeNow = time.Now
+
+// PollingEvent is the payload a PollingScheduler delivers back to the function, to check on an operation
+// started by PollingResource.OnEvent. Data is the state of the operation, as returned by OnEvent and
+// updated by IsComplete.
+type PollingEvent struct {
+	Event              Event                  `json:"Event"`
+	PhysicalResourceID string                 `json:"PhysicalResourceId"`
+	Data               map[string]interface{} `json:"Data,omitempty"`
+	NoEcho             bool                   `json:"NoEcho,omitempty"`
+	Attempt            int                    `json:"Attempt"`
+	Deadline           time.Time              `json:"Deadline"`
+}
+
+// PollingScheduler invokes the function again with event after delay, for example by sending a delayed
+// SQS message, creating a one-time EventBridge schedule, or from a Step Functions wait state.
+type PollingScheduler interface {
+	Schedule(ctx context.Context, event PollingEvent, delay time.Duration) error
+}
+
+// PollingLambdaFunction is a Lambda handler for a PollingResource. It accepts both the Event sent
+// by CloudFormation and the PollingEvent delivered by the PollingScheduler.
+type PollingLambdaFunction func(context.Context, json.RawMessage) (reason string, err error)
+
+// PollingResource implements a custom resource whose operation takes longer than a single invocation,
+// modeled on the CDK provider framework. OnEvent starts the operation and returns the PhysicalResourceID,
+// with the state of the operation in Data. IsComplete is then called with that state, immediately and
+// then every Interval through the Scheduler, until it reports done, or until the next check would come
+// more than TotalTimeout after OnEvent returned. Only then is the response sent to CloudFormation,
+// SUCCESS with the final state as Data, or FAILED. A panic in OnEvent or IsComplete is reported as FAILED,
+// with the panic value as the reason.
+//
+// The state is sent through the Scheduler as JSON, so its values must survive a round trip:
+// numbers are decoded as float64, and structs as map[string]interface{}.
+type PollingResource struct {
+	OnEvent CustomResourceFunctionV2
+	// IsComplete reports whether the operation is done. The returned data is merged into the state,
+	// both when done and to carry progress to the next call.
+	IsComplete func(ctx context.Context, event PollingEvent) (done bool, data map[string]interface{}, err error)
+	Scheduler  PollingScheduler
+
+	Interval     time.Duration // defaults to 30 seconds
+	TotalTimeout time.Duration // defaults to one hour, the default timeout of CloudFormation custom resources
+}
+
+// Handler returns a PollingLambdaFunction, which is something lambda.Start() will understand.
+//
+//	func main() {
+//		resource := &cfn.PollingResource{OnEvent: startDomain, IsComplete: domainReady, Scheduler: scheduler}
+//		lambda.Start(resource.Handler())
+//	}
+func (p *PollingResource) Handler() PollingLambdaFunction {
+	return p.handlerWithClient(http.DefaultClient)
+}
+
+func (p *PollingResource) handlerWithClient(client httpClient) PollingLambdaFunction {
+	return func(ctx context.Context, payload json.RawMessage) (reason string, err error) {
+		var probe struct {
+			Event json.RawMessage `json:"Event"`
+		}
+		if err = json.Unmarshal(payload, &probe); err != nil {
+			return
+		}
+
+		var r *Response
+		if probe.Event == nil {
+			var event Event
+			if err = json.Unmarshal(payload, &event); err != nil {
+				return
+			}
+			r = p.start(ctx, event)
+		} else {
+			var event PollingEvent
+			if err = json.Unmarshal(payload, &event); err != nil {
+				return
+			}
+			r = p.poll(ctx, event)
+		}
+		if r == nil {
+			// the next check is scheduled
+			return
+		}
+
+		err = r.sendWithRetry(ctx, client, defaultRetryPolicy)
+		if err != nil {
+			reason = err.Error()
+		}
+		return
+	}
+}
+
+// start runs OnEvent and then the first check, and returns the Response to send, or nil when
+// a later check is scheduled.
+func (p *PollingResource) start(ctx context.Context, event Event) *Response {
+	result, err := p.onEvent(ctx, event)
+	if result == nil {
+		result = &Result{}
+	}
+	if result.PhysicalResourceID == "" {
+		result.PhysicalResourceID = fallbackPhysicalResourceID(event)
+		log.Printf("PhysicalResourceID not set. Using fallback PhysicalResourceID: %s\n", result.PhysicalResourceID)
+	}
+	if err != nil {
+		return p.failed(ctx, event, result.PhysicalResourceID, err)
+	}
+
+	timeout := p.TotalTimeout
+	if timeout <= 0 {
+		timeout = defaultPollingTotalTimeout
+	}
+	return p.poll(ctx, PollingEvent{
+		Event:              event,
+		PhysicalResourceID: result.PhysicalResourceID,
+		Data:               result.Data,
+		NoEcho:             result.NoEcho,
+		Deadline:           timeNow().Add(timeout),
+	})
+}
+
+// poll runs IsComplete, and returns the Response to send, or nil when the next check is scheduled.
+func (p *PollingResource) poll(ctx context.Context, event PollingEvent) *Response {
+	event.Attempt++
+	done, data, err := p.isComplete(ctx, event)
+	if err != nil {
+		return p.failed(ctx, event.Event, event.PhysicalResourceID, err)
+	}
+	if len(data) > 0 && event.Data == nil {
+		event.Data = make(map[string]interface{}, len(data))
+	}
+	for k, v := range data {
+		event.Data[k] = v
+	}
+
+	if done {
+		r := NewResponse(&event.Event)
+		r.Status = StatusSuccess
+		r.PhysicalResourceID, r.Data, r.NoEcho = event.PhysicalResourceID, event.Data, event.NoEcho
+		return r
+	}
+
+	interval := p.Interval
+	if interval <= 0 {
+		interval = defaultPollingInterval
+	}
+	if timeNow().Add(interval).After(event.Deadline) {
+		return p.failed(ctx, event.Event, event.PhysicalResourceID,
+			fmt.Errorf("operation did not complete after %d checks, before %s", event.Attempt, event.Deadline.Format(time.RFC3339)))
+	}
+	if err := p.Scheduler.Schedule(ctx, event, interval); err != nil {
+		return p.failed(ctx, event.Event, event.PhysicalResourceID, fmt.Errorf("scheduling the next check: %w", err))
+	}
+	return nil
+}
+
+// onEvent calls OnEvent, returning a panic as an error, so that a FAILED response is sent
+// rather than leaving the stack waiting for a response.
+func (p *PollingResource) onEvent(ctx context.Context, event Event) (result *Result, err error) {
+	defer recoverCallback("OnEvent", &err)
+	return p.OnEvent(ctx, event)
+}
+
+// isComplete calls IsComplete, returning a panic as an error, like onEvent.
+func (p *PollingResource) isComplete(ctx context.Context, event PollingEvent) (done bool, data map[string]interface{}, err error) {
+	defer recoverCallback("IsComplete", &err)
+	return p.IsComplete(ctx, event)
+}
+
+// recoverCallback recovers a panic of the callback named name, logs its stack, and sets err to describe it.
+func recoverCallback(name string, err *error) {
+	if v := recover(); v != nil {
+		log.Printf("%s panicked: %v\n%s", name, v, debug.Stack())
+		*err = fmt.Errorf("%s panicked: %v", name, v)
+	}
+}
+
+func (p *PollingResource) failed(ctx context.Context, event Event, physicalResourceID string, err error) *Response {
+	log.Printf("sending status failed: %s\n", err.Error())
+	r := NewResponse(&event)
+	r.Status = StatusFailed
+	r.Reason = failureReason(ctx, err.Error())
+	r.PhysicalResourceID = physicalResourceID
+	return r
+}
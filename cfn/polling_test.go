@@ -0,0 +1,284 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package cfn
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeScheduler queues scheduled checks, and advances the fake clock by their delay when they are delivered.
+type fakeScheduler struct {
+	now     time.Time
+	pending []PollingEvent
+	delays  []time.Duration // of every scheduled check, delivered or not
+	err     error
+}
+
+func (s *fakeScheduler) Schedule(ctx context.Context, event PollingEvent, delay time.Duration) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.pending = append(s.pending, event)
+	s.delays = append(s.delays, delay)
+	return nil
+}
+
+// run invokes the handler with event, and then with every scheduled check, the way the scheduler would.
+func (s *fakeScheduler) run(t *testing.T, handler PollingLambdaFunction, event interface{}) {
+	payload, err := json.Marshal(event)
+	require.NoError(t, err)
+	_, err = handler(context.TODO(), payload)
+	require.NoError(t, err)
+
+	for delivered := 0; len(s.pending) > 0; delivered++ {
+		next := s.pending[0]
+		s.pending = s.pending[1:]
+		s.now = s.now.Add(s.delays[delivered])
+
+		payload, err := json.Marshal(next)
+		require.NoError(t, err)
+		_, err = handler(context.TODO(), payload)
+		require.NoError(t, err)
+	}
+}
+
+// useFakeClock makes the polling code read the time from s, until the returned function is called.
+func useFakeClock(s *fakeScheduler) (restore func()) {
+	s.now = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return s.now }
+	return func() { timeNow = time.Now }
+}
+
+func recordingClient(t *testing.T, responses *[]Response) *mockClient {
+	return &mockClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			*responses = append(*responses, extractResponseBody(t, req))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       nopCloser{bytes.NewBufferString("")},
+			}, nil
+		},
+	}
+}
+
+func TestPollingResourceCompletes(t *testing.T) {
+	scheduler := &fakeScheduler{}
+	defer useFakeClock(scheduler)()
+	var responses []Response
+
+	onEventCalls := 0
+	var checks []PollingEvent
+	resource := &PollingResource{
+		OnEvent: func(ctx context.Context, event Event) (*Result, error) {
+			onEventCalls++
+			return &Result{
+				PhysicalResourceID: "my-domain",
+				Data:               map[string]interface{}{"DomainArn": "arn:aws:es:us-east-1:123456789012:domain/my-domain"},
+			}, nil
+		},
+		IsComplete: func(ctx context.Context, event PollingEvent) (bool, map[string]interface{}, error) {
+			checks = append(checks, event)
+			if event.Attempt < 4 {
+				return false, map[string]interface{}{"Progress": event.Attempt}, nil
+			}
+			return true, map[string]interface{}{"Endpoint": "search-my-domain.us-east-1.es.amazonaws.com"}, nil
+		},
+		Scheduler: scheduler,
+		Interval:  time.Minute,
+	}
+
+	scheduler.run(t, resource.handlerWithClient(recordingClient(t, &responses)), testEvent)
+
+	assert.Equal(t, 1, onEventCalls)
+	assert.Equal(t, []time.Duration{time.Minute, time.Minute, time.Minute}, scheduler.delays)
+	require.Len(t, checks, 4)
+	for i, check := range checks {
+		assert.Equal(t, i+1, check.Attempt)
+		assert.Equal(t, "my-domain", check.PhysicalResourceID)
+		assert.Equal(t, *testEvent, check.Event)
+		assert.Equal(t, "arn:aws:es:us-east-1:123456789012:domain/my-domain", check.Data["DomainArn"])
+	}
+	// the state carried through the scheduler went through JSON
+	assert.Equal(t, float64(3), checks[3].Data["Progress"])
+
+	require.Len(t, responses, 1)
+	response := responses[0]
+	assert.Equal(t, StatusSuccess, response.Status)
+	assert.Equal(t, "my-domain", response.PhysicalResourceID)
+	assert.Equal(t, testEvent.RequestID, response.RequestID)
+	assert.Equal(t, map[string]interface{}{
+		"DomainArn": "arn:aws:es:us-east-1:123456789012:domain/my-domain",
+		"Progress":  float64(3),
+		"Endpoint":  "search-my-domain.us-east-1.es.amazonaws.com",
+	}, response.Data)
+}
+
+func TestPollingResourceCompletesImmediately(t *testing.T) {
+	scheduler := &fakeScheduler{}
+	var responses []Response
+	resource := &PollingResource{
+		OnEvent: func(ctx context.Context, event Event) (*Result, error) {
+			return nil, nil
+		},
+		IsComplete: func(ctx context.Context, event PollingEvent) (bool, map[string]interface{}, error) {
+			return true, nil, nil
+		},
+		Scheduler: scheduler,
+	}
+
+	scheduler.run(t, resource.handlerWithClient(recordingClient(t, &responses)), testEvent)
+
+	assert.Empty(t, scheduler.delays)
+	require.Len(t, responses, 1)
+	assert.Equal(t, StatusSuccess, responses[0].Status)
+	assert.Equal(t, testEvent.PhysicalResourceID, responses[0].PhysicalResourceID)
+}
+
+func TestPollingResourceTimeout(t *testing.T) {
+	scheduler := &fakeScheduler{}
+	defer useFakeClock(scheduler)()
+	var responses []Response
+
+	checks := 0
+	resource := &PollingResource{
+		OnEvent: func(ctx context.Context, event Event) (*Result, error) {
+			return &Result{PhysicalResourceID: "my-domain"}, nil
+		},
+		IsComplete: func(ctx context.Context, event PollingEvent) (bool, map[string]interface{}, error) {
+			checks++
+			return false, nil, nil
+		},
+		Scheduler:    scheduler,
+		Interval:     10 * time.Minute,
+		TotalTimeout: 25 * time.Minute,
+	}
+
+	scheduler.run(t, resource.handlerWithClient(recordingClient(t, &responses)), testEvent)
+
+	assert.Equal(t, 3, checks)
+	assert.Len(t, scheduler.delays, 2)
+	require.Len(t, responses, 1)
+	assert.Equal(t, StatusFailed, responses[0].Status)
+	assert.Equal(t, "my-domain", responses[0].PhysicalResourceID)
+	assert.Equal(t, "operation did not complete after 3 checks, before 2026-10-16T12:25:00Z", responses[0].Reason)
+}
+
+func TestPollingResourceFailures(t *testing.T) {
+	for name, test := range map[string]struct {
+		onEventErr      error
+		onEventPanic    interface{}
+		isCompleteErr   error
+		isCompletePanic interface{}
+		scheduleErr     error
+		expectedReason  string
+		expectedPhysID  string
+		expectedChecks  int
+		expectedOnEvent int
+	}{
+		"OnEvent fails": {
+			onEventErr:      errors.New("quota exceeded"),
+			expectedReason:  "quota exceeded",
+			expectedPhysID:  testEvent.PhysicalResourceID,
+			expectedOnEvent: 1,
+		},
+		"IsComplete fails": {
+			isCompleteErr:   errors.New("domain failed to create"),
+			expectedReason:  "domain failed to create",
+			expectedPhysID:  "my-domain",
+			expectedChecks:  1,
+			expectedOnEvent: 1,
+		},
+		"OnEvent panics": {
+			onEventPanic:    "index out of range",
+			expectedReason:  "OnEvent panicked: index out of range",
+			expectedPhysID:  testEvent.PhysicalResourceID,
+			expectedOnEvent: 1,
+		},
+		"IsComplete panics": {
+			isCompletePanic: errors.New("nil map"),
+			expectedReason:  "IsComplete panicked: nil map",
+			expectedPhysID:  "my-domain",
+			expectedChecks:  1,
+			expectedOnEvent: 1,
+		},
+		"scheduling fails": {
+			scheduleErr:     errors.New("access denied"),
+			expectedReason:  "scheduling the next check: access denied",
+			expectedPhysID:  "my-domain",
+			expectedChecks:  1,
+			expectedOnEvent: 1,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			scheduler := &fakeScheduler{err: test.scheduleErr}
+			var responses []Response
+			onEventCalls, checks := 0, 0
+			resource := &PollingResource{
+				OnEvent: func(ctx context.Context, event Event) (*Result, error) {
+					onEventCalls++
+					if test.onEventPanic != nil {
+						panic(test.onEventPanic)
+					}
+					if test.onEventErr != nil {
+						return nil, test.onEventErr
+					}
+					return &Result{PhysicalResourceID: "my-domain"}, nil
+				},
+				IsComplete: func(ctx context.Context, event PollingEvent) (bool, map[string]interface{}, error) {
+					checks++
+					if test.isCompletePanic != nil {
+						panic(test.isCompletePanic)
+					}
+					return false, nil, test.isCompleteErr
+				},
+				Scheduler: scheduler,
+			}
+
+			scheduler.run(t, resource.handlerWithClient(recordingClient(t, &responses)), testEvent)
+
+			assert.Equal(t, test.expectedOnEvent, onEventCalls)
+			assert.Equal(t, test.expectedChecks, checks)
+			require.Len(t, responses, 1)
+			assert.Equal(t, StatusFailed, responses[0].Status)
+			assert.Equal(t, test.expectedReason, responses[0].Reason)
+			assert.Equal(t, test.expectedPhysID, responses[0].PhysicalResourceID)
+		})
+	}
+}
+
+func TestPollingResourceSendFailure(t *testing.T) {
+	scheduler := &fakeScheduler{}
+	resource := &PollingResource{
+		OnEvent: func(ctx context.Context, event Event) (*Result, error) {
+			return nil, nil
+		},
+		IsComplete: func(ctx context.Context, event PollingEvent) (bool, map[string]interface{}, error) {
+			return true, nil, nil
+		},
+		Scheduler: scheduler,
+	}
+	client := &mockClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusForbidden,
+				Body:       nopCloser{bytes.NewBufferString("")},
+			}, nil
+		},
+	}
+
+	payload, err := json.Marshal(testEvent)
+	require.NoError(t, err)
+	reason, err := resource.handlerWithClient(client)(context.TODO(), payload)
+	assert.Error(t, err)
+	assert.True(t, strings.HasPrefix(reason, "invalid status code. got: 403"))
+}
@@ -4,11 +4,17 @@ package cfn
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil" //nolint: staticcheck
 	"log"
+	"math/rand"
 	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aws/aws-lambda-go/lambdacontext"
 )
 
 // StatusType represents a CloudFormation response status
@@ -50,36 +56,95 @@ type httpClient interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
+// retryPolicy controls how sending a Response is retried after a transient failure.
+type retryPolicy struct {
+	maxAttempts int
+	baseDelay   time.Duration
+}
+
+// defaultRetryPolicy is used by LambdaWrap, the S3 pre-signed URL usually recovers from errors within a few seconds.
+var defaultRetryPolicy = retryPolicy{maxAttempts: 5, baseDelay: 100 * time.Millisecond}
+
+// delay returns a random delay before the given retry, with an exponentially growing upper bound.
+func (p retryPolicy) delay(retry int) time.Duration {
+	limit := p.baseDelay << (retry - 1)
+	if limit <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(limit))) // nolint:gosec
+}
+
 func (r *Response) sendWith(client httpClient) error {
+	return r.sendWithRetry(context.Background(), client, retryPolicy{maxAttempts: 1})
+}
+
+// sendWithRetry sends the Response, retrying network errors and 5xx status codes
+// until policy.maxAttempts is reached or ctx is done. A request in flight when ctx is done is aborted.
+func (r *Response) sendWithRetry(ctx context.Context, client httpClient, policy retryPolicy) error {
 	body, err := json.Marshal(r)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest(http.MethodPut, r.url, bytes.NewBuffer(body))
+	for attempt := 1; ; attempt++ {
+		retryable, err := r.put(ctx, client, body)
+		if err == nil || !retryable || attempt >= policy.maxAttempts {
+			return err
+		}
+		delay := policy.delay(attempt)
+		log.Printf("sending response failed, retrying in %v: %v\n", delay, err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
+
+// put sends body to the response URL once, and reports whether a failure may be retried.
+// The request is aborted when ctx is done.
+func (r *Response) put(ctx context.Context, client httpClient, body []byte) (retryable bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, r.url, bytes.NewReader(body))
 	if err != nil {
-		return err
+		return false, err
 	}
 	req.Header.Del("Content-Type")
 
 	res, err := client.Do(req)
 	if err != nil {
-		return err
+		return true, err
 	}
 
-	body, err = ioutil.ReadAll(res.Body)
+	resBody, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
 	if err != nil {
-		return err
+		return true, err
 	}
-	res.Body.Close()
 
 	if res.StatusCode != 200 {
-		log.Printf("StatusCode: %d\nBody: %v\n", res.StatusCode, string(body))
-		return fmt.Errorf("invalid status code. got: %d", res.StatusCode)
+		log.Printf("StatusCode: %d\nBody: %v\n", res.StatusCode, string(resBody))
+		return res.StatusCode >= 500, &ResponseSendError{StatusCode: res.StatusCode, Body: truncate(string(resBody), maxResponseBodyLength)}
 	}
 
-	return nil
+	return false, nil
+}
+
+// maxResponseBodyLength is the longest response body kept in a ResponseSendError, in bytes.
+const maxResponseBodyLength = 1024
 
+// ResponseSendError is returned when the pre-signed response URL rejects the Response.
+// Body holds the start of the response, for S3 an XML document whose Code tells an
+// expired URL (AccessDenied) from a malformed request.
+type ResponseSendError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *ResponseSendError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("invalid status code. got: %d", e.StatusCode)
+	}
+	return fmt.Sprintf("invalid status code. got: %d: %s", e.StatusCode, e.Body)
 }
 
 // Send will send the Response to the given URL using the
@@ -87,3 +152,31 @@ func (r *Response) sendWith(client httpClient) error {
 func (r *Response) Send() error {
 	return r.sendWith(http.DefaultClient)
 }
+
+// maxReasonLength is the longest Reason sent to CloudFormation, in bytes. CloudFormation drops responses
+// larger than 4096 bytes, which leaves the stack waiting, so the Reason is kept well below that to leave room for Data.
+const maxReasonLength = 1024
+
+// failureReason returns reason truncated to maxReasonLength, followed by where to find the logs of the
+// invocation when ctx carries a Lambda context.
+func failureReason(ctx context.Context, reason string) string {
+	var hint string
+	if lc, ok := lambdacontext.FromContext(ctx); ok {
+		hint = fmt.Sprintf(" See CloudWatch log group %s stream %s request %s", lambdacontext.LogGroupName, lambdacontext.LogStreamName, lc.AwsRequestID)
+	}
+	return truncate(reason, maxReasonLength-len(hint)) + hint
+}
+
+// truncate shortens s to at most limit bytes, marking the cut with an ellipsis.
+func truncate(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	const ellipsis = "..."
+	cut := limit - len(ellipsis)
+	// don't split a multi-byte character
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + ellipsis
+}
@@ -82,6 +82,7 @@ func TestRequestForbidden(t *testing.T) {
 
 	s := r.sendWith(client)
 	if assert.Error(t, s) {
-		assert.Equal(t, fmt.Errorf("invalid status code. got: %d", sc), s)
+		assert.Equal(t, &ResponseSendError{StatusCode: sc}, s)
+		assert.EqualError(t, s, fmt.Sprintf("invalid status code. got: %d", sc))
 	}
 }
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 )
@@ -24,30 +25,136 @@ type SNSCustomResourceLambdaFunction func(context.Context, events.SNSEvent) (rea
 // to CloudFormation.
 type CustomResourceFunction func(context.Context, Event) (physicalResourceID string, data map[string]interface{}, err error)
 
-func lambdaWrapWithClient(lambdaFunction CustomResourceFunction, client httpClient) (fn CustomResourceLambdaFunction) {
+// Result is the outcome of a CustomResourceFunctionV2.
+// When NoEcho is true, CloudFormation masks the Data values in the console and in DescribeStacks output.
+type Result struct {
+	PhysicalResourceID string
+	Data               map[string]interface{}
+	NoEcho             bool
+}
+
+// CustomResourceFunctionV2 is a representation of the customer's Custom Resource function.
+// LambdaWrapV2 will take the returned Result and turn it into a response to be sent
+// to CloudFormation. A nil Result is treated as an empty one.
+type CustomResourceFunctionV2 func(context.Context, Event) (*Result, error)
+
+type wrapOptions struct {
+	retry        retryPolicy
+	timeoutGrace time.Duration
+}
+
+// WrapOption configures the response handling of LambdaWrapWithOptions and LambdaWrapV2.
+type WrapOption func(*wrapOptions)
+
+// WithRetryPolicy sets how many times, at most, sending the response to CloudFormation is attempted,
+// and the base of the exponential backoff between attempts. Each delay is chosen at random, up to
+// baseDelay doubled for each previous retry. Network errors and 5xx status codes are retried,
+// other status codes, such as 403 for an expired pre-signed URL, are not.
+// Retries stop early when the invocation context is done. The default is 5 attempts with a 100ms base delay.
+func WithRetryPolicy(maxAttempts int, baseDelay time.Duration) WrapOption {
+	return func(o *wrapOptions) {
+		o.retry = retryPolicy{maxAttempts: maxAttempts, baseDelay: baseDelay}
+	}
+}
+
+// WithTimeoutGrace sends a FAILED response with the reason "function timed out" when the custom resource
+// function has not returned grace before the invocation deadline, instead of leaving CloudFormation waiting
+// for a response until its own timeout. The grace period should leave enough time to send the response.
+// The function keeps running in the background, and its late result is discarded.
+func WithTimeoutGrace(grace time.Duration) WrapOption {
+	return func(o *wrapOptions) {
+		o.timeoutGrace = grace
+	}
+}
+
+// errTimedOut is reported to CloudFormation when the function does not return in time, see WithTimeoutGrace.
+var errTimedOut = errors.New("function timed out")
+
+// call runs lambdaFunction, giving up grace before the deadline of ctx when WithTimeoutGrace is set.
+// A panic in lambdaFunction is re-raised in the calling goroutine.
+func (o *wrapOptions) call(ctx context.Context, lambdaFunction CustomResourceFunctionV2, event Event) (*Result, error) {
+	deadline, ok := ctx.Deadline()
+	if o.timeoutGrace <= 0 || !ok {
+		return lambdaFunction(ctx, event)
+	}
+
+	type outcome struct {
+		result    *Result
+		err       error
+		didPanic  bool
+		panicWith interface{}
+	}
+	// buffered, so that a late outcome does not block the function's goroutine
+	done := make(chan outcome, 1)
+	go func() {
+		out := outcome{didPanic: true}
+		defer func() {
+			if out.didPanic {
+				out.panicWith = recover()
+			}
+			done <- out
+		}()
+		out.result, out.err = lambdaFunction(ctx, event)
+		out.didPanic = false
+	}()
+
+	timer := time.NewTimer(time.Until(deadline.Add(-o.timeoutGrace)))
+	defer timer.Stop()
+	select {
+	case out := <-done:
+		if out.didPanic {
+			panic(out.panicWith)
+		}
+		return out.result, out.err
+	case <-timer.C:
+		return nil, errTimedOut
+	}
+}
+
+// fallbackPhysicalResourceID is the PhysicalResourceID sent when the function does not set one.
+func fallbackPhysicalResourceID(event Event) string {
+	// A previous physical resource id exists unless this is a create request.
+	if event.RequestType == RequestCreate {
+		// If this is a create request, the fallback should be the request ID
+		return event.RequestID
+	}
+	return event.PhysicalResourceID
+}
+
+func lambdaWrapWithClient(lambdaFunction CustomResourceFunction, client httpClient, options ...WrapOption) (fn CustomResourceLambdaFunction) {
+	return lambdaWrapV2WithClient(func(ctx context.Context, event Event) (*Result, error) {
+		physicalResourceID, data, err := lambdaFunction(ctx, event)
+		return &Result{PhysicalResourceID: physicalResourceID, Data: data}, err
+	}, client, options...)
+}
+
+func lambdaWrapV2WithClient(lambdaFunction CustomResourceFunctionV2, client httpClient, options ...WrapOption) (fn CustomResourceLambdaFunction) {
+	o := &wrapOptions{retry: defaultRetryPolicy}
+	for _, option := range options {
+		option(o)
+	}
+
 	fn = func(ctx context.Context, event Event) (reason string, err error) {
 		r := NewResponse(&event)
 
-		// A previous physical resource id exists unless this is a create request.
-		fallbackPhysicalResourceID := event.PhysicalResourceID
-		if event.RequestType == RequestCreate {
-			// If this is a create request, the fallback should be the request ID
-			fallbackPhysicalResourceID = event.RequestID
-		}
+		fallbackPhysicalResourceID := fallbackPhysicalResourceID(event)
 
 		funcDidPanic := true
 		defer func() {
 			if funcDidPanic {
 				r.Status = StatusFailed
-				r.Reason = "Function panicked, see log stream for details"
+				r.Reason = failureReason(ctx, "Function panicked, see log stream for details")
 				r.PhysicalResourceID = fallbackPhysicalResourceID
 				// FIXME: something should be done if an error is returned here
-				_ = r.sendWith(client)
+				_ = r.sendWithRetry(ctx, client, o.retry)
 			}
 		}()
 
-		r.PhysicalResourceID, r.Data, err = lambdaFunction(ctx, event)
+		result, err := o.call(ctx, lambdaFunction, event)
 		funcDidPanic = false
+		if result != nil {
+			r.PhysicalResourceID, r.Data, r.NoEcho = result.PhysicalResourceID, result.Data, result.NoEcho
+		}
 
 		if r.PhysicalResourceID == "" {
 			r.PhysicalResourceID = fallbackPhysicalResourceID
@@ -56,13 +163,13 @@ func lambdaWrapWithClient(lambdaFunction CustomResourceFunction, client httpClie
 
 		if err != nil {
 			r.Status = StatusFailed
-			r.Reason = err.Error()
-			log.Printf("sending status failed: %s\n", r.Reason)
+			r.Reason = failureReason(ctx, err.Error())
+			log.Printf("sending status failed: %s\n", err.Error())
 		} else {
 			r.Status = StatusSuccess
 		}
 
-		err = r.sendWith(client)
+		err = r.sendWithRetry(ctx, client, o.retry)
 		if err != nil {
 			reason = err.Error()
 		}
@@ -76,7 +183,8 @@ func lambdaWrapWithClient(lambdaFunction CustomResourceFunction, client httpClie
 // LambdaWrap returns a CustomResourceLambdaFunction which is something lambda.Start()
 // will understand. The purpose of doing this is so that Response Handling boiler
 // plate is taken away from the customer and it makes writing a Custom Resource
-// simpler.
+// simpler. Sending the response is retried on transient failures, see WithRetryPolicy,
+// and is aborted when the context of the invocation is done.
 //
 //	func myLambda(ctx context.Context, event cfn.Event) (physicalResourceID string, data map[string]interface{}, err error) {
 //		physicalResourceID = "arn:...."
@@ -90,6 +198,39 @@ func LambdaWrap(lambdaFunction CustomResourceFunction) (fn CustomResourceLambdaF
 	return lambdaWrapWithClient(lambdaFunction, http.DefaultClient)
 }
 
+// LambdaWrapWithOptions is like LambdaWrap, with options for sending the response, such as WithRetryPolicy.
+func LambdaWrapWithOptions(lambdaFunction CustomResourceFunction, options ...WrapOption) (fn CustomResourceLambdaFunction) {
+	return lambdaWrapWithClient(lambdaFunction, http.DefaultClient, options...)
+}
+
+// LambdaWrapWithHTTPClient is like LambdaWrapWithOptions, but sends the response to CloudFormation with client
+// instead of http.DefaultClient, for example to go through a proxy or to trust a custom certificate authority.
+// A nil client is replaced by http.DefaultClient.
+func LambdaWrapWithHTTPClient(lambdaFunction CustomResourceFunction, client *http.Client, options ...WrapOption) (fn CustomResourceLambdaFunction) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return lambdaWrapWithClient(lambdaFunction, client, options...)
+}
+
+// LambdaWrapV2 is like LambdaWrap, but takes a CustomResourceFunctionV2, whose Result
+// can also set NoEcho to mask the returned Data.
+//
+//	func myLambda(ctx context.Context, event cfn.Event) (*cfn.Result, error) {
+//		return &cfn.Result{
+//			PhysicalResourceID: "arn:....",
+//			Data:               map[string]interface{}{"Password": password},
+//			NoEcho:             true,
+//		}, nil
+//	}
+//
+//	func main() {
+//		lambda.Start(cfn.LambdaWrapV2(myLambda))
+//	}
+func LambdaWrapV2(lambdaFunction CustomResourceFunctionV2, options ...WrapOption) (fn CustomResourceLambdaFunction) {
+	return lambdaWrapV2WithClient(lambdaFunction, http.DefaultClient, options...)
+}
+
 // LambdaWrapSNS wraps a Lambda handler with support for SNS-based custom
 // resources. Usage and purpose otherwise same as LambdaWrap().
 func LambdaWrapSNS(lambdaFunction CustomResourceFunction) SNSCustomResourceLambdaFunction {
@@ -10,8 +10,14 @@ import (
 	"fmt"
 	"io/ioutil" //nolint: staticcheck
 	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+	"unicode/utf8"
 
+	"github.com/aws/aws-lambda-go/lambdacontext"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -204,6 +210,325 @@ func TestWrappedSendFailure(t *testing.T) {
 	r, e := lambdaWrapWithClient(fn, client)(context.TODO(), *testEvent)
 	assert.NotNil(t, e)
 	assert.Equal(t, "things went wrong", r)
+
+	const s3Error = `<?xml version="1.0" encoding="UTF-8"?>
+<Error><Code>AccessDenied</Code><Message>Request has expired</Message><Expires>2026-10-16T10:00:00Z</Expires><RequestId>656c76696e6727732072657175657374</RequestId></Error>`
+	client = &mockClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusForbidden,
+				Body:       nopCloser{bytes.NewBufferString(s3Error)},
+			}, nil
+		},
+	}
+
+	r, e = lambdaWrapWithClient(fn, client)(context.TODO(), *testEvent)
+	var sendErr *ResponseSendError
+	if assert.True(t, errors.As(e, &sendErr)) {
+		assert.Equal(t, http.StatusForbidden, sendErr.StatusCode)
+		assert.Equal(t, s3Error, sendErr.Body)
+	}
+	assert.Equal(t, "invalid status code. got: 403: "+s3Error, r)
+
+	client = &mockClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Body:       nopCloser{bytes.NewBufferString(strings.Repeat("x", 5000))},
+			}, nil
+		},
+	}
+
+	_, e = lambdaWrapWithClient(fn, client)(context.TODO(), *testEvent)
+	if assert.True(t, errors.As(e, &sendErr)) {
+		assert.Equal(t, http.StatusBadRequest, sendErr.StatusCode)
+		assert.Len(t, sendErr.Body, maxResponseBodyLength)
+		assert.True(t, strings.HasSuffix(sendErr.Body, "..."))
+	}
+}
+
+func TestWrapV2NoEcho(t *testing.T) {
+	for _, noEcho := range []bool{true, false} {
+		var body map[string]interface{}
+		client := &mockClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				b, err := ioutil.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.NoError(t, json.Unmarshal(b, &body))
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       nopCloser{bytes.NewBufferString("")},
+				}, nil
+			},
+		}
+
+		fn := func(ctx context.Context, event Event) (*Result, error) {
+			return &Result{
+				PhysicalResourceID: "testingtesting",
+				Data:               map[string]interface{}{"Password": "hunter2"},
+				NoEcho:             noEcho,
+			}, nil
+		}
+
+		_, err := lambdaWrapV2WithClient(fn, client)(context.TODO(), *testEvent)
+		assert.NoError(t, err)
+		assert.Equal(t, "SUCCESS", body["Status"])
+		assert.Equal(t, "testingtesting", body["PhysicalResourceId"])
+		assert.Equal(t, map[string]interface{}{"Password": "hunter2"}, body["Data"])
+		if noEcho {
+			assert.Equal(t, true, body["NoEcho"])
+		} else {
+			assert.NotContains(t, body, "NoEcho")
+		}
+	}
+}
+
+func TestWrapV2NilResult(t *testing.T) {
+	client := &mockClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			response := extractResponseBody(t, req)
+
+			assert.Equal(t, StatusSuccess, response.Status)
+			assert.Equal(t, testEvent.PhysicalResourceID, response.PhysicalResourceID)
+			assert.False(t, response.NoEcho)
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       nopCloser{bytes.NewBufferString("")},
+			}, nil
+		},
+	}
+
+	fn := func(ctx context.Context, event Event) (*Result, error) {
+		return nil, nil
+	}
+
+	_, err := lambdaWrapV2WithClient(fn, client)(context.TODO(), *testEvent)
+	assert.NoError(t, err)
+}
+
+func TestWrappedSendRetries(t *testing.T) {
+	for name, test := range map[string]struct {
+		responses     []int // status codes, 0 for a network error
+		expectedCalls int
+		expectedErr   string
+	}{
+		"success":                 {[]int{200}, 1, ""},
+		"network error recovers":  {[]int{0, 0, 200}, 3, ""},
+		"5xx recovers":            {[]int{500, 503, 200}, 3, ""},
+		"5xx exhausts attempts":   {[]int{500, 500, 500, 500}, 4, "invalid status code. got: 500"},
+		"network error exhausted": {[]int{0, 0, 0, 0}, 4, "connection reset by peer"},
+		"403 is not retried":      {[]int{403, 200}, 1, "invalid status code. got: 403"},
+		"404 is not retried":      {[]int{404, 200}, 1, "invalid status code. got: 404"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			client := &mockClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					// the body must be complete on every attempt
+					response := extractResponseBody(t, req)
+					assert.Equal(t, StatusSuccess, response.Status)
+					assert.Equal(t, testEvent.LogicalResourceID, response.LogicalResourceID)
+
+					status := test.responses[calls]
+					calls++
+					if status == 0 {
+						return nil, errors.New("connection reset by peer")
+					}
+					return &http.Response{
+						StatusCode: status,
+						Body:       nopCloser{bytes.NewBufferString("")},
+					}, nil
+				},
+			}
+
+			fn := func(ctx context.Context, event Event) (physicalResourceID string, data map[string]interface{}, err error) {
+				return
+			}
+
+			reason, err := lambdaWrapWithClient(fn, client, WithRetryPolicy(4, time.Millisecond))(context.TODO(), *testEvent)
+			assert.Equal(t, test.expectedCalls, calls)
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedErr)
+				assert.Equal(t, test.expectedErr, reason)
+			}
+		})
+	}
+}
+
+func TestWrappedSendRetriesStopWhenContextDone(t *testing.T) {
+	calls := 0
+	client := &mockClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			return nil, errors.New("connection reset by peer")
+		},
+	}
+
+	fn := func(ctx context.Context, event Event) (physicalResourceID string, data map[string]interface{}, err error) {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := lambdaWrapWithClient(fn, client, WithRetryPolicy(5, time.Hour))(ctx, *testEvent)
+	assert.EqualError(t, err, "connection reset by peer")
+	assert.Equal(t, 1, calls)
+}
+
+func TestWrappedTimeoutSendsFailure(t *testing.T) {
+	for _, requestType := range []RequestType{RequestCreate, RequestUpdate} {
+		var responses []Response
+		var mu sync.Mutex
+		client := &mockClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				responses = append(responses, extractResponseBody(t, req))
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       nopCloser{bytes.NewBufferString("")},
+				}, nil
+			},
+		}
+
+		release := make(chan struct{})
+		returned := make(chan struct{})
+		fn := func(ctx context.Context, event Event) (physicalResourceID string, data map[string]interface{}, err error) {
+			defer close(returned)
+			<-release
+			return "latePhysicalResourceID", nil, nil
+		}
+
+		event := *testEvent
+		event.RequestType = requestType
+		expectedPhysicalResourceID := event.PhysicalResourceID
+		if requestType == RequestCreate {
+			event.PhysicalResourceID = ""
+			expectedPhysicalResourceID = event.RequestID
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		_, err := lambdaWrapWithClient(fn, client, WithTimeoutGrace(50*time.Millisecond))(ctx, event)
+		cancel()
+		assert.NoError(t, err)
+
+		// the late result is discarded
+		close(release)
+		<-returned
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		assert.Len(t, responses, 1)
+		assert.Equal(t, StatusFailed, responses[0].Status)
+		assert.Equal(t, "function timed out", responses[0].Reason)
+		assert.Equal(t, expectedPhysicalResourceID, responses[0].PhysicalResourceID)
+		mu.Unlock()
+	}
+}
+
+func TestWrappedTimeoutGraceFunctionReturnsInTime(t *testing.T) {
+	calls := 0
+	client := &mockClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			response := extractResponseBody(t, req)
+			assert.Equal(t, StatusSuccess, response.Status)
+			assert.Equal(t, "testingtesting", response.PhysicalResourceID)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       nopCloser{bytes.NewBufferString("")},
+			}, nil
+		},
+	}
+
+	fn := func(ctx context.Context, event Event) (physicalResourceID string, data map[string]interface{}, err error) {
+		return "testingtesting", nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := lambdaWrapWithClient(fn, client, WithTimeoutGrace(100*time.Millisecond))(ctx, *testEvent)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestWrappedTimeoutGracePanicSendsFailure(t *testing.T) {
+	calls := 0
+	client := &mockClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			response := extractResponseBody(t, req)
+			assert.Equal(t, StatusFailed, response.Status)
+			assert.Equal(t, "Function panicked, see log stream for details", response.Reason)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       nopCloser{bytes.NewBufferString("")},
+			}, nil
+		},
+	}
+
+	fn := func(ctx context.Context, event Event) (physicalResourceID string, data map[string]interface{}, err error) {
+		panic("a panic")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.PanicsWithValue(t, "a panic", func() {
+		_, _ = lambdaWrapWithClient(fn, client, WithTimeoutGrace(100*time.Millisecond))(ctx, *testEvent)
+	})
+	assert.Equal(t, 1, calls)
+}
+
+func TestWrappedLongErrorIsTruncated(t *testing.T) {
+	defer func(group, stream string) {
+		lambdacontext.LogGroupName, lambdacontext.LogStreamName = group, stream
+	}(lambdacontext.LogGroupName, lambdacontext.LogStreamName)
+	lambdacontext.LogGroupName = "/aws/lambda/my-custom-resource"
+	lambdacontext.LogStreamName = "2026/03/14/[$LATEST]0123456789abcdef"
+
+	var body map[string]interface{}
+	client := &mockClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			b, err := ioutil.ReadAll(req.Body)
+			assert.NoError(t, err)
+			assert.NoError(t, json.Unmarshal(b, &body))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       nopCloser{bytes.NewBufferString("")},
+			}, nil
+		},
+	}
+
+	fn := func(ctx context.Context, event Event) (physicalResourceID string, data map[string]interface{}, err error) {
+		err = fmt.Errorf("failed to create resource: %s", strings.Repeat("é", 4096))
+		return
+	}
+
+	ctx := lambdacontext.NewContext(context.TODO(), &lambdacontext.LambdaContext{AwsRequestID: "request-123"})
+	_, err := lambdaWrapWithClient(fn, client)(ctx, *testEvent)
+	assert.NoError(t, err)
+
+	reason := body["Reason"].(string)
+	assert.LessOrEqual(t, len(reason), maxReasonLength)
+	assert.True(t, utf8.ValidString(reason))
+	assert.True(t, strings.HasPrefix(reason, "failed to create resource: éé"))
+	assert.True(t, strings.HasSuffix(reason, "... See CloudWatch log group /aws/lambda/my-custom-resource stream 2026/03/14/[$LATEST]0123456789abcdef request request-123"), reason)
+}
+
+func TestFailureReason(t *testing.T) {
+	assert.Equal(t, "short", failureReason(context.TODO(), "short"))
+
+	ctx := lambdacontext.NewContext(context.TODO(), &lambdacontext.LambdaContext{AwsRequestID: "request-123"})
+	assert.True(t, strings.HasPrefix(failureReason(ctx, "short"), "short See CloudWatch log group "))
+	assert.True(t, strings.HasSuffix(failureReason(ctx, "short"), " request request-123"))
+
+	long := failureReason(context.TODO(), strings.Repeat("x", 5000))
+	assert.Len(t, long, maxReasonLength)
+	assert.True(t, strings.HasSuffix(long, "x..."))
 }
 
 func extractResponseBody(t *testing.T, req *http.Request) Response {
@@ -216,3 +541,64 @@ func extractResponseBody(t *testing.T, req *http.Request) Response {
 	assert.NoError(t, err)
 	return response
 }
+
+func TestWrappedSendIsCancelledWithTheInvocation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := &mockClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, ctx.Done(), req.Context().Done())
+			// the invocation is cancelled while the response is sent
+			cancel()
+			<-req.Context().Done()
+			return nil, req.Context().Err()
+		},
+	}
+
+	fn := func(ctx context.Context, event Event) (physicalResourceID string, data map[string]interface{}, err error) {
+		return
+	}
+
+	_, err := lambdaWrapWithClient(fn, client, WithRetryPolicy(5, time.Hour))(ctx, *testEvent)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestLambdaWrapWithHTTPClient(t *testing.T) {
+	var received []Response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		var response Response
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&response))
+		received = append(received, response)
+	}))
+	defer server.Close()
+
+	// the response URL is only reachable through the custom client
+	var transported []string
+	client := &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		transported = append(transported, req.URL.String())
+		req.URL.Scheme = "http"
+		req.URL.Host = strings.TrimPrefix(server.URL, "http://")
+		return http.DefaultTransport.RoundTrip(req)
+	})}
+
+	event := *testEvent
+	event.ResponseURL = "https://pre-signed-S3-url-for-response.invalid/response"
+	fn := func(ctx context.Context, event Event) (physicalResourceID string, data map[string]interface{}, err error) {
+		return "custom-client", nil, nil
+	}
+	reason, err := LambdaWrapWithHTTPClient(fn, client)(context.Background(), event)
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+	assert.Equal(t, []string{event.ResponseURL}, transported)
+	if assert.Len(t, received, 1) {
+		assert.Equal(t, StatusSuccess, received[0].Status)
+		assert.Equal(t, "custom-client", received[0].PhysicalResourceID)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,66 @@
+//go:build go1.18
+// +build go1.18
+
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package cfn
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// CustomResourceTypedFunction is a Custom Resource function that receives the ResourceProperties of the event
+// decoded into P, and the OldResourceProperties decoded into *P, which is nil unless the event is an Update.
+type CustomResourceTypedFunction[P any] func(ctx context.Context, event Event, props P, oldProps *P) (physicalResourceID string, data map[string]interface{}, err error)
+
+// LambdaWrapTyped is like LambdaWrap, but decodes the ResourceProperties and OldResourceProperties of the event
+// into P before calling lambdaFunction. If decoding fails, a FAILED response describing the error is sent
+// without calling lambdaFunction.
+//
+// CloudFormation passes all scalar property values as strings, so numeric and boolean fields of P
+// need the ",string" JSON tag option.
+//
+//	type BucketProperties struct {
+//		BucketName string
+//		Versioned  bool `json:",string"`
+//	}
+//
+//	func myLambda(ctx context.Context, event cfn.Event, props BucketProperties, oldProps *BucketProperties) (physicalResourceID string, data map[string]interface{}, err error) {
+//		physicalResourceID = props.BucketName
+//		return
+//	}
+//
+//	func main() {
+//		lambda.Start(cfn.LambdaWrapTyped(myLambda))
+//	}
+func LambdaWrapTyped[P any](lambdaFunction CustomResourceTypedFunction[P], options ...WrapOption) CustomResourceLambdaFunction {
+	return lambdaWrapWithClient(decodeProperties(lambdaFunction), http.DefaultClient, options...)
+}
+
+func decodeProperties[P any](lambdaFunction CustomResourceTypedFunction[P]) CustomResourceFunction {
+	return func(ctx context.Context, event Event) (string, map[string]interface{}, error) {
+		var props P
+		if err := remarshal(event.ResourceProperties, &props); err != nil {
+			return "", nil, fmt.Errorf("decoding ResourceProperties: %w", err)
+		}
+		var oldProps *P
+		if event.RequestType == RequestUpdate && event.OldResourceProperties != nil {
+			oldProps = new(P)
+			if err := remarshal(event.OldResourceProperties, oldProps); err != nil {
+				return "", nil, fmt.Errorf("decoding OldResourceProperties: %w", err)
+			}
+		}
+		return lambdaFunction(ctx, event, props, oldProps)
+	}
+}
+
+func remarshal(properties map[string]interface{}, v interface{}) error {
+	b, err := json.Marshal(properties)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
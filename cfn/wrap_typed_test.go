@@ -0,0 +1,112 @@
+//go:build go1.18
+// +build go1.18
+
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package cfn
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testProperties struct {
+	BucketName string
+	Versioned  bool `json:",string"`
+}
+
+func TestLambdaWrapTyped(t *testing.T) {
+	for name, test := range map[string]struct {
+		requestType           RequestType
+		properties            map[string]interface{}
+		oldProperties         map[string]interface{}
+		expectCalled          bool
+		expectProps           testProperties
+		expectOldProps        *testProperties
+		expectStatus          StatusType
+		expectReason          string // prefix, the rest of the message depends on the Go version
+		expectPhysicalResouce string
+	}{
+		"Create": {
+			requestType:           RequestCreate,
+			properties:            map[string]interface{}{"BucketName": "my-bucket", "Versioned": "true"},
+			expectCalled:          true,
+			expectProps:           testProperties{BucketName: "my-bucket", Versioned: true},
+			expectStatus:          StatusSuccess,
+			expectPhysicalResouce: "my-bucket",
+		},
+		"Update": {
+			requestType:           RequestUpdate,
+			properties:            map[string]interface{}{"BucketName": "my-bucket", "Versioned": "true"},
+			oldProperties:         map[string]interface{}{"BucketName": "my-bucket", "Versioned": "false"},
+			expectCalled:          true,
+			expectProps:           testProperties{BucketName: "my-bucket", Versioned: true},
+			expectOldProps:        &testProperties{BucketName: "my-bucket"},
+			expectStatus:          StatusSuccess,
+			expectPhysicalResouce: "my-bucket",
+		},
+		"Create with malformed properties": {
+			requestType:           RequestCreate,
+			properties:            map[string]interface{}{"BucketName": "my-bucket", "Versioned": "maybe"},
+			expectStatus:          StatusFailed,
+			expectReason:          "decoding ResourceProperties: json: ",
+			expectPhysicalResouce: testEvent.RequestID,
+		},
+		"Update with malformed properties": {
+			requestType:           RequestUpdate,
+			properties:            map[string]interface{}{"BucketName": []interface{}{"a", "b"}},
+			oldProperties:         map[string]interface{}{"BucketName": "my-bucket"},
+			expectStatus:          StatusFailed,
+			expectReason:          "decoding ResourceProperties: json: ",
+			expectPhysicalResouce: testEvent.PhysicalResourceID,
+		},
+		"Update with malformed old properties": {
+			requestType:           RequestUpdate,
+			properties:            map[string]interface{}{"BucketName": "my-bucket"},
+			oldProperties:         map[string]interface{}{"Versioned": "maybe"},
+			expectStatus:          StatusFailed,
+			expectReason:          "decoding OldResourceProperties: json: ",
+			expectPhysicalResouce: testEvent.PhysicalResourceID,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			event := *testEvent
+			event.RequestType = test.requestType
+			event.ResourceProperties = test.properties
+			event.OldResourceProperties = test.oldProperties
+			if test.requestType == RequestCreate {
+				event.PhysicalResourceID = ""
+			}
+
+			client := &mockClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					response := extractResponseBody(t, req)
+					assert.Equal(t, test.expectStatus, response.Status)
+					assert.True(t, strings.HasPrefix(response.Reason, test.expectReason), "unexpected reason %q", response.Reason)
+					assert.Equal(t, test.expectPhysicalResouce, response.PhysicalResourceID)
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       nopCloser{bytes.NewBufferString("")},
+					}, nil
+				},
+			}
+
+			called := false
+			fn := func(ctx context.Context, event Event, props testProperties, oldProps *testProperties) (physicalResourceID string, data map[string]interface{}, err error) {
+				called = true
+				assert.Equal(t, test.expectProps, props)
+				assert.Equal(t, test.expectOldProps, oldProps)
+				return props.BucketName, nil, nil
+			}
+
+			_, err := lambdaWrapWithClient(decodeProperties(fn), client)(context.TODO(), event)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectCalled, called)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// HTTPRequest returns r as an *http.Request with ctx, to serve it with an http.Handler.
+// Either the single or the multi-value query string parameters and headers are used, whichever the target group sends.
+// ALB does not decode the query string, so the parameters are kept as they were received, and the
+// values of the returned request's URL.Query() are decoded.
+func (r ALBTargetGroupRequest) HTTPRequest(ctx context.Context) (*http.Request, error) {
+	body := []byte(r.Body)
+	if r.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(r.Body)
+		if err != nil {
+			return nil, fmt.Errorf("decoding the base64 body of the ALB request: %w", err)
+		}
+		body = decoded
+	}
+
+	header := make(http.Header)
+	if len(r.MultiValueHeaders) > 0 {
+		for k, values := range r.MultiValueHeaders {
+			for _, v := range values {
+				header.Add(k, v)
+			}
+		}
+	} else {
+		for k, v := range r.Headers {
+			header.Add(k, v)
+		}
+	}
+
+	scheme := header.Get("X-Forwarded-Proto")
+	if scheme == "" {
+		scheme = "http"
+	}
+	requestURI := r.Path
+	if query := r.rawQuery(); query != "" {
+		requestURI += "?" + query
+	}
+
+	var bodyReader io.Reader = http.NoBody
+	if len(body) > 0 {
+		bodyReader = bytes.NewReader(body)
+	}
+	request, err := http.NewRequestWithContext(ctx, r.HTTPMethod, scheme+"://"+header.Get("Host")+requestURI, bodyReader)
+	if err != nil {
+		return nil, err
+	}
+	// as for the requests of an http.Server, the Host header is only kept in the Host field
+	header.Del("Host")
+	request.Header = header
+	request.RequestURI = requestURI
+	if forwardedFor := header.Get("X-Forwarded-For"); forwardedFor != "" {
+		// the client is the first of the addresses when the request went through proxies before the load balancer
+		request.RemoteAddr = strings.TrimSpace(strings.Split(forwardedFor, ",")[0])
+	}
+	return request, nil
+}
+
+// rawQuery returns the query string of r, with the parameters sorted by key.
+func (r ALBTargetGroupRequest) rawQuery() string {
+	params := r.MultiValueQueryStringParameters
+	if len(params) == 0 && len(r.QueryStringParameters) > 0 {
+		params = make(map[string][]string, len(r.QueryStringParameters))
+		for k, v := range r.QueryStringParameters {
+			params[k] = []string{v}
+		}
+	}
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var query strings.Builder
+	for _, k := range keys {
+		for _, v := range params[k] {
+			if query.Len() > 0 {
+				query.WriteByte('&')
+			}
+			query.WriteString(k)
+			query.WriteByte('=')
+			query.WriteString(v)
+		}
+	}
+	return query.String()
+}
+
+// NewALBTargetGroupResponse returns the response recorded by rec, for the requests converted by
+// ALBTargetGroupRequest.HTTPRequest. Both Headers and MultiValueHeaders are set, so that the response works whether
+// multi-value headers are enabled on the target group or not. As Headers holds a single value per header, it gets
+// the values joined by commas, or the first one for Set-Cookie, which cannot be joined.
+// Bodies that are not valid UTF-8 are base64 encoded.
+func NewALBTargetGroupResponse(rec *httptest.ResponseRecorder) ALBTargetGroupResponse {
+	result := rec.Result()
+	var body []byte
+	if rec.Body != nil {
+		body = rec.Body.Bytes()
+	}
+
+	response := ALBTargetGroupResponse{
+		StatusCode:        result.StatusCode,
+		StatusDescription: fmt.Sprintf("%d %s", result.StatusCode, http.StatusText(result.StatusCode)),
+		Headers:           make(map[string]string, len(result.Header)),
+		MultiValueHeaders: make(map[string][]string, len(result.Header)),
+	}
+	for k, values := range result.Header {
+		if len(values) == 0 {
+			continue
+		}
+		response.MultiValueHeaders[k] = values
+		if k == "Set-Cookie" {
+			response.Headers[k] = values[0]
+		} else {
+			response.Headers[k] = strings.Join(values, ", ")
+		}
+	}
+	if utf8.Valid(body) {
+		response.Body = string(body)
+	} else {
+		response.Body = base64.StdEncoding.EncodeToString(body)
+		response.IsBase64Encoded = true
+	}
+	return response
+}
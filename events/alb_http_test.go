@@ -0,0 +1,147 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil" //nolint: staticcheck
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestALBTargetGroupRequestHTTPRequestFixtures(t *testing.T) {
+	for _, file := range []string{
+		"./testdata/alb-lambda-target-request-headers-only.json",
+		"./testdata/alb-lambda-target-request-multivalue-headers.json",
+	} {
+		t.Run(file, func(t *testing.T) {
+			var albRequest ALBTargetGroupRequest
+			require.NoError(t, json.Unmarshal(test.ReadJSONFromFile(t, file), &albRequest))
+
+			request, err := albRequest.HTTPRequest(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, http.MethodGet, request.Method)
+			assert.Equal(t, "http", request.URL.Scheme)
+			assert.Contains(t, request.Host, ".us-east-1.elb.amazonaws.com")
+			assert.Empty(t, request.Header.Get("Host"))
+			assert.Equal(t, "/?key=hello", request.RequestURI)
+			assert.Equal(t, "hello", request.URL.Query().Get("key"))
+			assert.Equal(t, "curl/7.54.0", request.UserAgent())
+			assert.Equal(t, "123", request.Header.Get("X-Myheader"))
+			assert.Equal(t, http.NoBody, request.Body)
+			assert.Equal(t, int64(0), request.ContentLength)
+		})
+	}
+}
+
+func TestALBTargetGroupRequestHTTPRequest(t *testing.T) {
+	ctx := context.WithValue(context.Background(), struct{}{}, "value")
+	request, err := ALBTargetGroupRequest{
+		HTTPMethod: http.MethodPost,
+		Path:       "/items/a%20b",
+		MultiValueQueryStringParameters: map[string][]string{
+			"q":    {"caf%C3%A9", "a%2Bb"},
+			"page": {"2"},
+		},
+		MultiValueHeaders: map[string][]string{
+			"host":              {"example.com"},
+			"x-forwarded-proto": {"https"},
+			"x-forwarded-for":   {"203.0.113.10, 10.0.0.1"},
+			"accept":            {"text/html", "application/json"},
+			"content-type":      {"application/octet-stream"},
+		},
+		IsBase64Encoded: true,
+		Body:            base64.StdEncoding.EncodeToString([]byte{0x00, 0xff, 0x10}),
+	}.HTTPRequest(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, "value", request.Context().Value(struct{}{}))
+	assert.Equal(t, "https://example.com/items/a%20b?page=2&q=caf%C3%A9&q=a%2Bb", request.URL.String())
+	assert.Equal(t, "/items/a b", request.URL.Path)
+	assert.Equal(t, []string{"café", "a+b"}, request.URL.Query()["q"])
+	assert.Equal(t, []string{"text/html", "application/json"}, request.Header["Accept"])
+	assert.Equal(t, "203.0.113.10", request.RemoteAddr)
+	assert.Equal(t, int64(3), request.ContentLength)
+	body, err := ioutil.ReadAll(request.Body)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x00, 0xff, 0x10}, body)
+}
+
+func TestALBTargetGroupRequestHTTPRequestInvalidBase64(t *testing.T) {
+	_, err := ALBTargetGroupRequest{HTTPMethod: http.MethodPost, Path: "/", IsBase64Encoded: true, Body: "not base64!"}.HTTPRequest(context.Background())
+	assert.Error(t, err)
+}
+
+func TestALBTargetGroupRoundTrip(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Add("Set-Cookie", "a=1")
+		w.Header().Add("Set-Cookie", "b=2")
+		w.Header()["X-Tags"] = r.Header["X-Tag"]
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(r.Method + " " + r.URL.Path + "?" + r.URL.Query().Get("name") + " " + string(body)))
+	})
+
+	for name, albRequest := range map[string]ALBTargetGroupRequest{
+		"single value": {
+			HTTPMethod:            http.MethodPut,
+			Path:                  "/greet",
+			QueryStringParameters: map[string]string{"name": "J%C3%BCrgen"},
+			Headers:               map[string]string{"host": "example.com", "x-tag": "one"},
+			Body:                  "hello",
+		},
+		"multi value": {
+			HTTPMethod:                      http.MethodPut,
+			Path:                            "/greet",
+			MultiValueQueryStringParameters: map[string][]string{"name": {"J%C3%BCrgen"}},
+			MultiValueHeaders:               map[string][]string{"host": {"example.com"}, "x-tag": {"one"}},
+			IsBase64Encoded:                 true,
+			Body:                            base64.StdEncoding.EncodeToString([]byte("hello")),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			request, err := albRequest.HTTPRequest(context.Background())
+			require.NoError(t, err)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, request)
+
+			response := NewALBTargetGroupResponse(rec)
+			assert.Equal(t, http.StatusCreated, response.StatusCode)
+			assert.Equal(t, "201 Created", response.StatusDescription)
+			assert.Equal(t, "PUT /greet?Jürgen hello", response.Body)
+			assert.False(t, response.IsBase64Encoded)
+			assert.Equal(t, "text/plain", response.Headers["Content-Type"])
+			assert.Equal(t, "one", response.Headers["X-Tags"])
+			assert.Equal(t, "a=1", response.Headers["Set-Cookie"])
+			assert.Equal(t, []string{"a=1", "b=2"}, response.MultiValueHeaders["Set-Cookie"])
+		})
+	}
+}
+
+func TestNewALBTargetGroupResponse(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.Header().Add("Vary", "Accept")
+	rec.Header().Add("Vary", "Origin")
+	_, _ = rec.Write([]byte{0x89, 'P', 'N', 'G', 0xff})
+
+	response := NewALBTargetGroupResponse(rec)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "200 OK", response.StatusDescription)
+	assert.Equal(t, "Accept, Origin", response.Headers["Vary"])
+	assert.Equal(t, []string{"Accept", "Origin"}, response.MultiValueHeaders["Vary"])
+	assert.True(t, response.IsBase64Encoded)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte{0x89, 'P', 'N', 'G', 0xff}), response.Body)
+
+	empty := NewALBTargetGroupResponse(httptest.NewRecorder())
+	assert.Equal(t, http.StatusOK, empty.StatusCode)
+	assert.Empty(t, empty.Body)
+	assert.False(t, empty.IsBase64Encoded)
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
+	"strings"
 )
 
 // APIGatewayProxyRequest contains data coming from the API Gateway proxy
@@ -391,6 +392,79 @@ type APIGatewayCustomAuthorizerRequestTypeRequest struct {
 	RequestContext                  APIGatewayCustomAuthorizerRequestTypeRequestContext `json:"requestContext"`
 }
 
+// APIGatewayWebsocketAuthorizerRequest contains data coming in to a REQUEST authorizer function of a WebSocket API.
+// The authorizer only runs for the $connect route, MethodArn is the ARN of that route.
+type APIGatewayWebsocketAuthorizerRequest struct {
+	Type                            string                                      `json:"type"`
+	MethodArn                       string                                      `json:"methodArn"` //nolint: staticcheck
+	Headers                         map[string]string                           `json:"headers"`
+	MultiValueHeaders               map[string][]string                         `json:"multiValueHeaders"`
+	QueryStringParameters           map[string]string                           `json:"queryStringParameters"`
+	MultiValueQueryStringParameters map[string][]string                         `json:"multiValueQueryStringParameters"`
+	StageVariables                  map[string]string                           `json:"stageVariables"`
+	RequestContext                  APIGatewayWebsocketAuthorizerRequestContext `json:"requestContext"`
+}
+
+// APIGatewayWebsocketAuthorizerRequestContext contains the information to identify the connection being authorized.
+type APIGatewayWebsocketAuthorizerRequestContext struct {
+	RouteKey          string                    `json:"routeKey"`
+	EventType         string                    `json:"eventType"`
+	ExtendedRequestID string                    `json:"extendedRequestId"`
+	RequestTime       string                    `json:"requestTime"`
+	MessageDirection  string                    `json:"messageDirection"`
+	Stage             string                    `json:"stage"`
+	ConnectedAt       int64                     `json:"connectedAt"`
+	RequestTimeEpoch  int64                     `json:"requestTimeEpoch"`
+	Identity          APIGatewayRequestIdentity `json:"identity"`
+	RequestID         string                    `json:"requestId"`
+	DomainName        string                    `json:"domainName"`
+	ConnectionID      string                    `json:"connectionId"`
+	APIID             string                    `json:"apiId"`
+}
+
+// QueryStringParameter returns the value of the query string parameter name, or the first of its values when it
+// is repeated. Browsers cannot set headers on a WebSocket connection, so the token is commonly passed in the query string.
+func (r APIGatewayWebsocketAuthorizerRequest) QueryStringParameter(name string) string {
+	if v, ok := r.QueryStringParameters[name]; ok {
+		return v
+	}
+	if vs := r.MultiValueQueryStringParameters[name]; len(vs) > 0 {
+		return vs[0]
+	}
+	return ""
+}
+
+// Header returns the value of the header name. Header names are matched case-insensitively.
+func (r APIGatewayWebsocketAuthorizerRequest) Header(name string) string {
+	for k, v := range r.Headers {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	for k, vs := range r.MultiValueHeaders {
+		if strings.EqualFold(k, name) && len(vs) > 0 {
+			return vs[0]
+		}
+	}
+	return ""
+}
+
+// Allow returns a response allowing principalID to connect. The policy is scoped to the MethodArn of the request.
+func (r APIGatewayWebsocketAuthorizerRequest) Allow(principalID string) APIGatewayCustomAuthorizerResponse {
+	return APIGatewayCustomAuthorizerResponse{
+		PrincipalID:    principalID,
+		PolicyDocument: NewAPIGatewayCustomAuthorizerPolicy(IAMPolicyEffectAllow, r.MethodArn),
+	}
+}
+
+// Deny returns a response denying principalID the connection. The policy is scoped to the MethodArn of the request.
+func (r APIGatewayWebsocketAuthorizerRequest) Deny(principalID string) APIGatewayCustomAuthorizerResponse {
+	return APIGatewayCustomAuthorizerResponse{
+		PrincipalID:    principalID,
+		PolicyDocument: NewAPIGatewayCustomAuthorizerPolicy(IAMPolicyEffectDeny, r.MethodArn),
+	}
+}
+
 // APIGatewayCustomAuthorizerResponse represents the expected format of an API Gateway authorization response.
 type APIGatewayCustomAuthorizerResponse struct {
 	PrincipalID        string                           `json:"principalId"`
@@ -411,6 +485,20 @@ type APIGatewayV2CustomAuthorizerSimpleResponse struct {
 // should reference IAMPolicyDocument directly instead.
 type APIGatewayCustomAuthorizerPolicy IAMPolicyDocument
 
+// NewAPIGatewayCustomAuthorizerPolicy returns a policy with a single statement applying effect to invoking resources.
+func NewAPIGatewayCustomAuthorizerPolicy(effect string, resources ...string) APIGatewayCustomAuthorizerPolicy {
+	return APIGatewayCustomAuthorizerPolicy{
+		Version: IAMPolicyVersion,
+		Statement: []IAMPolicyStatement{
+			{
+				Action:   []string{"execute-api:Invoke"},
+				Effect:   effect,
+				Resource: resources,
+			},
+		},
+	}
+}
+
 type APIGatewayV2CustomAuthorizerIAMPolicyResponse struct {
 	PrincipalID    string                           `json:"principalId"`
 	PolicyDocument APIGatewayCustomAuthorizerPolicy `json:"policyDocument"`
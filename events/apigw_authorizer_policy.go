@@ -0,0 +1,127 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// authorizerPolicyVerbs are the HTTP verbs of the methods of an authorizer policy, * matching all of them.
+var authorizerPolicyVerbs = map[string]bool{
+	"*": true, "GET": true, "POST": true, "PUT": true, "PATCH": true, "DELETE": true, "HEAD": true, "OPTIONS": true,
+}
+
+var authorizerPolicyPath = regexp.MustCompile(`^[/.a-zA-Z0-9_~%*-]*$`)
+
+// AuthorizerPolicyBuilder builds the response of an API Gateway Lambda authorizer, allowing or denying the methods
+// of an API.
+//
+//	policy := events.NewAuthorizerPolicyBuilder(region, accountID, apiID, "prod").
+//		AllowMethod("GET", "/pets/*").
+//		DenyMethod("*", "/admin/*")
+//	return policy.Build(principalID, nil), nil
+//
+// The builder fails closed: if a method is not valid, or no method is allowed or denied, the built policy denies
+// all the methods of the API, and Err reports the invalid method.
+type AuthorizerPolicyBuilder struct {
+	arnPrefix string
+	allow     []string
+	deny      []string
+	err       error
+}
+
+// NewAuthorizerPolicyBuilder returns a builder of policies for the methods of the stage of the API apiID, in region
+// and accountID. Any of them can be "*" to match all.
+func NewAuthorizerPolicyBuilder(region, accountID, apiID, stage string) *AuthorizerPolicyBuilder {
+	return &AuthorizerPolicyBuilder{
+		arnPrefix: fmt.Sprintf("arn:aws:execute-api:%s:%s:%s/%s/", region, accountID, apiID, stage),
+	}
+}
+
+// AllowMethod allows the method httpVerb, or "*" or "ANY" for all verbs, of resourcePath, such as "/pets/*".
+func (b *AuthorizerPolicyBuilder) AllowMethod(httpVerb, resourcePath string) *AuthorizerPolicyBuilder {
+	b.allow = b.addMethod(b.allow, httpVerb, resourcePath)
+	return b
+}
+
+// DenyMethod denies the method httpVerb, or "*" or "ANY" for all verbs, of resourcePath, such as "/admin/*".
+// A method both allowed and denied is denied.
+func (b *AuthorizerPolicyBuilder) DenyMethod(httpVerb, resourcePath string) *AuthorizerPolicyBuilder {
+	b.deny = b.addMethod(b.deny, httpVerb, resourcePath)
+	return b
+}
+
+// AllowAllMethods allows all the methods of the API.
+func (b *AuthorizerPolicyBuilder) AllowAllMethods() *AuthorizerPolicyBuilder {
+	return b.AllowMethod("*", "*")
+}
+
+// DenyAllMethods denies all the methods of the API.
+func (b *AuthorizerPolicyBuilder) DenyAllMethods() *AuthorizerPolicyBuilder {
+	return b.DenyMethod("*", "*")
+}
+
+// Err returns the first invalid method given to the builder.
+func (b *AuthorizerPolicyBuilder) Err() error {
+	return b.err
+}
+
+// Build returns the response of a REST API authorizer, or an HTTP API authorizer with the IAM policy format, for
+// principalID. The allowed and the denied methods are merged in one statement for each effect.
+func (b *AuthorizerPolicyBuilder) Build(principalID string, context map[string]interface{}) APIGatewayCustomAuthorizerResponse {
+	policy := APIGatewayCustomAuthorizerPolicy{Version: IAMPolicyVersion}
+	if b.err != nil || (len(b.allow) == 0 && len(b.deny) == 0) {
+		policy.Statement = NewAPIGatewayCustomAuthorizerPolicy(IAMPolicyEffectDeny, b.arnPrefix+"*/*").Statement
+	} else {
+		if len(b.allow) > 0 {
+			policy.Statement = append(policy.Statement, NewAPIGatewayCustomAuthorizerPolicy(IAMPolicyEffectAllow, b.allow...).Statement...)
+		}
+		if len(b.deny) > 0 {
+			policy.Statement = append(policy.Statement, NewAPIGatewayCustomAuthorizerPolicy(IAMPolicyEffectDeny, b.deny...).Statement...)
+		}
+	}
+	return APIGatewayCustomAuthorizerResponse{
+		PrincipalID:    principalID,
+		PolicyDocument: policy,
+		Context:        context,
+	}
+}
+
+// BuildSimple returns the response of an HTTP API authorizer with the simple response format. The response is not
+// authorized if a method given to the builder is not valid.
+func (b *AuthorizerPolicyBuilder) BuildSimple(isAuthorized bool, context map[string]interface{}) APIGatewayV2CustomAuthorizerSimpleResponse {
+	return APIGatewayV2CustomAuthorizerSimpleResponse{
+		IsAuthorized: isAuthorized && b.err == nil,
+		Context:      context,
+	}
+}
+
+func (b *AuthorizerPolicyBuilder) addMethod(resources []string, httpVerb, resourcePath string) []string {
+	verb := strings.ToUpper(httpVerb)
+	if verb == "ANY" {
+		verb = "*"
+	}
+	if !authorizerPolicyVerbs[verb] {
+		b.fail(fmt.Errorf("invalid HTTP verb %q", httpVerb))
+		return resources
+	}
+	if !authorizerPolicyPath.MatchString(resourcePath) {
+		b.fail(fmt.Errorf("invalid resource path %q", resourcePath))
+		return resources
+	}
+	arn := b.arnPrefix + verb + "/" + strings.TrimLeft(resourcePath, "/")
+	for _, resource := range resources {
+		if resource == arn {
+			return resources
+		}
+	}
+	return append(resources, arn)
+}
+
+func (b *AuthorizerPolicyBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorizerPolicyBuilder(t *testing.T) {
+	builder := NewAuthorizerPolicyBuilder("us-east-1", "123456789012", "abcdef123", "prod").
+		AllowMethod("get", "/pets/*").
+		AllowMethod("GET", "pets/*").
+		AllowMethod("POST", "/").
+		DenyMethod("ANY", "/admin/*").
+		AllowMethod("*", "/health")
+	require.NoError(t, builder.Err())
+
+	response := builder.Build("user-1", map[string]interface{}{"tier": "free"})
+	responseJSON, err := json.Marshal(response)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"principalId": "user-1",
+		"policyDocument": {
+			"Version": "2012-10-17",
+			"Statement": [
+				{
+					"Action": ["execute-api:Invoke"],
+					"Effect": "Allow",
+					"Resource": [
+						"arn:aws:execute-api:us-east-1:123456789012:abcdef123/prod/GET/pets/*",
+						"arn:aws:execute-api:us-east-1:123456789012:abcdef123/prod/POST/",
+						"arn:aws:execute-api:us-east-1:123456789012:abcdef123/prod/*/health"
+					]
+				},
+				{
+					"Action": ["execute-api:Invoke"],
+					"Effect": "Deny",
+					"Resource": ["arn:aws:execute-api:us-east-1:123456789012:abcdef123/prod/*/admin/*"]
+				}
+			]
+		},
+		"context": {"tier": "free"}
+	}`, string(responseJSON))
+}
+
+func TestAuthorizerPolicyBuilderAllMethods(t *testing.T) {
+	response := NewAuthorizerPolicyBuilder("*", "*", "*", "*").AllowAllMethods().Build("user-1", nil)
+	assert.Equal(t, []IAMPolicyStatement{{
+		Action:   []string{"execute-api:Invoke"},
+		Effect:   IAMPolicyEffectAllow,
+		Resource: []string{"arn:aws:execute-api:*:*:*/*/*/*"},
+	}}, response.PolicyDocument.Statement)
+
+	response = NewAuthorizerPolicyBuilder("us-east-1", "123456789012", "abcdef123", "prod").DenyAllMethods().Build("user-1", nil)
+	assert.Equal(t, []IAMPolicyStatement{{
+		Action:   []string{"execute-api:Invoke"},
+		Effect:   IAMPolicyEffectDeny,
+		Resource: []string{"arn:aws:execute-api:us-east-1:123456789012:abcdef123/prod/*/*"},
+	}}, response.PolicyDocument.Statement)
+}
+
+func TestAuthorizerPolicyBuilderFailsClosed(t *testing.T) {
+	denyAll := []IAMPolicyStatement{{
+		Action:   []string{"execute-api:Invoke"},
+		Effect:   IAMPolicyEffectDeny,
+		Resource: []string{"arn:aws:execute-api:us-east-1:123456789012:abcdef123/prod/*/*"},
+	}}
+	newBuilder := func() *AuthorizerPolicyBuilder {
+		return NewAuthorizerPolicyBuilder("us-east-1", "123456789012", "abcdef123", "prod")
+	}
+
+	// no method
+	assert.Equal(t, denyAll, newBuilder().Build("user-1", nil).PolicyDocument.Statement)
+
+	// an invalid verb, even if other methods are valid
+	builder := newBuilder().AllowMethod("GET", "/pets").DenyMethod("FETCH", "/admin")
+	assert.EqualError(t, builder.Err(), `invalid HTTP verb "FETCH"`)
+	assert.Equal(t, denyAll, builder.Build("user-1", nil).PolicyDocument.Statement)
+	assert.False(t, builder.BuildSimple(true, nil).IsAuthorized)
+
+	builder = newBuilder().AllowMethod("GET", "/pets?id=1")
+	assert.EqualError(t, builder.Err(), `invalid resource path "/pets?id=1"`)
+	assert.Equal(t, denyAll, builder.Build("user-1", nil).PolicyDocument.Statement)
+}
+
+func TestAuthorizerPolicyBuilderBuildSimple(t *testing.T) {
+	builder := NewAuthorizerPolicyBuilder("us-east-1", "123456789012", "abcdef123", "$default")
+	response := builder.BuildSimple(true, map[string]interface{}{"userId": "user-1"})
+	responseJSON, err := json.Marshal(response)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"isAuthorized": true, "context": {"userId": "user-1"}}`, string(responseJSON))
+
+	assert.False(t, builder.BuildSimple(false, nil).IsAuthorized)
+}
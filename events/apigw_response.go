@@ -0,0 +1,150 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// NewAPIGatewayProxyJSONResponse returns a response with status, and body encoded as JSON.
+func NewAPIGatewayProxyJSONResponse(status int, body interface{}) (APIGatewayProxyResponse, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return APIGatewayProxyResponse{}, err
+	}
+	return APIGatewayProxyResponse{
+		StatusCode: status,
+		Headers:    map[string]string{"Content-Type": "application/json"},
+		Body:       string(b),
+	}, nil
+}
+
+// NewAPIGatewayProxyBinaryResponse returns a 200 response with body encoded as base64, which API Gateway decodes
+// if contentType is one of the binary media types of the API.
+func NewAPIGatewayProxyBinaryResponse(body []byte, contentType string) APIGatewayProxyResponse {
+	return APIGatewayProxyResponse{
+		StatusCode:      http.StatusOK,
+		Headers:         map[string]string{"Content-Type": contentType},
+		Body:            base64.StdEncoding.EncodeToString(body),
+		IsBase64Encoded: true,
+	}
+}
+
+// WithHeader returns a copy of r with the header key set to value, replacing the values of key,
+// compared case-insensitively, in both Headers and MultiValueHeaders.
+func (r APIGatewayProxyResponse) WithHeader(key, value string) APIGatewayProxyResponse {
+	r.Headers, r.MultiValueHeaders = setResponseHeader(r.Headers, r.MultiValueHeaders, key, value)
+	return r
+}
+
+// WithCORS returns a copy of r allowing the requests from origin, and with methods, such as for
+// the response to a CORS preflight request. See https://developer.mozilla.org/docs/Web/HTTP/CORS
+func (r APIGatewayProxyResponse) WithCORS(origin string, methods ...string) APIGatewayProxyResponse {
+	r.Headers, r.MultiValueHeaders = setCORSResponseHeaders(r.Headers, r.MultiValueHeaders, origin, methods)
+	return r
+}
+
+// NewAPIGatewayV2HTTPJSONResponse returns a response with status, and body encoded as JSON.
+func NewAPIGatewayV2HTTPJSONResponse(status int, body interface{}) (APIGatewayV2HTTPResponse, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return APIGatewayV2HTTPResponse{}, err
+	}
+	return APIGatewayV2HTTPResponse{
+		StatusCode: status,
+		Headers:    map[string]string{"Content-Type": "application/json"},
+		Body:       string(b),
+	}, nil
+}
+
+// NewAPIGatewayV2HTTPBinaryResponse returns a 200 response with body encoded as base64, which API Gateway decodes.
+func NewAPIGatewayV2HTTPBinaryResponse(body []byte, contentType string) APIGatewayV2HTTPResponse {
+	return APIGatewayV2HTTPResponse{
+		StatusCode:      http.StatusOK,
+		Headers:         map[string]string{"Content-Type": contentType},
+		Body:            base64.StdEncoding.EncodeToString(body),
+		IsBase64Encoded: true,
+	}
+}
+
+// WithHeader returns a copy of r with the header key set to value, replacing the values of key,
+// compared case-insensitively, in both Headers and MultiValueHeaders.
+func (r APIGatewayV2HTTPResponse) WithHeader(key, value string) APIGatewayV2HTTPResponse {
+	r.Headers, r.MultiValueHeaders = setResponseHeader(r.Headers, r.MultiValueHeaders, key, value)
+	return r
+}
+
+// WithCORS returns a copy of r allowing the requests from origin, and with methods, such as for
+// the response to a CORS preflight request. See https://developer.mozilla.org/docs/Web/HTTP/CORS
+func (r APIGatewayV2HTTPResponse) WithCORS(origin string, methods ...string) APIGatewayV2HTTPResponse {
+	r.Headers, r.MultiValueHeaders = setCORSResponseHeaders(r.Headers, r.MultiValueHeaders, origin, methods)
+	return r
+}
+
+func setCORSResponseHeaders(headers map[string]string, multiValueHeaders map[string][]string, origin string, methods []string) (map[string]string, map[string][]string) {
+	headers, multiValueHeaders = setResponseHeader(headers, multiValueHeaders, "Access-Control-Allow-Origin", origin)
+	if len(methods) > 0 {
+		headers, multiValueHeaders = setResponseHeader(headers, multiValueHeaders, "Access-Control-Allow-Methods", strings.Join(methods, ", "))
+	}
+	if origin != "*" {
+		// the response depends on the origin of the request, so it must not be cached for other origins
+		headers, multiValueHeaders = setResponseHeader(headers, multiValueHeaders, "Vary", appendVary(headers, multiValueHeaders, "Origin"))
+	}
+	return headers, multiValueHeaders
+}
+
+// appendVary returns the Vary header of the response, with name appended unless it is already listed.
+func appendVary(headers map[string]string, multiValueHeaders map[string][]string, name string) string {
+	var values []string
+	for k, v := range headers {
+		if strings.EqualFold(k, "Vary") {
+			values = append(values, v)
+		}
+	}
+	for k, v := range multiValueHeaders {
+		if strings.EqualFold(k, "Vary") {
+			values = append(values, v...)
+		}
+	}
+
+	var vary []string
+	for _, value := range values {
+		for _, field := range strings.Split(value, ",") {
+			field = strings.TrimSpace(field)
+			if field == "*" || strings.EqualFold(field, name) {
+				// the response already varies on name
+				return strings.Join(values, ", ")
+			}
+			if field != "" {
+				vary = append(vary, field)
+			}
+		}
+	}
+	return strings.Join(append(vary, name), ", ")
+}
+
+// setResponseHeader returns copies of headers and multiValueHeaders, so that responses sharing the maps are left
+// unchanged, with key set to value. API Gateway ignores a key of headers which is also in multiValueHeaders.
+func setResponseHeader(headers map[string]string, multiValueHeaders map[string][]string, key, value string) (map[string]string, map[string][]string) {
+	merged := make(map[string]string, len(headers)+1)
+	for k, v := range headers {
+		if !strings.EqualFold(k, key) {
+			merged[k] = v
+		}
+	}
+	merged[key] = value
+
+	var mergedMultiValue map[string][]string
+	if multiValueHeaders != nil {
+		mergedMultiValue = make(map[string][]string, len(multiValueHeaders))
+		for k, v := range multiValueHeaders {
+			if !strings.EqualFold(k, key) {
+				mergedMultiValue[k] = v
+			}
+		}
+	}
+	return merged, mergedMultiValue
+}
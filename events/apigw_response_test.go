@@ -0,0 +1,169 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPIGatewayProxyJSONResponse(t *testing.T) {
+	response, err := NewAPIGatewayProxyJSONResponse(http.StatusCreated, map[string]interface{}{"id": 42, "name": "widget"})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+	assert.Equal(t, map[string]string{"Content-Type": "application/json"}, response.Headers)
+	assert.JSONEq(t, `{"id":42,"name":"widget"}`, response.Body)
+	assert.False(t, response.IsBase64Encoded)
+
+	v2, err := NewAPIGatewayV2HTTPJSONResponse(http.StatusOK, []string{"a", "b"})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, v2.StatusCode)
+	assert.Equal(t, map[string]string{"Content-Type": "application/json"}, v2.Headers)
+	assert.Equal(t, `["a","b"]`, v2.Body)
+	assert.False(t, v2.IsBase64Encoded)
+}
+
+func TestNewAPIGatewayProxyJSONResponseMarshalError(t *testing.T) {
+	_, err := NewAPIGatewayProxyJSONResponse(http.StatusOK, make(chan int))
+	var typeErr *json.UnsupportedTypeError
+	assert.ErrorAs(t, err, &typeErr)
+
+	_, err = NewAPIGatewayV2HTTPJSONResponse(http.StatusOK, map[string]interface{}{"f": func() {}})
+	assert.ErrorAs(t, err, &typeErr)
+}
+
+func TestNewAPIGatewayProxyBinaryResponse(t *testing.T) {
+	body := []byte{0x89, 'P', 'N', 'G', 0x00, 0xff}
+
+	response := NewAPIGatewayProxyBinaryResponse(body, "image/png")
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "image/png", response.Headers["Content-Type"])
+	assert.True(t, response.IsBase64Encoded)
+	decoded, err := base64.StdEncoding.DecodeString(response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, body, decoded)
+
+	v2 := NewAPIGatewayV2HTTPBinaryResponse(body, "image/png")
+	assert.True(t, v2.IsBase64Encoded)
+	assert.Equal(t, response.Body, v2.Body)
+
+	// the flag is serialized so that API Gateway decodes the body
+	b, err := json.Marshal(response)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"isBase64Encoded":true`)
+}
+
+func TestAPIGatewayProxyResponseWithHeader(t *testing.T) {
+	response := APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Headers:    map[string]string{"content-type": "text/plain", "X-Request-Id": "1"},
+		MultiValueHeaders: map[string][]string{
+			"Set-Cookie":    {"a=1", "b=2"},
+			"Cache-Control": {"no-cache", "no-store"},
+		},
+	}
+
+	merged := response.
+		WithHeader("Content-Type", "application/json").
+		WithHeader("cache-control", "max-age=60").
+		WithHeader("X-Trace", "abc")
+
+	assert.Equal(t, map[string]string{
+		"Content-Type":  "application/json",
+		"X-Request-Id":  "1",
+		"cache-control": "max-age=60",
+		"X-Trace":       "abc",
+	}, merged.Headers)
+	assert.Equal(t, map[string][]string{"Set-Cookie": {"a=1", "b=2"}}, merged.MultiValueHeaders)
+
+	// the original response is unchanged
+	assert.Equal(t, "text/plain", response.Headers["content-type"])
+	assert.Len(t, response.MultiValueHeaders, 2)
+}
+
+func TestAPIGatewayV2HTTPResponseWithHeader(t *testing.T) {
+	var response APIGatewayV2HTTPResponse
+	merged := response.WithHeader("X-Trace", "abc")
+	assert.Equal(t, map[string]string{"X-Trace": "abc"}, merged.Headers)
+	assert.Nil(t, merged.MultiValueHeaders)
+	assert.Nil(t, response.Headers)
+}
+
+func TestAPIGatewayProxyResponseWithCORS(t *testing.T) {
+	for name, test := range map[string]struct {
+		origin   string
+		methods  []string
+		expected map[string]string
+	}{
+		"preflight": {
+			origin:  "https://example.com",
+			methods: []string{http.MethodGet, http.MethodPost},
+			expected: map[string]string{
+				"Access-Control-Allow-Origin":  "https://example.com",
+				"Access-Control-Allow-Methods": "GET, POST",
+				"Vary":                         "Origin",
+			},
+		},
+		"any origin": {
+			origin: "*",
+			expected: map[string]string{
+				"Access-Control-Allow-Origin": "*",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			preflight := APIGatewayProxyResponse{StatusCode: http.StatusNoContent}.WithCORS(test.origin, test.methods...)
+			assert.Equal(t, http.StatusNoContent, preflight.StatusCode)
+			assert.Equal(t, test.expected, preflight.Headers)
+			assert.Empty(t, preflight.Body)
+
+			v2 := APIGatewayV2HTTPResponse{StatusCode: http.StatusNoContent}.WithCORS(test.origin, test.methods...)
+			assert.Equal(t, test.expected, v2.Headers)
+		})
+	}
+}
+
+func TestAPIGatewayProxyResponseWithCORSAppendsVary(t *testing.T) {
+	for name, test := range map[string]struct {
+		headers           map[string]string
+		multiValueHeaders map[string][]string
+		expected          string
+	}{
+		"set":                {headers: map[string]string{"Vary": "Accept-Encoding"}, expected: "Accept-Encoding, Origin"},
+		"lower case":         {headers: map[string]string{"vary": "Accept-Encoding, Accept-Language"}, expected: "Accept-Encoding, Accept-Language, Origin"},
+		"multi value":        {multiValueHeaders: map[string][]string{"Vary": {"Accept-Encoding", "Cookie"}}, expected: "Accept-Encoding, Cookie, Origin"},
+		"already origin":     {headers: map[string]string{"Vary": "Accept-Encoding, origin"}, expected: "Accept-Encoding, origin"},
+		"already everything": {headers: map[string]string{"Vary": "*"}, expected: "*"},
+		"empty":              {headers: map[string]string{"Vary": ""}, expected: "Origin"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			response := APIGatewayProxyResponse{Headers: test.headers, MultiValueHeaders: test.multiValueHeaders}.WithCORS("https://example.com")
+			assert.Equal(t, test.expected, response.Headers["Vary"])
+			assert.Len(t, response.Headers, 2)
+			assert.Empty(t, response.MultiValueHeaders)
+
+			v2 := APIGatewayV2HTTPResponse{Headers: test.headers, MultiValueHeaders: test.multiValueHeaders}.WithCORS("https://example.com")
+			assert.Equal(t, test.expected, v2.Headers["Vary"])
+		})
+	}
+}
+
+func TestAPIGatewayProxyResponseWithCORSMergesHeaders(t *testing.T) {
+	response, err := NewAPIGatewayProxyJSONResponse(http.StatusOK, "ok")
+	require.NoError(t, err)
+	response.MultiValueHeaders = map[string][]string{"access-control-allow-origin": {"https://other.example.com"}}
+
+	response = response.WithCORS("https://example.com", http.MethodGet)
+	assert.Equal(t, map[string]string{
+		"Content-Type":                 "application/json",
+		"Access-Control-Allow-Origin":  "https://example.com",
+		"Access-Control-Allow-Methods": "GET",
+		"Vary":                         "Origin",
+	}, response.Headers)
+	assert.Empty(t, response.MultiValueHeaders)
+}
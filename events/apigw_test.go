@@ -288,6 +288,72 @@ func TestApiGatewayWebsocketRequestMalformedJson(t *testing.T) {
 	test.TestMalformedJson(t, APIGatewayWebsocketProxyRequest{})
 }
 
+func TestApiGatewayWebsocketAuthorizerRequestMarshaling(t *testing.T) {
+
+	// read json from file
+	inputJSON, err := ioutil.ReadFile("./testdata/apigw-websocket-authorizer-request.json")
+	if err != nil {
+		t.Errorf("could not open test file. details: %v", err)
+	}
+
+	// de-serialize into Go object
+	var inputEvent APIGatewayWebsocketAuthorizerRequest
+	if err := json.Unmarshal(inputJSON, &inputEvent); err != nil {
+		t.Errorf("could not unmarshal event. details: %v", err)
+	}
+
+	// serialize to json
+	outputJSON, err := json.Marshal(inputEvent)
+	if err != nil {
+		t.Errorf("could not marshal event. details: %v", err)
+	}
+
+	assert.JSONEq(t, string(inputJSON), string(outputJSON))
+}
+
+func TestApiGatewayWebsocketAuthorizerRequestMalformedJson(t *testing.T) {
+	test.TestMalformedJson(t, APIGatewayWebsocketAuthorizerRequest{})
+}
+
+func TestApiGatewayWebsocketAuthorizerRequestHelpers(t *testing.T) {
+	inputJSON, err := ioutil.ReadFile("./testdata/apigw-websocket-authorizer-request.json")
+	if err != nil {
+		t.Errorf("could not open test file. details: %v", err)
+	}
+	var request APIGatewayWebsocketAuthorizerRequest
+	if err := json.Unmarshal(inputJSON, &request); err != nil {
+		t.Errorf("could not unmarshal event. details: %v", err)
+	}
+
+	assert.Equal(t, "$connect", request.RequestContext.RouteKey)
+	assert.Equal(t, "Bc123dEFghIjk=", request.RequestContext.ConnectionID)
+
+	assert.Equal(t, "allow-me", request.QueryStringParameter("token"))
+	assert.Equal(t, "news", request.QueryStringParameter("channel"))
+	assert.Equal(t, "", request.QueryStringParameter("missing"))
+
+	assert.Equal(t, "headerValue1", request.Header("headerauth1"))
+	assert.Equal(t, "0", request.Header("Content-Length"))
+	assert.Equal(t, "", request.Header("Authorization"))
+	request.Headers = nil
+	assert.Equal(t, "13", request.Header("sec-websocket-version"))
+
+	expectedPolicy := func(effect string) APIGatewayCustomAuthorizerPolicy {
+		return APIGatewayCustomAuthorizerPolicy{
+			Version: "2012-10-17",
+			Statement: []IAMPolicyStatement{
+				{
+					Action:   []string{"execute-api:Invoke"},
+					Effect:   effect,
+					Resource: []string{"arn:aws:execute-api:us-east-1:123456789012:abcdef123/production/$connect"},
+				},
+			},
+		}
+	}
+	assert.Equal(t, APIGatewayCustomAuthorizerResponse{PrincipalID: "user", PolicyDocument: expectedPolicy("Allow")}, request.Allow("user"))
+	assert.Equal(t, APIGatewayCustomAuthorizerResponse{PrincipalID: "user", PolicyDocument: expectedPolicy("Deny")}, request.Deny("user"))
+}
+
 func TestApiGatewayCustomAuthorizerResponseMarshaling(t *testing.T) {
 
 	// read json from file
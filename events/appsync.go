@@ -27,10 +27,79 @@ type AppSyncCognitoIdentity struct {
 	Issuer              string                 `json:"issuer"`
 	Username            string                 `json:"username"`
 	Claims              map[string]interface{} `json:"claims"`
+	Groups              []string               `json:"groups,omitempty"`
 	SourceIP            []string               `json:"sourceIp"`
 	DefaultAuthStrategy string                 `json:"defaultAuthStrategy"`
 }
 
+// AppSyncDirectLambdaEvent is the event of a direct Lambda resolver, sent with the 2018-05-29 payload version.
+// A resolver with batching enabled is sent a []AppSyncDirectLambdaEvent, with one event per field to resolve, and
+// returns a slice of results in the same order.
+// See https://docs.aws.amazon.com/appsync/latest/devguide/resolver-context-reference.html
+type AppSyncDirectLambdaEvent struct {
+	Arguments map[string]interface{} `json:"arguments"`
+	// Identity is null for the API key authorization, and is decoded with CognitoIdentity or IAMIdentity.
+	Identity json.RawMessage        `json:"identity"`
+	Source   map[string]interface{} `json:"source"`
+	Request  AppSyncRequest         `json:"request"`
+	Prev     *AppSyncPrev           `json:"prev"`
+	Info     AppSyncInfo            `json:"info"`
+	Stash    map[string]interface{} `json:"stash"`
+}
+
+// AppSyncRequest contains the headers of the GraphQL request.
+type AppSyncRequest struct {
+	Headers map[string]string `json:"headers"`
+	// DomainName is only set for the requests to a custom domain name.
+	DomainName *string `json:"domainName"`
+}
+
+// AppSyncPrev contains the result of the previous function of a pipeline resolver.
+type AppSyncPrev struct {
+	Result interface{} `json:"result"`
+}
+
+// AppSyncInfo contains the information about the field being resolved.
+type AppSyncInfo struct {
+	FieldName           string                 `json:"fieldName"`
+	ParentTypeName      string                 `json:"parentTypeName"`
+	Variables           map[string]interface{} `json:"variables"`
+	SelectionSetList    []string               `json:"selectionSetList"`
+	SelectionSetGraphQL string                 `json:"selectionSetGraphQL"`
+}
+
+// CognitoIdentity decodes the identity of a caller authorized by a Cognito user pool. It returns false when the
+// caller was authorized another way.
+func (e AppSyncDirectLambdaEvent) CognitoIdentity() (*AppSyncCognitoIdentity, bool) {
+	var identity AppSyncCognitoIdentity
+	if !e.decodeIdentity("issuer", &identity) {
+		return nil, false
+	}
+	return &identity, true
+}
+
+// IAMIdentity decodes the identity of a caller authorized by IAM. It returns false when the caller was authorized
+// another way.
+func (e AppSyncDirectLambdaEvent) IAMIdentity() (*AppSyncIAMIdentity, bool) {
+	var identity AppSyncIAMIdentity
+	if !e.decodeIdentity("accountId", &identity) {
+		return nil, false
+	}
+	return &identity, true
+}
+
+// decodeIdentity decodes the identity into out when it has key, which is only sent for one kind of identity.
+func (e AppSyncDirectLambdaEvent) decodeIdentity(key string, out interface{}) bool {
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(e.Identity, &keys); err != nil {
+		return false
+	}
+	if _, ok := keys[key]; !ok {
+		return false
+	}
+	return json.Unmarshal(e.Identity, out) == nil
+}
+
 // Deprecated: not used by any event schema
 type AppSyncOperation string
 
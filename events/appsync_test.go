@@ -2,11 +2,13 @@ package events
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil" //nolint: staticcheck
 	"testing"
 
 	"github.com/aws/aws-lambda-go/events/test"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAppSyncIdentity_IAM(t *testing.T) {
@@ -47,6 +49,62 @@ func TestAppSyncIdentity_Cognito(t *testing.T) {
 	assert.JSONEq(t, string(inputJSON), string(outputJSON))
 }
 
+func TestAppSyncDirectLambdaEventCognito(t *testing.T) {
+	var event AppSyncDirectLambdaEvent
+	test.AssertJSONRoundTrip(t, "./testdata/appsync-direct-lambda-cognito.json", &event)
+
+	identity, ok := event.CognitoIdentity()
+	require.True(t, ok)
+	assert.Equal(t, "user1", identity.Username)
+	assert.Equal(t, []string{"admins"}, identity.Groups)
+	_, ok = event.IAMIdentity()
+	assert.False(t, ok)
+
+	assert.Equal(t, "createPost", event.Info.FieldName)
+	assert.Equal(t, "Mutation", event.Info.ParentTypeName)
+	assert.Equal(t, []string{"id", "title", "author", "author/name"}, event.Info.SelectionSetList)
+	assert.Equal(t, "Hello", event.Arguments["input"].(map[string]interface{})["title"])
+	require.NotNil(t, event.Request.DomainName)
+	assert.Equal(t, "api.example.com", *event.Request.DomainName)
+	assert.Nil(t, event.Prev)
+	assert.Nil(t, event.Source)
+}
+
+func TestAppSyncDirectLambdaEventIAM(t *testing.T) {
+	var event AppSyncDirectLambdaEvent
+	test.AssertJSONRoundTrip(t, "./testdata/appsync-direct-lambda-iam.json", &event)
+
+	identity, ok := event.IAMIdentity()
+	require.True(t, ok)
+	assert.Equal(t, "arn:aws:iam::123456789012:user/appsync", identity.UserARN)
+	_, ok = event.CognitoIdentity()
+	assert.False(t, ok)
+
+	require.NotNil(t, event.Prev)
+	assert.Equal(t, map[string]interface{}{"id": "post-1"}, event.Prev.Result)
+	assert.Equal(t, "t-1", event.Stash["tenant"])
+	assert.Nil(t, event.Request.DomainName)
+}
+
+func TestAppSyncDirectLambdaEventBatch(t *testing.T) {
+	var events []AppSyncDirectLambdaEvent
+	test.AssertJSONRoundTrip(t, "./testdata/appsync-direct-lambda-batch.json", &events)
+
+	require.Len(t, events, 3)
+	for i, event := range events {
+		assert.Equal(t, "author", event.Info.FieldName)
+		assert.Equal(t, fmt.Sprintf("user-%d", i+1), event.Source["authorId"])
+		// the API key authorization has no identity
+		assert.Equal(t, "null", string(event.Identity))
+		_, ok := event.CognitoIdentity()
+		assert.False(t, ok)
+		_, ok = event.IAMIdentity()
+		assert.False(t, ok)
+	}
+	_, ok := AppSyncDirectLambdaEvent{}.IAMIdentity()
+	assert.False(t, ok)
+}
+
 func TestAppSyncLambdaAuthorizerRequestMarshalling(t *testing.T) {
 	inputJSON, err := ioutil.ReadFile("./testdata/appsync-lambda-auth-request.json")
 	if err != nil {
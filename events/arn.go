@@ -0,0 +1,18 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import "strings"
+
+// splitARN returns the six colon separated parts of s, partition, service, region, account, and resource
+// following the "arn" prefix, or nil if s is not an ARN.
+func splitARN(s string) []string {
+	if !strings.HasPrefix(s, "arn:") {
+		return nil
+	}
+	parts := strings.SplitN(s, ":", 6)
+	if len(parts) != 6 {
+		return nil
+	}
+	return parts
+}
@@ -271,40 +271,43 @@ func (av DynamoDBAttributeValue) MarshalJSON() ([]byte, error) {
 	var err error
 	var b []byte
 
+	// av.value is marshaled as is, rather than asserted to the type of av.dataType,
+	// so that the zero value or a partially unmarshaled value does not panic.
+
 	switch av.dataType {
 	case DataTypeBinary:
 		buff.WriteString(`{ "B":`)
-		b, err = json.Marshal(av.value.([]byte))
+		b, err = json.Marshal(av.value)
 		buff.Write(b)
 
 	case DataTypeBoolean:
 		buff.WriteString(`{ "BOOL":`)
-		b, err = json.Marshal(av.value.(bool))
+		b, err = json.Marshal(av.value)
 		buff.Write(b)
 
 	case DataTypeBinarySet:
 		buff.WriteString(`{ "BS":`)
-		b, err = json.Marshal(av.value.([][]byte))
+		b, err = json.Marshal(av.value)
 		buff.Write(b)
 
 	case DataTypeList:
 		buff.WriteString(`{ "L":`)
-		b, err = json.Marshal(av.value.([]DynamoDBAttributeValue))
+		b, err = json.Marshal(av.value)
 		buff.Write(b)
 
 	case DataTypeMap:
 		buff.WriteString(`{ "M":`)
-		b, err = json.Marshal(av.value.(map[string]DynamoDBAttributeValue))
+		b, err = json.Marshal(av.value)
 		buff.Write(b)
 
 	case DataTypeNumber:
 		buff.WriteString(`{ "N":`)
-		b, err = json.Marshal(av.value.(string))
+		b, err = json.Marshal(av.value)
 		buff.Write(b)
 
 	case DataTypeNumberSet:
 		buff.WriteString(`{ "NS":`)
-		b, err = json.Marshal(av.value.([]string))
+		b, err = json.Marshal(av.value)
 		buff.Write(b)
 
 	case DataTypeNull:
@@ -312,12 +315,12 @@ func (av DynamoDBAttributeValue) MarshalJSON() ([]byte, error) {
 
 	case DataTypeString:
 		buff.WriteString(`{ "S":`)
-		b, err = json.Marshal(av.value.(string))
+		b, err = json.Marshal(av.value)
 		buff.Write(b)
 
 	case DataTypeStringSet:
 		buff.WriteString(`{ "SS":`)
-		b, err = json.Marshal(av.value.([]string))
+		b, err = json.Marshal(av.value)
 		buff.Write(b)
 	}
 
@@ -332,12 +335,13 @@ func unmarshalNull(target *DynamoDBAttributeValue) error {
 }
 
 func unmarshalString(target *DynamoDBAttributeValue, value interface{}) error {
-	var ok bool
-	target.value, ok = value.(string)
-	target.dataType = DataTypeString
+	stringValue, ok := value.(string)
 	if !ok {
 		return errors.New("DynamoDBAttributeValue: S type should contain a string")
 	}
+
+	target.value = stringValue
+	target.dataType = DataTypeString
 	return nil
 }
 
@@ -378,7 +382,10 @@ func unmarshalBinarySet(target *DynamoDBAttributeValue, value interface{}) error
 
 	for index, element := range list {
 		var err error
-		elementString := element.(string)
+		elementString, ok := element.(string)
+		if !ok {
+			return errors.New("DynamoDBAttributeValue: BS type should contain a list of base64 strings")
+		}
 		binarySet[index], err = base64.StdEncoding.DecodeString(elementString)
 		if err != nil {
 			return err
@@ -443,12 +450,13 @@ func unmarshalMap(target *DynamoDBAttributeValue, value interface{}) error {
 }
 
 func unmarshalNumber(target *DynamoDBAttributeValue, value interface{}) error {
-	var ok bool
-	target.value, ok = value.(string)
-	target.dataType = DataTypeNumber
+	numberValue, ok := value.(string)
 	if !ok {
 		return errors.New("DynamoDBAttributeValue: N type should contain a string")
 	}
+
+	target.value = numberValue
+	target.dataType = DataTypeNumber
 	return nil
 }
 
@@ -192,6 +192,40 @@ func TestUnmarshalEmptyStringSet(t *testing.T) {
 	assert.Equal(t, 0, len(av.StringSet()))
 }
 
+// Regression tests for panics found by FuzzDynamoDBEvent.
+func TestUnmarshalMalformedDoesNotPanic(t *testing.T) {
+	for _, input := range []string{
+		`{"BS": [1]}`,
+		`{"BS": ["AAEqQQ==", null]}`,
+		`{"S": 1}`,
+		`{"N": 1}`,
+		`{"L": [{"S": 1}]}`,
+		`{"M": {"a": {"BS": [true]}}}`,
+		`{}`,
+		`null`,
+	} {
+		var av DynamoDBAttributeValue
+		assert.NotPanics(t, func() {
+			assert.Error(t, json.Unmarshal([]byte(input), &av), input)
+			_, _ = json.Marshal(av)
+		}, input)
+	}
+}
+
+func TestMarshalZeroValue(t *testing.T) {
+	var av DynamoDBAttributeValue
+	marshaled, err := json.Marshal(av)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"B": null}`, string(marshaled))
+
+	var event DynamoDBEvent
+	input := []byte(`{"Records": [{"dynamodb": {"Keys": {"id": {"S": 1}, "sort": {}}}}]}`)
+	assert.Error(t, json.Unmarshal(input, &event))
+	assert.NotPanics(t, func() {
+		_, _ = json.Marshal(event)
+	})
+}
+
 func TestAccessWithWrongTypePanics(t *testing.T) {
 	testCases := []struct {
 		input         string
@@ -0,0 +1,374 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// DynamoDBUnmarshalTypeError is the error returned by UnmarshalDynamoDBEventAttributeMap when an attribute
+// cannot be stored in the Go value at Path, for example a String into an int, or a Number out of range.
+type DynamoDBUnmarshalTypeError struct {
+	Path  string           // of the attribute, for example "Address.Lines[1]"
+	Value DynamoDBDataType // of the attribute
+	Type  reflect.Type     // of the Go value
+	Err   error            // parsing a Number, if any
+}
+
+func (e *DynamoDBUnmarshalTypeError) Error() string {
+	msg := fmt.Sprintf("cannot unmarshal DynamoDB %s into Go value of type %s", dataTypeLabel(e.Value), e.Type)
+	if e.Path != "" {
+		msg += " at " + e.Path
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *DynamoDBUnmarshalTypeError) Unwrap() error {
+	return e.Err
+}
+
+// UnmarshalDynamoDBEventAttributeMap stores the attributes of m, for example the NewImage of a DynamoDBEventRecord,
+// in the struct or map pointed to by out.
+//
+// Struct fields are matched to attributes by the name in their `dynamodbav` tag, or by their name, preferring an exact
+// match. Fields tagged "-" are ignored, as are attributes without a field. The fields of embedded structs are treated
+// as fields of the outer struct.
+//
+// Numbers are stored in integers, floats or strings. Sets are stored in slices. Binary values are stored in []byte.
+// A NULL attribute sets the Go value to its zero value. A String is stored in a value implementing
+// encoding.TextUnmarshaler, such as time.Time. In an interface{}, attributes are stored as string, float64, bool, []byte,
+// nil, []interface{}, map[string]interface{}, []string, []float64 or [][]byte.
+func UnmarshalDynamoDBEventAttributeMap(m map[string]DynamoDBAttributeValue, out interface{}) error {
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("UnmarshalDynamoDBEventAttributeMap: out must be a non-nil pointer, got %T", out)
+	}
+	return unmarshalAttributeMap("", m, v.Elem())
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+func unmarshalAttribute(path string, av DynamoDBAttributeValue, v reflect.Value) error {
+	if av.IsNull() {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return unmarshalAttribute(path, av, v.Elem())
+	}
+	if v.Kind() == reflect.Interface && v.NumMethod() == 0 {
+		value, err := attributeInterface(path, av)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(&value).Elem())
+		return nil
+	}
+	if av.DataType() == DataTypeString && v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		if err := v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(av.String())); err != nil {
+			return &DynamoDBUnmarshalTypeError{Path: path, Value: DataTypeString, Type: v.Type(), Err: err}
+		}
+		return nil
+	}
+
+	mismatch := &DynamoDBUnmarshalTypeError{Path: path, Value: av.DataType(), Type: v.Type()}
+	switch av.DataType() {
+	case DataTypeString:
+		if v.Kind() != reflect.String {
+			return mismatch
+		}
+		v.SetString(av.String())
+	case DataTypeNumber:
+		return unmarshalNumberAttribute(path, av.Number(), v)
+	case DataTypeBoolean:
+		if v.Kind() != reflect.Bool {
+			return mismatch
+		}
+		v.SetBool(av.Boolean())
+	case DataTypeBinary:
+		if v.Kind() != reflect.Slice || v.Type().Elem().Kind() != reflect.Uint8 {
+			return mismatch
+		}
+		v.SetBytes(append([]byte(nil), av.Binary()...))
+	case DataTypeList:
+		list := av.List()
+		if v.Kind() != reflect.Slice {
+			return mismatch
+		}
+		s := reflect.MakeSlice(v.Type(), len(list), len(list))
+		for i, element := range list {
+			if err := unmarshalAttribute(fmt.Sprintf("%s[%d]", path, i), element, s.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+	case DataTypeMap:
+		return unmarshalAttributeMap(path, av.Map(), v)
+	case DataTypeStringSet:
+		return unmarshalSetAttribute(path, av.StringSet(), NewStringAttribute, v, mismatch)
+	case DataTypeNumberSet:
+		return unmarshalSetAttribute(path, av.NumberSet(), NewNumberAttribute, v, mismatch)
+	case DataTypeBinarySet:
+		if v.Kind() != reflect.Slice {
+			return mismatch
+		}
+		set := av.BinarySet()
+		s := reflect.MakeSlice(v.Type(), len(set), len(set))
+		for i, element := range set {
+			if err := unmarshalAttribute(fmt.Sprintf("%s[%d]", path, i), NewBinaryAttribute(element), s.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+	default:
+		return mismatch
+	}
+	return nil
+}
+
+func unmarshalSetAttribute(path string, set []string, newAttribute func(string) DynamoDBAttributeValue, v reflect.Value, mismatch error) error {
+	if v.Kind() != reflect.Slice {
+		return mismatch
+	}
+	s := reflect.MakeSlice(v.Type(), len(set), len(set))
+	for i, element := range set {
+		if err := unmarshalAttribute(fmt.Sprintf("%s[%d]", path, i), newAttribute(element), s.Index(i)); err != nil {
+			return err
+		}
+	}
+	v.Set(s)
+	return nil
+}
+
+func unmarshalNumberAttribute(path string, number string, v reflect.Value) error {
+	typeErr := func(err error) error {
+		return &DynamoDBUnmarshalTypeError{Path: path, Value: DataTypeNumber, Type: v.Type(), Err: err}
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(number, 10, v.Type().Bits())
+		if err != nil {
+			return typeErr(err)
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(number, 10, v.Type().Bits())
+		if err != nil {
+			return typeErr(err)
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(number, v.Type().Bits())
+		if err != nil {
+			return typeErr(err)
+		}
+		v.SetFloat(n)
+	case reflect.String:
+		v.SetString(number)
+	default:
+		return typeErr(nil)
+	}
+	return nil
+}
+
+func unmarshalAttributeMap(path string, m map[string]DynamoDBAttributeValue, v reflect.Value) error {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return unmarshalAttributeMap(path, m, v.Elem())
+	}
+	switch {
+	case v.Kind() == reflect.Interface && v.NumMethod() == 0:
+		return unmarshalAttribute(path, NewMapAttribute(m), v)
+	case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+		if v.IsNil() {
+			v.Set(reflect.MakeMapWithSize(v.Type(), len(m)))
+		}
+		for name, av := range m {
+			element := reflect.New(v.Type().Elem()).Elem()
+			if err := unmarshalAttribute(joinAttributePath(path, name), av, element); err != nil {
+				return err
+			}
+			v.SetMapIndex(reflect.ValueOf(name).Convert(v.Type().Key()), element)
+		}
+		return nil
+	case v.Kind() == reflect.Struct:
+		fields := attributeFields(v.Type())
+		for name, av := range m {
+			field, ok := fields.lookup(name)
+			if !ok {
+				continue
+			}
+			fv, ok := fieldByIndexAlloc(v, field.index)
+			if !ok {
+				continue
+			}
+			if err := unmarshalAttribute(joinAttributePath(path, name), av, fv); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return &DynamoDBUnmarshalTypeError{Path: path, Value: DataTypeMap, Type: v.Type()}
+}
+
+func joinAttributePath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// attributeInterface returns the value of av as stored in an interface{}.
+func attributeInterface(path string, av DynamoDBAttributeValue) (interface{}, error) {
+	switch av.DataType() {
+	case DataTypeString:
+		return av.String(), nil
+	case DataTypeNumber:
+		var n float64
+		err := unmarshalNumberAttribute(path, av.Number(), reflect.ValueOf(&n).Elem())
+		return n, err
+	case DataTypeBoolean:
+		return av.Boolean(), nil
+	case DataTypeBinary:
+		return append([]byte(nil), av.Binary()...), nil
+	case DataTypeStringSet:
+		return append([]string(nil), av.StringSet()...), nil
+	case DataTypeNumberSet:
+		var ns []float64
+		err := unmarshalAttribute(path, av, reflect.ValueOf(&ns).Elem())
+		return ns, err
+	case DataTypeBinarySet:
+		var bs [][]byte
+		err := unmarshalAttribute(path, av, reflect.ValueOf(&bs).Elem())
+		return bs, err
+	case DataTypeList:
+		var l []interface{}
+		err := unmarshalAttribute(path, av, reflect.ValueOf(&l).Elem())
+		return l, err
+	case DataTypeMap:
+		var m map[string]interface{}
+		err := unmarshalAttributeMap(path, av.Map(), reflect.ValueOf(&m).Elem())
+		return m, err
+	}
+	return nil, nil
+}
+
+type attributeField struct {
+	index []int
+	depth int
+}
+
+type attributeFieldSet map[string]attributeField
+
+// lookup returns the field named name, or else a field whose name matches name case-insensitively.
+func (fields attributeFieldSet) lookup(name string) (attributeField, bool) {
+	if field, ok := fields[name]; ok {
+		return field, true
+	}
+	for fieldName, field := range fields {
+		if strings.EqualFold(fieldName, name) {
+			return field, true
+		}
+	}
+	return attributeField{}, false
+}
+
+// attributeFields returns the fields of the struct type t, including those of embedded structs. A field of a less
+// deeply embedded struct hides the fields of the same name of more deeply embedded structs.
+func attributeFields(t reflect.Type) attributeFieldSet {
+	fields := attributeFieldSet{}
+	collectAttributeFields(t, nil, fields)
+	return fields
+}
+
+func collectAttributeFields(t reflect.Type, index []int, fields attributeFieldSet) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("dynamodbav")
+		if tag == "-" {
+			continue
+		}
+		name := tag
+		if comma := strings.Index(tag, ","); comma >= 0 {
+			name = tag[:comma]
+		}
+		fieldIndex := append(append([]int(nil), index...), i)
+
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				collectAttributeFields(ft, fieldIndex, fields)
+				continue
+			}
+		}
+		if sf.PkgPath != "" {
+			continue // unexported
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		if existing, ok := fields[name]; ok && existing.depth <= len(index) {
+			continue
+		}
+		fields[name] = attributeField{index: fieldIndex, depth: len(index)}
+	}
+}
+
+// fieldByIndexAlloc is like reflect.Value.FieldByIndex, but allocates nil embedded struct pointers.
+// It reports false when such a pointer cannot be set, because its type is unexported.
+func fieldByIndexAlloc(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+func dataTypeLabel(t DynamoDBDataType) string {
+	switch t {
+	case DataTypeBinary:
+		return "B"
+	case DataTypeBoolean:
+		return "BOOL"
+	case DataTypeBinarySet:
+		return "BS"
+	case DataTypeList:
+		return "L"
+	case DataTypeMap:
+		return "M"
+	case DataTypeNumber:
+		return "N"
+	case DataTypeNumberSet:
+		return "NS"
+	case DataTypeNull:
+		return "NULL"
+	case DataTypeString:
+		return "S"
+	case DataTypeStringSet:
+		return "SS"
+	}
+	return strconv.Itoa(int(t))
+}
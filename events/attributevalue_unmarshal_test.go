@@ -0,0 +1,205 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testOrderLine struct {
+	SKU      string `dynamodbav:"sku"`
+	Quantity int    `dynamodbav:"qty"`
+}
+
+type testAddress struct {
+	City string
+	Zip  string
+}
+
+type testCustomer struct {
+	Name    string       `dynamodbav:"name"`
+	Email   *string      `dynamodbav:"email"`
+	Address *testAddress `dynamodbav:"address"`
+}
+
+type TestAudit struct {
+	CreatedBy string `dynamodbav:"createdBy"`
+}
+
+type testVersioned struct {
+	Version int64
+	Id      string // hidden by the field of the outer struct
+}
+
+type testOrder struct {
+	testVersioned
+	*TestAudit `dynamodbav:"Audit"`
+
+	ID          string `dynamodbav:"Id"`
+	Total       float64
+	Quantity    *uint8
+	Serial      uint64
+	Paid        bool
+	Signature   []byte
+	Notes       *string
+	CreatedAt   time.Time
+	Tags        []string
+	Ratings     []float32
+	Attachments [][]byte
+	Lines       []testOrderLine
+	Customer    testCustomer
+	Metadata    map[string]interface{}
+	Ignored     string `dynamodbav:"-"`
+}
+
+func readTestAttributeMap(t *testing.T) map[string]DynamoDBAttributeValue {
+	var m map[string]DynamoDBAttributeValue
+	require.NoError(t, json.Unmarshal(test.ReadJSONFromFile(t, "./testdata/dynamodb-attribute-map.json"), &m))
+	return m
+}
+
+func TestUnmarshalDynamoDBEventAttributeMap(t *testing.T) {
+	m := readTestAttributeMap(t)
+	m["Ignored"] = NewStringAttribute("ignored")
+	m["Unknown"] = NewStringAttribute("unknown")
+
+	notes := "stale"
+	order := testOrder{Notes: &notes}
+	require.NoError(t, UnmarshalDynamoDBEventAttributeMap(m, &order))
+
+	quantity := uint8(3)
+	email := "jane@example.com"
+	assert.Equal(t, testOrder{
+		testVersioned: testVersioned{Version: 7},
+		TestAudit:     &TestAudit{CreatedBy: "system"},
+		ID:            "order-1234",
+		Total:         129.95,
+		Quantity:      &quantity,
+		Serial:        18446744073709551615,
+		Paid:          true,
+		Signature:     []byte("signed"),
+		Notes:         nil,
+		CreatedAt:     time.Date(2026, 3, 2, 10, 15, 30, 0, time.UTC),
+		Tags:          []string{"gift", "express"},
+		Ratings:       []float32{4, 5, 3.5},
+		Attachments:   [][]byte{[]byte("a"), []byte("b")},
+		Lines:         []testOrderLine{{SKU: "A-1", Quantity: 2}, {SKU: "B-2", Quantity: 1}},
+		Customer:      testCustomer{Name: "Jane", Email: &email, Address: &testAddress{City: "Seattle", Zip: "98101"}},
+		Metadata: map[string]interface{}{
+			"source":  "web",
+			"attempt": float64(2),
+			"flags":   []interface{}{false, nil, "x"},
+		},
+	}, order)
+}
+
+func TestUnmarshalDynamoDBEventAttributeMapIntoMap(t *testing.T) {
+	m := readTestAttributeMap(t)
+
+	var out map[string]interface{}
+	require.NoError(t, UnmarshalDynamoDBEventAttributeMap(m, &out))
+	assert.Equal(t, "order-1234", out["Id"])
+	assert.Equal(t, 129.95, out["Total"])
+	assert.Equal(t, []byte("signed"), out["Signature"])
+	assert.Nil(t, out["Notes"])
+	assert.Equal(t, []string{"gift", "express"}, out["Tags"])
+	assert.Equal(t, []float64{4, 5, 3.5}, out["Ratings"])
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("b")}, out["Attachments"])
+	assert.Equal(t, map[string]interface{}{"city": "Seattle", "zip": "98101"}, out["Customer"].(map[string]interface{})["address"])
+
+	var numbers map[string]string
+	require.NoError(t, UnmarshalDynamoDBEventAttributeMap(map[string]DynamoDBAttributeValue{
+		"big":   NewNumberAttribute("123456789012345678901234567890"),
+		"small": NewNumberAttribute("0.000001"),
+	}, &numbers))
+	assert.Equal(t, map[string]string{"big": "123456789012345678901234567890", "small": "0.000001"}, numbers)
+}
+
+func TestUnmarshalDynamoDBEventAttributeMapErrors(t *testing.T) {
+	for name, test := range map[string]struct {
+		m        map[string]DynamoDBAttributeValue
+		out      interface{}
+		expected string
+		parseErr bool
+	}{
+		"type mismatch": {
+			m:        map[string]DynamoDBAttributeValue{"Paid": NewStringAttribute("yes")},
+			out:      &testOrder{},
+			expected: "cannot unmarshal DynamoDB S into Go value of type bool at Paid",
+		},
+		"nested path": {
+			m: map[string]DynamoDBAttributeValue{"Lines": NewListAttribute([]DynamoDBAttributeValue{
+				NewMapAttribute(map[string]DynamoDBAttributeValue{"qty": NewNumberAttribute("1")}),
+				NewMapAttribute(map[string]DynamoDBAttributeValue{"qty": NewStringAttribute("two")}),
+			})},
+			out:      &testOrder{},
+			expected: "cannot unmarshal DynamoDB S into Go value of type int at Lines[1].qty",
+		},
+		"number out of range": {
+			m:        map[string]DynamoDBAttributeValue{"Quantity": NewNumberAttribute("300")},
+			out:      &testOrder{},
+			expected: `cannot unmarshal DynamoDB N into Go value of type uint8 at Quantity: strconv.ParseUint: parsing "300": value out of range`,
+			parseErr: true,
+		},
+		"fraction into integer": {
+			m: map[string]DynamoDBAttributeValue{"Customer": NewMapAttribute(map[string]DynamoDBAttributeValue{"address": NewMapAttribute(map[string]DynamoDBAttributeValue{"Zip": NewNumberAttribute("981.01")})})},
+			out: &struct {
+				Customer struct{ Address struct{ Zip int } }
+			}{},
+			expected: `cannot unmarshal DynamoDB N into Go value of type int at Customer.address.Zip: strconv.ParseInt: parsing "981.01": invalid syntax`,
+			parseErr: true,
+		},
+		"set into string": {
+			m:        map[string]DynamoDBAttributeValue{"Id": NewStringSetAttribute([]string{"a"})},
+			out:      &testOrder{},
+			expected: "cannot unmarshal DynamoDB SS into Go value of type string at Id",
+		},
+		"map into struct": {
+			m:        map[string]DynamoDBAttributeValue{"Id": NewStringAttribute("a")},
+			out:      new(string),
+			expected: "cannot unmarshal DynamoDB M into Go value of type string",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := UnmarshalDynamoDBEventAttributeMap(test.m, test.out)
+			require.Error(t, err)
+			assert.Equal(t, test.expected, err.Error())
+			var typeErr *DynamoDBUnmarshalTypeError
+			require.True(t, errors.As(err, &typeErr))
+			var numErr *strconv.NumError
+			assert.Equal(t, test.parseErr, errors.As(err, &numErr))
+		})
+	}
+
+	var order testOrder
+	assert.Error(t, UnmarshalDynamoDBEventAttributeMap(nil, order))
+	assert.Error(t, UnmarshalDynamoDBEventAttributeMap(nil, (*testOrder)(nil)))
+	assert.Error(t, UnmarshalDynamoDBEventAttributeMap(nil, nil))
+}
+
+func TestUnmarshalDynamoDBEventRecordImage(t *testing.T) {
+	var event DynamoDBEvent
+	require.NoError(t, json.Unmarshal(test.ReadJSONFromFile(t, "./testdata/dynamodb-event.json"), &event))
+
+	for _, record := range event.Records {
+		if record.Change.NewImage == nil {
+			continue
+		}
+		var image map[string]interface{}
+		require.NoError(t, UnmarshalDynamoDBEventAttributeMap(record.Change.NewImage, &image))
+		assert.Equal(t, len(record.Change.NewImage), len(image))
+		for name, av := range record.Change.NewImage {
+			if av.DataType() == DataTypeString {
+				assert.Equal(t, av.String(), image[name])
+			}
+		}
+	}
+}
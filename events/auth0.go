@@ -0,0 +1,50 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+// Auth0LogEventDetail is the detail of an "Auth0 log" event delivered through the Auth0 EventBridge partner event source.
+// Use with CloudWatchEvent by unmarshaling the Detail field, after checking the source with CloudWatchEvent.MatchesSourcePrefix.
+//
+// See https://auth0.com/docs/customize/log-streams/amazon-eventbridge
+type Auth0LogEventDetail struct {
+	LogID string            `json:"log_id"`
+	Data  Auth0LogEventData `json:"data"`
+}
+
+// Auth0LogEventData is a single Auth0 tenant log entry.
+// The set of populated fields depends on the log event Type, see https://auth0.com/docs/deploy-monitor/logs/log-event-type-codes
+type Auth0LogEventData struct {
+	Date         string                 `json:"date"`
+	Type         string                 `json:"type"`
+	Description  string                 `json:"description,omitempty"`
+	Connection   string                 `json:"connection,omitempty"`
+	ConnectionID string                 `json:"connection_id,omitempty"`
+	ClientID     string                 `json:"client_id,omitempty"`
+	ClientName   string                 `json:"client_name,omitempty"`
+	IP           string                 `json:"ip,omitempty"`
+	UserAgent    string                 `json:"user_agent,omitempty"`
+	Hostname     string                 `json:"hostname,omitempty"`
+	UserID       string                 `json:"user_id,omitempty"`
+	UserName     string                 `json:"user_name,omitempty"`
+	Audience     string                 `json:"audience,omitempty"`
+	Scope        string                 `json:"scope,omitempty"`
+	Strategy     string                 `json:"strategy,omitempty"`
+	StrategyType string                 `json:"strategy_type,omitempty"`
+	LogID        string                 `json:"log_id,omitempty"`
+	TenantName   string                 `json:"tenant_name,omitempty"`
+	IsMobile     bool                   `json:"isMobile"`
+	Details      map[string]interface{} `json:"details,omitempty"`
+	LocationInfo *Auth0LogLocationInfo  `json:"location_info,omitempty"`
+}
+
+// Auth0LogLocationInfo is the geolocation Auth0 resolved for the IP address of a log entry.
+type Auth0LogLocationInfo struct {
+	CountryCode   string  `json:"country_code"`
+	CountryCode3  string  `json:"country_code3"`
+	CountryName   string  `json:"country_name"`
+	CityName      string  `json:"city_name"`
+	Latitude      float64 `json:"latitude"`
+	Longitude     float64 `json:"longitude"`
+	TimeZone      string  `json:"time_zone"`
+	ContinentCode string  `json:"continent_code"`
+}
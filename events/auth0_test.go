@@ -0,0 +1,48 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+package events
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuth0LogEventMarshaling(t *testing.T) {
+	// 1. read JSON from file
+	inputJSON := test.ReadJSONFromFile(t, "./testdata/auth0-log-event.json")
+
+	// 2. de-serialize into Go object
+	var inputEvent CloudWatchEvent
+	require.NoError(t, json.Unmarshal(inputJSON, &inputEvent))
+	assert.True(t, inputEvent.IsPartnerEvent())
+	assert.Equal(t, "auth0.com", inputEvent.PartnerName())
+	assert.True(t, inputEvent.MatchesSourcePrefix("aws.partner/auth0.com"))
+
+	var detail Auth0LogEventDetail
+	require.NoError(t, json.Unmarshal(inputEvent.Detail, &detail))
+
+	// 3. Verify values populated into Go Object, at least one validation per data type
+	assert.Equal(t, "90020211201184333385000000000000001223372036854775807", detail.LogID)
+	assert.Equal(t, "s", detail.Data.Type)
+	assert.Equal(t, "auth0|61a7c1f5e4b0a2006a1b2c3d", detail.Data.UserID)
+	assert.Equal(t, "203.0.113.10", detail.Data.IP)
+	assert.False(t, detail.Data.IsMobile)
+	assert.Equal(t, float64(382), detail.Data.Details["elapsedTime"])
+	require.NotNil(t, detail.Data.LocationInfo)
+	assert.Equal(t, "USA", detail.Data.LocationInfo.CountryCode3)
+	assert.Equal(t, 47.6062, detail.Data.LocationInfo.Latitude)
+
+	// 4. serialize to JSON
+	outputJSON, err := json.Marshal(detail)
+	require.NoError(t, err)
+
+	// 5. check result
+	assert.JSONEq(t, string(inputEvent.Detail), string(outputJSON))
+}
+
+func TestAuth0LogEventMarshalingMalformedJson(t *testing.T) {
+	test.TestMalformedJson(t, Auth0LogEventDetail{})
+}
@@ -1,6 +1,7 @@
 package events
 
 import (
+	"encoding/json"
 	"time"
 )
 
@@ -16,3 +17,46 @@ type AutoScalingEvent struct {
 	Resources  []string               `json:"resources"`   //Information about resources impacted by event
 	Detail     map[string]interface{} `json:"detail"`
 }
+
+// AutoScalingLifecycleTransition is the transition of an EC2 Auto Scaling lifecycle hook.
+type AutoScalingLifecycleTransition string
+
+const (
+	AutoScalingLifecycleTransitionLaunching   AutoScalingLifecycleTransition = "autoscaling:EC2_INSTANCE_LAUNCHING"
+	AutoScalingLifecycleTransitionTerminating AutoScalingLifecycleTransition = "autoscaling:EC2_INSTANCE_TERMINATING"
+)
+
+// AutoScalingLifecycleActionEvent is an "EC2 Instance-launch Lifecycle Action" or "EC2 Instance-terminate Lifecycle Action"
+// event, sent by EC2 Auto Scaling when an instance enters a lifecycle hook's wait state.
+// Complete the action with the CompleteLifecycleAction API, passing the LifecycleActionToken.
+//
+// See https://docs.aws.amazon.com/autoscaling/ec2/userguide/lifecycle-hooks.html
+type AutoScalingLifecycleActionEvent struct {
+	Version    string                           `json:"version"`
+	ID         string                           `json:"id"`
+	DetailType string                           `json:"detail-type"`
+	Source     string                           `json:"source"`
+	AccountID  string                           `json:"account"`
+	Time       time.Time                        `json:"time"`
+	Region     string                           `json:"region"`
+	Resources  []string                         `json:"resources"`
+	Detail     AutoScalingLifecycleActionDetail `json:"detail"`
+}
+
+// AutoScalingLifecycleActionDetail is the detail of an AutoScalingLifecycleActionEvent.
+type AutoScalingLifecycleActionDetail struct {
+	LifecycleActionToken string                         `json:"LifecycleActionToken"`
+	AutoScalingGroupName string                         `json:"AutoScalingGroupName"`
+	LifecycleHookName    string                         `json:"LifecycleHookName"`
+	EC2InstanceID        string                         `json:"EC2InstanceId"`
+	LifecycleTransition  AutoScalingLifecycleTransition `json:"LifecycleTransition"`
+	NotificationMetadata string                         `json:"NotificationMetadata,omitempty"`
+	Origin               string                         `json:"Origin,omitempty"`
+	Destination          string                         `json:"Destination,omitempty"`
+}
+
+// DecodeNotificationMetadata unmarshals the NotificationMetadata configured on the lifecycle hook into v,
+// for hooks whose metadata is a JSON document.
+func (d AutoScalingLifecycleActionDetail) DecodeNotificationMetadata(v interface{}) error {
+	return json.Unmarshal([]byte(d.NotificationMetadata), v)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/aws/aws-lambda-go/events/test"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAutoScalingEventMarshaling(t *testing.T) {
@@ -39,3 +40,47 @@ func TestAutoScalingEventMarshaling(t *testing.T) {
 func TestAutoScalingMarshalingMalformedJson(t *testing.T) {
 	test.TestMalformedJson(t, AutoScalingEvent{})
 }
+
+func TestAutoScalingLifecycleActionEventMarshaling(t *testing.T) {
+	for _, sampleFile := range []string{"autoscaling-event-lifecycle-action.json", "autoscaling-event-terminate-action.json",
+		"autoscaling-event-lifecycle-action-json-metadata.json"} {
+		t.Run(sampleFile, func(t *testing.T) {
+			inputJSON := test.ReadJSONFromFile(t, "./testdata/"+sampleFile)
+
+			var inputEvent AutoScalingLifecycleActionEvent
+			require.NoError(t, json.Unmarshal(inputJSON, &inputEvent))
+
+			outputJSON, err := json.Marshal(inputEvent)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(inputJSON), string(outputJSON))
+		})
+	}
+}
+
+func TestAutoScalingLifecycleActionDetail(t *testing.T) {
+	inputJSON := test.ReadJSONFromFile(t, "./testdata/autoscaling-event-lifecycle-action-json-metadata.json")
+
+	var inputEvent AutoScalingLifecycleActionEvent
+	require.NoError(t, json.Unmarshal(inputJSON, &inputEvent))
+
+	detail := inputEvent.Detail
+	assert.Equal(t, AutoScalingLifecycleTransitionTerminating, detail.LifecycleTransition)
+	assert.Equal(t, "i-1234567890abcdef0", detail.EC2InstanceID)
+	assert.Equal(t, "71514b9d-6a40-4b26-8523-05e7eEXAMPLE", detail.LifecycleActionToken)
+
+	var metadata struct {
+		Cluster             string `json:"cluster"`
+		DrainTimeoutSeconds int    `json:"drainTimeoutSeconds"`
+	}
+	require.NoError(t, detail.DecodeNotificationMetadata(&metadata))
+	assert.Equal(t, "web", metadata.Cluster)
+	assert.Equal(t, 120, metadata.DrainTimeoutSeconds)
+
+	// metadata that is not JSON can't be decoded
+	detail.NotificationMetadata = "additional-info"
+	assert.Error(t, detail.DecodeNotificationMetadata(&metadata))
+}
+
+func TestAutoScalingLifecycleActionMarshalingMalformedJson(t *testing.T) {
+	test.TestMalformedJson(t, AutoScalingLifecycleActionEvent{})
+}
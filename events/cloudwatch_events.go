@@ -2,6 +2,7 @@ package events
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 )
 
@@ -19,3 +20,39 @@ type CloudWatchEvent struct {
 }
 
 type EventBridgeEvent = CloudWatchEvent
+
+// PartnerEventSourcePrefix is the source prefix of events delivered through an EventBridge partner event source,
+// for example "aws.partner/auth0.com/my-tenant-id/auth0.logs".
+const PartnerEventSourcePrefix = "aws.partner/"
+
+// MatchesSourcePrefix reports whether the event source is prefix, or begins with prefix followed by a "/".
+// Matching is done on whole path segments, so "aws.partner/auth0.com" matches
+// "aws.partner/auth0.com/tenant/auth0.logs" but not "aws.partner/auth0.community/tenant".
+func (e CloudWatchEvent) MatchesSourcePrefix(prefix string) bool {
+	return matchesSourcePrefix(e.Source, prefix)
+}
+
+// IsPartnerEvent reports whether the event was delivered through an EventBridge partner event source.
+func (e CloudWatchEvent) IsPartnerEvent() bool {
+	return strings.HasPrefix(e.Source, PartnerEventSourcePrefix)
+}
+
+// PartnerName returns the partner segment of a partner event source, for example "auth0.com".
+// An empty string is returned when the event is not from a partner event source.
+func (e CloudWatchEvent) PartnerName() string {
+	if !e.IsPartnerEvent() {
+		return ""
+	}
+	name := strings.TrimPrefix(e.Source, PartnerEventSourcePrefix)
+	if i := strings.Index(name, "/"); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+func matchesSourcePrefix(source, prefix string) bool {
+	if !strings.HasPrefix(source, prefix) {
+		return false
+	}
+	return len(source) == len(prefix) || strings.HasSuffix(prefix, "/") || source[len(prefix)] == '/'
+}
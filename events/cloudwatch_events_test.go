@@ -34,3 +34,34 @@ func TestCloudwatchScheduledEventIdempotency(t *testing.T) {
 func TestCloudwatchScheduledEventRequestMalformedJson(t *testing.T) {
 	test.TestMalformedJson(t, CloudWatchEvent{})
 }
+
+func TestCloudWatchEventMatchesSourcePrefix(t *testing.T) {
+	tests := []struct {
+		source string
+		prefix string
+		want   bool
+	}{
+		{"aws.partner/auth0.com/tenant-id/auth0.logs", "aws.partner/auth0.com", true},
+		{"aws.partner/auth0.com/tenant-id/auth0.logs", "aws.partner/auth0.com/", true},
+		{"aws.partner/auth0.com/tenant-id/auth0.logs", "aws.partner/", true},
+		{"aws.partner/auth0.com/tenant-id/auth0.logs", "aws.partner/auth0.com/tenant-id/auth0.logs", true},
+		{"aws.partner/auth0.community/tenant-id", "aws.partner/auth0.com", false},
+		{"aws.partner/datadog.com/tenant-id", "aws.partner/auth0.com", false},
+		{"aws.events", "aws.partner/", false},
+		{"aws.events", "aws.events", true},
+		{"", "aws.partner/", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source+"|"+tt.prefix, func(t *testing.T) {
+			event := CloudWatchEvent{Source: tt.source}
+			assert.Equal(t, tt.want, event.MatchesSourcePrefix(tt.prefix))
+		})
+	}
+}
+
+func TestCloudWatchEventPartnerName(t *testing.T) {
+	assert.Equal(t, "auth0.com", CloudWatchEvent{Source: "aws.partner/auth0.com/tenant-id/auth0.logs"}.PartnerName())
+	assert.Equal(t, "pagerduty.com", CloudWatchEvent{Source: "aws.partner/pagerduty.com"}.PartnerName())
+	assert.Equal(t, "", CloudWatchEvent{Source: "aws.events"}.PartnerName())
+	assert.False(t, CloudWatchEvent{Source: "aws.events"}.IsPartnerEvent())
+}
@@ -3,8 +3,20 @@ package events
 import (
 	"bytes"
 	"compress/gzip"
+	"compress/zlib"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// The MessageType values of CloudwatchLogsData.
+const (
+	CloudwatchLogsDataMessage = "DATA_MESSAGE"
+	// CloudwatchLogsControlMessage is sent by CloudWatch Logs to check that the destination is reachable.
+	CloudwatchLogsControlMessage = "CONTROL_MESSAGE"
 )
 
 // CloudwatchLogsEvent represents raw data from a cloudwatch logs event
@@ -18,16 +30,25 @@ type CloudwatchLogsRawData struct {
 	Data string `json:"data"`
 }
 
-// Parse returns a struct representing a usable CloudwatchLogs event
+// Parse returns a struct representing a usable CloudwatchLogs event.
+// The data can be compressed with either gzip, as sent to Lambda subscription filters, or zlib.
 func (c CloudwatchLogsRawData) Parse() (d CloudwatchLogsData, err error) {
 	data, err := base64.StdEncoding.DecodeString(c.Data)
 	if err != nil {
-		return
+		return d, fmt.Errorf("cloudwatch logs data is not valid base64: %w", err)
 	}
 
-	zr, err := gzip.NewReader(bytes.NewBuffer(data))
+	var zr io.ReadCloser
+	switch {
+	case isGzip(data):
+		zr, err = gzip.NewReader(bytes.NewReader(data))
+	case isZlib(data):
+		zr, err = zlib.NewReader(bytes.NewReader(data))
+	default:
+		return d, errors.New("cloudwatch logs data is neither gzip nor zlib compressed")
+	}
 	if err != nil {
-		return
+		return d, fmt.Errorf("cloudwatch logs data cannot be decompressed: %w", err)
 	}
 	defer zr.Close()
 
@@ -37,6 +58,28 @@ func (c CloudwatchLogsRawData) Parse() (d CloudwatchLogsData, err error) {
 	return
 }
 
+func isGzip(data []byte) bool {
+	return len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b
+}
+
+// isZlib reports whether data starts with a zlib header of the deflate method, see RFC 1950.
+func isZlib(data []byte) bool {
+	return len(data) >= 2 && data[0]&0x0f == 8 && (uint16(data[0])<<8|uint16(data[1]))%31 == 0
+}
+
+// Encode returns d gzipped and base64 encoded, as CloudWatch Logs sends it to subscription filters.
+func (d CloudwatchLogsData) Encode() (CloudwatchLogsRawData, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(d); err != nil {
+		return CloudwatchLogsRawData{}, err
+	}
+	if err := zw.Close(); err != nil {
+		return CloudwatchLogsRawData{}, err
+	}
+	return CloudwatchLogsRawData{Data: base64.StdEncoding.EncodeToString(buf.Bytes())}, nil
+}
+
 // CloudwatchLogsData is an unmarshal'd, ungzip'd, cloudwatch logs event
 type CloudwatchLogsData struct {
 	Owner               string                   `json:"owner"`
@@ -53,3 +96,81 @@ type CloudwatchLogsLogEvent struct {
 	Timestamp int64  `json:"timestamp"`
 	Message   string `json:"message"`
 }
+
+// CloudwatchLogsDataBuilder builds a CloudwatchLogsEvent, such as to test the handler of a subscription filter.
+//
+//	event, err := events.NewCloudwatchLogsDataBuilder().
+//		SetLogGroup("/aws/lambda/my-function").
+//		AddLogEvent(time.Now(), "[ERROR] something failed").
+//		Build()
+type CloudwatchLogsDataBuilder struct {
+	data CloudwatchLogsData
+}
+
+// NewCloudwatchLogsDataBuilder returns a builder of a DATA_MESSAGE event without log events.
+func NewCloudwatchLogsDataBuilder() *CloudwatchLogsDataBuilder {
+	return &CloudwatchLogsDataBuilder{data: CloudwatchLogsData{
+		MessageType:         CloudwatchLogsDataMessage,
+		SubscriptionFilters: []string{},
+		LogEvents:           []CloudwatchLogsLogEvent{},
+	}}
+}
+
+// SetOwner sets the AWS account ID of the log group.
+func (b *CloudwatchLogsDataBuilder) SetOwner(owner string) *CloudwatchLogsDataBuilder {
+	b.data.Owner = owner
+	return b
+}
+
+func (b *CloudwatchLogsDataBuilder) SetLogGroup(logGroup string) *CloudwatchLogsDataBuilder {
+	b.data.LogGroup = logGroup
+	return b
+}
+
+func (b *CloudwatchLogsDataBuilder) SetLogStream(logStream string) *CloudwatchLogsDataBuilder {
+	b.data.LogStream = logStream
+	return b
+}
+
+// SetFilters sets the names of the subscription filters that matched the log events.
+func (b *CloudwatchLogsDataBuilder) SetFilters(filters ...string) *CloudwatchLogsDataBuilder {
+	b.data.SubscriptionFilters = append([]string{}, filters...)
+	return b
+}
+
+// SetControlMessage sets the MessageType to CONTROL_MESSAGE, or back to DATA_MESSAGE.
+func (b *CloudwatchLogsDataBuilder) SetControlMessage(control bool) *CloudwatchLogsDataBuilder {
+	if control {
+		b.data.MessageType = CloudwatchLogsControlMessage
+	} else {
+		b.data.MessageType = CloudwatchLogsDataMessage
+	}
+	return b
+}
+
+// AddLogEvent adds a log event with the ID "eventId<n>", n being the number of log events added so far, plus one.
+func (b *CloudwatchLogsDataBuilder) AddLogEvent(timestamp time.Time, message string) *CloudwatchLogsDataBuilder {
+	b.data.LogEvents = append(b.data.LogEvents, CloudwatchLogsLogEvent{
+		ID:        fmt.Sprintf("eventId%d", len(b.data.LogEvents)+1),
+		Timestamp: timestamp.UnixNano() / int64(time.Millisecond),
+		Message:   message,
+	})
+	return b
+}
+
+// Data returns the CloudwatchLogsData built so far, which Parse returns from the AWSLogs of the built event.
+func (b *CloudwatchLogsDataBuilder) Data() CloudwatchLogsData {
+	data := b.data
+	data.SubscriptionFilters = append([]string{}, b.data.SubscriptionFilters...)
+	data.LogEvents = append([]CloudwatchLogsLogEvent{}, b.data.LogEvents...)
+	return data
+}
+
+// Build returns the event delivering the data built so far.
+func (b *CloudwatchLogsDataBuilder) Build() (CloudwatchLogsEvent, error) {
+	raw, err := b.data.Encode()
+	if err != nil {
+		return CloudwatchLogsEvent{}, err
+	}
+	return CloudwatchLogsEvent{AWSLogs: raw}, nil
+}
@@ -1,13 +1,30 @@
 package events
 
 import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
 	"encoding/json"
 	"reflect"
 	"testing"
+	"time"
 
 	tst "github.com/aws/aws-lambda-go/events/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+// testCloudwatchLogsData builds the data of ./testdata/cloudwatch-logs-event.json.
+func testCloudwatchLogsData() *CloudwatchLogsDataBuilder {
+	return NewCloudwatchLogsDataBuilder().
+		SetOwner("123456789123").
+		SetLogGroup("testLogGroup").
+		SetLogStream("testLogStream").
+		SetFilters("testFilter").
+		AddLogEvent(time.Unix(1440442987, 0), "[ERROR] First test message").
+		AddLogEvent(time.Unix(1440442987, int64(time.Millisecond)), "[ERROR] Second test message")
+}
+
 func TestCloudwatchLogs(t *testing.T) {
 	for _, test := range []struct {
 		name                      string
@@ -48,53 +65,147 @@ func TestCloudwatchLogs(t *testing.T) {
 }
 
 func TestCloudwatchLogsParse(t *testing.T) {
+	fixture := tst.ReadJSONFromFile(t, "./testdata/cloudwatch-logs-event.json")
+	control, err := NewCloudwatchLogsDataBuilder().
+		SetOwner("CloudwatchLogs").
+		SetControlMessage(true).
+		AddLogEvent(time.Unix(1440442987, 0), "CWL CONTROL MESSAGE: Checking health of destination Firehose.").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	controlJSON, _ := json.Marshal(control)
+
 	for _, test := range []struct {
 		name                     string
-		eventJSON                string
+		eventJSON                []byte
 		expectError              bool
 		expectCloudwatchLogsData CloudwatchLogsData
 	}{
 		{"Well formed cloudwatch event",
-			"./testdata/cloudwatch-logs-event.json",
+			fixture,
+			false,
+			testCloudwatchLogsData().Data(),
+		},
+		{"Control message",
+			controlJSON,
 			false,
 			CloudwatchLogsData{
-				Owner:     "123456789123",
-				LogGroup:  "testLogGroup",
-				LogStream: "testLogStream",
-				SubscriptionFilters: []string{
-					"testFilter",
-				},
-				MessageType: "DATA_MESSAGE",
+				Owner:               "CloudwatchLogs",
+				SubscriptionFilters: []string{},
+				MessageType:         "CONTROL_MESSAGE",
 				LogEvents: []CloudwatchLogsLogEvent{
-					{ID: "eventId1", Timestamp: 1440442987000, Message: "[ERROR] First test message"},
-					{ID: "eventId2", Timestamp: 1440442987001, Message: "[ERROR], Second test message"},
+					{ID: "eventId1", Timestamp: 1440442987000, Message: "CWL CONTROL MESSAGE: Checking health of destination Firehose."},
 				},
 			},
 		},
+		{"Invalid base64",
+			[]byte(`{"awslogs": {"data": "not base64!"}}`),
+			true,
+			CloudwatchLogsData{},
+		},
+		{"Not gzipped",
+			[]byte(`{"awslogs": {"data": "` + base64.StdEncoding.EncodeToString([]byte(`{"owner": "123456789123"}`)) + `"}}`),
+			true,
+			CloudwatchLogsData{},
+		},
 	} {
 		test := test
 		t.Run(test.name, func(t *testing.T) {
-			inputJSON := tst.ReadJSONFromFile(t, test.eventJSON)
-
 			var inputEvent CloudwatchLogsEvent
-			if err := json.Unmarshal(inputJSON, &inputEvent); err != nil {
+			if err := json.Unmarshal(test.eventJSON, &inputEvent); err != nil {
 				t.Errorf("could not unmarshal event. details: %v", err)
 			}
 
 			d, err := inputEvent.AWSLogs.Parse()
-			if err != nil {
-				if !test.expectError {
-					t.Errorf("unexpected error: %+v", err)
-				}
-
-				if !reflect.DeepEqual(test.expectCloudwatchLogsData, d) {
-					t.Errorf("expected: %+v, received: %v", test.expectCloudwatchLogsData, d)
-				}
+			if err != nil && !test.expectError {
+				t.Errorf("unexpected error: %+v", err)
 			}
-
 			if err == nil && test.expectError {
 				t.Errorf("expected error")
 			}
+
+			if !reflect.DeepEqual(test.expectCloudwatchLogsData, d) {
+				t.Errorf("expected: %+v, received: %v", test.expectCloudwatchLogsData, d)
+			}
 		})
 	}
 }
+
+func TestCloudwatchLogsDataBuilder(t *testing.T) {
+	builder := testCloudwatchLogsData()
+	event, err := builder.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the built event survives being delivered as JSON
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var delivered CloudwatchLogsEvent
+	if err := json.Unmarshal(eventJSON, &delivered); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := delivered.AWSLogs.Parse()
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if !reflect.DeepEqual(builder.Data(), d) {
+		t.Errorf("expected: %+v, received: %v", builder.Data(), d)
+	}
+	if d.MessageType != CloudwatchLogsDataMessage {
+		t.Errorf("expected a %s, received: %s", CloudwatchLogsDataMessage, d.MessageType)
+	}
+
+	// the data built so far is not changed by later calls
+	data := builder.Data()
+	builder.SetFilters("other").AddLogEvent(time.Unix(1440442988, 0), "third")
+	if len(data.LogEvents) != 2 || data.SubscriptionFilters[0] != "testFilter" {
+		t.Errorf("data changed by the builder: %+v", data)
+	}
+	if id := builder.Data().LogEvents[2].ID; id != "eventId3" {
+		t.Errorf("expected eventId3, received: %s", id)
+	}
+}
+
+func TestCloudwatchLogsDataEncode(t *testing.T) {
+	data := testCloudwatchLogsData().Data()
+	raw, err := data.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := raw.Parse()
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if !reflect.DeepEqual(data, d) {
+		t.Errorf("expected: %+v, received: %v", data, d)
+	}
+}
+
+func TestCloudwatchLogsParseZlib(t *testing.T) {
+	data := testCloudwatchLogsData().Data()
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	require.NoError(t, json.NewEncoder(zw).Encode(data))
+	require.NoError(t, zw.Close())
+
+	d, err := CloudwatchLogsRawData{Data: base64.StdEncoding.EncodeToString(buf.Bytes())}.Parse()
+	require.NoError(t, err)
+	assert.Equal(t, data, d)
+}
+
+func TestCloudwatchLogsParseErrors(t *testing.T) {
+	_, err := CloudwatchLogsRawData{Data: "H4sIAAAA!!!"}.Parse()
+	assert.EqualError(t, err, "cloudwatch logs data is not valid base64: illegal base64 data at input byte 8")
+
+	_, err = CloudwatchLogsRawData{Data: base64.StdEncoding.EncodeToString([]byte(`{"owner": "123456789123"}`))}.Parse()
+	assert.EqualError(t, err, "cloudwatch logs data is neither gzip nor zlib compressed")
+
+	// a truncated gzip header
+	_, err = CloudwatchLogsRawData{Data: base64.StdEncoding.EncodeToString([]byte{0x1f, 0x8b, 0x08})}.Parse()
+	assert.Contains(t, err.Error(), "cloudwatch logs data cannot be decompressed")
+}
@@ -101,6 +101,8 @@ type CodeBuildEventAdditionalInformation struct {
 
 	Timeout DurationMinutes `json:"timeout-in-minutes"`
 
+	QueuedTimeout DurationMinutes `json:"queued-timeout-in-minutes,omitempty"`
+
 	BuildComplete bool `json:"build-complete"`
 
 	BuildNumber CodeBuildNumber `json:"build-number,omitempty"`
@@ -116,6 +118,24 @@ type CodeBuildEventAdditionalInformation struct {
 	Logs CodeBuildLogs `json:"logs"`
 
 	Phases []CodeBuildPhase `json:"phases"`
+
+	Cache *CodeBuildCache `json:"cache,omitempty"`
+
+	// NetworkInterface is set for builds running in a VPC.
+	NetworkInterface *CodeBuildNetworkInterface `json:"network-interface,omitempty"`
+}
+
+// CodeBuildCache represents the cache configuration of a build
+type CodeBuildCache struct {
+	// Type is NO_CACHE, LOCAL or S3.
+	Type     string `json:"type"`
+	Location string `json:"location,omitempty"`
+}
+
+// CodeBuildNetworkInterface represents the network interface of a build running in a VPC
+type CodeBuildNetworkInterface struct {
+	SubnetID           string `json:"subnet-id"`
+	NetworkInterfaceID string `json:"network-interface-id"`
 }
 
 // CodeBuildArtifact represents the artifact provided to build
@@ -132,6 +152,9 @@ type CodeBuildEnvironment struct {
 	ComputeType          string                         `json:"compute-type"`
 	Type                 string                         `json:"type"`
 	EnvironmentVariables []CodeBuildEnvironmentVariable `json:"environment-variables"`
+
+	// ImagePullCredentialsType is CODEBUILD or SERVICE_ROLE.
+	ImagePullCredentialsType string `json:"image-pull-credentials-type,omitempty"`
 }
 
 // CodeBuildEnvironmentVariable encapsulate environment variables for the code build
@@ -148,8 +171,9 @@ type CodeBuildEnvironmentVariable struct {
 
 // CodeBuildSource represent the code source will be build
 type CodeBuildSource struct {
-	Location string `json:"location"`
-	Type     string `json:"type"`
+	Location  string `json:"location"`
+	Type      string `json:"type"`
+	Buildspec string `json:"buildspec,omitempty"`
 }
 
 // CodeBuildLogs gives the log details of a code build
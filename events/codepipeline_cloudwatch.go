@@ -95,6 +95,9 @@ type CodePipelineEventDetail struct {
 	Type CodePipelineEventDetailType `json:"type,omitempty"`
 
 	ExecutionResult CodePipelineEventDetailExecutionResult `json:"execution-result,omitempty"`
+
+	// ExecutionTrigger is set on Pipeline Execution State Change events.
+	ExecutionTrigger *CodePipelineEventDetailExecutionTrigger `json:"execution-trigger,omitempty"`
 }
 
 type CodePipelineEventDetailType struct {
@@ -117,3 +120,10 @@ type CodePipelineEventDetailExecutionResult struct {
 
 	ErrorCode string `json:"error-code,omitempty"`
 }
+
+type CodePipelineEventDetailExecutionTrigger struct {
+	// TriggerType is for example StartPipelineExecution, Webhook, CloudWatchEvent or PollForSourceChanges.
+	TriggerType string `json:"trigger-type"`
+
+	TriggerDetail string `json:"trigger-detail,omitempty"`
+}
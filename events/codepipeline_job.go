@@ -2,6 +2,12 @@
 
 package events
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
 // CodePipelineJobEvent contains data from an event sent from AWS CodePipeline
 type CodePipelineJobEvent struct {
 	CodePipelineJob CodePipelineJob `json:"CodePipeline.job"`
@@ -14,6 +20,53 @@ type CodePipelineJob struct {
 	Data      CodePipelineData `json:"data"`
 }
 
+// DecodeUserParameters decodes the UserParameters of the action configuration, as JSON, into out.
+func (j CodePipelineJob) DecodeUserParameters(out interface{}) error {
+	userParameters := j.Data.ActionConfiguration.Configuration.UserParameters
+	if userParameters == "" {
+		return fmt.Errorf("codepipeline job %s has no user parameters", j.ID)
+	}
+	if err := json.Unmarshal([]byte(userParameters), out); err != nil {
+		return fmt.Errorf("codepipeline job %s user parameters are not valid JSON: %w", j.ID, err)
+	}
+	return nil
+}
+
+// ContinuationToken returns the token the job was continued with, and whether the job is a continuation of a previous
+// invocation, that returned the token with PutJobSuccessResult.
+func (j CodePipelineJob) ContinuationToken() (string, bool) {
+	return j.Data.ContinuationToken, j.Data.ContinuationToken != ""
+}
+
+// DecodeContinuationToken decodes the continuation token of the job, created with NewCodePipelineContinuationToken,
+// into out. It returns false if the job is not a continuation.
+func (j CodePipelineJob) DecodeContinuationToken(out interface{}) (bool, error) {
+	token, ok := j.ContinuationToken()
+	if !ok {
+		return false, nil
+	}
+	if err := json.Unmarshal([]byte(token), out); err != nil {
+		return true, fmt.Errorf("codepipeline job %s continuation token is not valid JSON: %w", j.ID, err)
+	}
+	return true, nil
+}
+
+// codePipelineContinuationTokenMax is the maximum length of a continuation token accepted by PutJobSuccessResult.
+const codePipelineContinuationTokenMax = 2048
+
+// NewCodePipelineContinuationToken returns state encoded as JSON, to return as the continuation token of
+// PutJobSuccessResult, so that the next invocation of the job decodes it with DecodeContinuationToken.
+func NewCodePipelineContinuationToken(state interface{}) (string, error) {
+	token, err := json.Marshal(state)
+	if err != nil {
+		return "", err
+	}
+	if len(token) > codePipelineContinuationTokenMax {
+		return "", fmt.Errorf("codepipeline continuation token of %d bytes is longer than %d", len(token), codePipelineContinuationTokenMax)
+	}
+	return string(token), nil
+}
+
 // CodePipelineData represents a job from an AWS CodePipeline event
 type CodePipelineData struct {
 	ActionConfiguration CodePipelineActionConfiguration `json:"actionConfiguration"`
@@ -41,12 +94,24 @@ type CodePipelineInputArtifact struct {
 	Name     string                    `json:"name"`
 }
 
+// S3Object returns the bucket and key of the artifact in the artifact store, or false if it is not stored in S3.
+func (a CodePipelineInputArtifact) S3Object() (bucket string, key string, ok bool) {
+	return a.Location.s3Object()
+}
+
 // CodePipelineInputLocation represents a input location
 type CodePipelineInputLocation struct {
 	S3Location   CodePipelineS3Location `json:"s3Location"`
 	LocationType string                 `json:"type"`
 }
 
+func (l CodePipelineInputLocation) s3Object() (string, string, bool) {
+	if l.LocationType != "S3" || l.S3Location.BucketName == "" || l.S3Location.ObjectKey == "" {
+		return "", "", false
+	}
+	return l.S3Location.BucketName, l.S3Location.ObjectKey, true
+}
+
 // CodePipelineS3Location represents an s3 input location
 type CodePipelineS3Location struct {
 	BucketName string `json:"bucketName"`
@@ -60,6 +125,12 @@ type CodePipelineOutputArtifact struct {
 	Name     string                    `json:"name"`
 }
 
+// S3Object returns the bucket and key to store the artifact at in the artifact store, or false if it is not stored
+// in S3.
+func (a CodePipelineOutputArtifact) S3Object() (bucket string, key string, ok bool) {
+	return a.Location.s3Object()
+}
+
 // CodePipelineOutputLocation represents a output location
 type CodePipelineOutputLocation struct {
 	S3Location   CodePipelineS3Location `json:"s3Location"`
@@ -72,3 +143,13 @@ type CodePipelineArtifactCredentials struct {
 	SessionToken    string `json:"sessionToken"`
 	AccessKeyID     string `json:"accessKeyId"`
 }
+
+// Values returns the credentials in the order of the arguments of the static credentials providers of the AWS SDKs,
+// such as credentials.NewStaticCredentialsProvider, to access the artifacts of the job. It returns an error if the
+// job has no credentials.
+func (c CodePipelineArtifactCredentials) Values() (accessKeyID, secretAccessKey, sessionToken string, err error) {
+	if c.AccessKeyID == "" || c.SecretAccessKey == "" {
+		return "", "", "", errors.New("codepipeline job has no artifact credentials")
+	}
+	return c.AccessKeyID, c.SecretAccessKey, c.SessionToken, nil
+}
@@ -71,6 +71,16 @@ type CognitoEventUserPoolsPreTokenGenV2_0 struct {
 	Response CognitoEventUserPoolsPreTokenGenResponseV2_0 `json:"response"`
 }
 
+// CognitoEventUserPoolsPreTokenGenV3_0 is sent by Amazon Cognito User Pools with the version 3 of the pre token
+// generation trigger, which is also invoked for machine-to-machine authorization with client credentials, where
+// there is no user and no ID token. Unlike the V2_0 types, the sections of the response are pointers, so that a
+// response only customizing the access token leaves out the others.
+type CognitoEventUserPoolsPreTokenGenV3_0 struct {
+	CognitoEventUserPoolsHeader
+	Request  CognitoEventUserPoolsPreTokenGenRequestV3_0  `json:"request"`
+	Response CognitoEventUserPoolsPreTokenGenResponseV3_0 `json:"response"`
+}
+
 // CognitoEventUserPoolsPostAuthentication is sent by Amazon Cognito User Pools after a user is authenticated,
 // allowing the Lambda to add custom logic.
 type CognitoEventUserPoolsPostAuthentication struct {
@@ -162,6 +172,15 @@ type CognitoEventUserPoolsPreTokenGenRequestV2_0 struct {
 	Scopes             []string               `json:"scopes"`
 }
 
+// CognitoEventUserPoolsPreTokenGenRequestV3_0 contains request portion of V3 PreTokenGen event.
+// UserAttributes is empty, and GroupConfiguration nil, for client credentials grants.
+type CognitoEventUserPoolsPreTokenGenRequestV3_0 struct {
+	UserAttributes     map[string]string       `json:"userAttributes"`
+	GroupConfiguration *GroupConfigurationV2_0 `json:"groupConfiguration"`
+	ClientMetadata     map[string]string       `json:"clientMetadata,omitempty"`
+	Scopes             []string                `json:"scopes"`
+}
+
 // CognitoEventUserPoolsPreTokenGenResponse contains the response portion of a PreTokenGen event
 type CognitoEventUserPoolsPreTokenGenResponse struct {
 	ClaimsOverrideDetails ClaimsOverrideDetails `json:"claimsOverrideDetails"`
@@ -179,6 +198,11 @@ type CognitoEventUserPoolsPreTokenGenResponseV2_0 struct {
 	ClaimsAndScopeOverrideDetails ClaimsAndScopeOverrideDetailsV2_0 `json:"claimsAndScopeOverrideDetails"`
 }
 
+// CognitoEventUserPoolsPreTokenGenResponseV3_0 contains the response portion of a V3 PreTokenGen event
+type CognitoEventUserPoolsPreTokenGenResponseV3_0 struct {
+	ClaimsAndScopeOverrideDetails *ClaimsAndScopeOverrideDetailsV3_0 `json:"claimsAndScopeOverrideDetails"`
+}
+
 // CognitoEventUserPoolsPostAuthenticationRequest contains the request portion of a PostAuthentication event
 type CognitoEventUserPoolsPostAuthenticationRequest struct {
 	NewDeviceUsed  bool              `json:"newDeviceUsed"`
@@ -222,6 +246,14 @@ type ClaimsAndScopeOverrideDetailsV2_0 struct {
 	GroupOverrideDetails  GroupConfigurationV2_0    `json:"groupOverrideDetails"`
 }
 
+// ClaimsAndScopeOverrideDetailsV3_0 allows lambda to add, suppress or override V3 claims and scopes in the token.
+// IDTokenGeneration must be nil for client credentials grants.
+type ClaimsAndScopeOverrideDetailsV3_0 struct {
+	IDTokenGeneration     *IDTokenGenerationV2_0     `json:"idTokenGeneration,omitempty"`
+	AccessTokenGeneration *AccessTokenGenerationV2_0 `json:"accessTokenGeneration,omitempty"`
+	GroupOverrideDetails  *GroupConfigurationV2_0    `json:"groupOverrideDetails,omitempty"`
+}
+
 // IDTokenGeneration allows lambda to modify the ID token
 type IDTokenGeneration struct {
 	ClaimsToAddOrOverride map[string]string `json:"claimsToAddOrOverride"`
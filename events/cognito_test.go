@@ -8,6 +8,7 @@ import (
 
 	"github.com/aws/aws-lambda-go/events/test"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCognitoEventMarshaling(t *testing.T) {
@@ -184,6 +185,48 @@ func TestCognitoEventUserPoolsPreTokenGenV2_0Marshaling(t *testing.T) {
 	test.AssertJsonsEqual(t, inputJSON, outputJSON)
 }
 
+func TestCognitoEventUserPoolsPreTokenGenV3_0Marshaling(t *testing.T) {
+	var inputEvent CognitoEventUserPoolsPreTokenGenV3_0
+	test.AssertJsonFile(t, "./testdata/cognito-event-userpools-pretokengen-v3_0.json", &inputEvent)
+
+	details := inputEvent.Response.ClaimsAndScopeOverrideDetails
+	assert.Equal(t, "3", inputEvent.Version)
+	assert.Equal(t, []interface{}{"reader", "writer"}, details.IDTokenGeneration.ClaimsToAddOrOverride["roles"])
+	assert.Equal(t, []string{"orders/read"}, details.AccessTokenGeneration.ScopesToAdd)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/admins", *details.GroupOverrideDetails.PreferredRole)
+	assert.Nil(t, inputEvent.Request.GroupConfiguration.PreferredRole)
+}
+
+func TestCognitoEventUserPoolsPreTokenGenV3_0ClientCredentialsMarshaling(t *testing.T) {
+	var inputEvent CognitoEventUserPoolsPreTokenGenV3_0
+	test.AssertJsonFile(t, "./testdata/cognito-event-userpools-pretokengen-v3_0-client-credentials.json", &inputEvent)
+
+	assert.Equal(t, "TokenGeneration_ClientCredentials", inputEvent.TriggerSource)
+	assert.Empty(t, inputEvent.Request.UserAttributes)
+	assert.Nil(t, inputEvent.Request.GroupConfiguration)
+	details := inputEvent.Response.ClaimsAndScopeOverrideDetails
+	assert.Nil(t, details.IDTokenGeneration)
+	assert.Nil(t, details.GroupOverrideDetails)
+	assert.Equal(t, float64(42), details.AccessTokenGeneration.ClaimsToAddOrOverride["partner_id"])
+	assert.Equal(t, []string{"orders/write"}, details.AccessTokenGeneration.ScopesToSuppress)
+}
+
+func TestCognitoEventUserPoolsPreTokenGenV3_0AccessTokenOnlyResponse(t *testing.T) {
+	var inputEvent CognitoEventUserPoolsPreTokenGenV3_0
+	inputEvent.Response.ClaimsAndScopeOverrideDetails = &ClaimsAndScopeOverrideDetailsV3_0{
+		AccessTokenGeneration: &AccessTokenGenerationV2_0{ScopesToAdd: []string{"orders/read"}},
+	}
+	outputJSON, err := json.Marshal(inputEvent.Response)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"claimsAndScopeOverrideDetails": {"accessTokenGeneration": {
+		"claimsToAddOrOverride": null, "claimsToSuppress": null, "scopesToAdd": ["orders/read"], "scopesToSuppress": null
+	}}}`, string(outputJSON))
+}
+
+func TestCognitoEventUserPoolsPreTokenGenV3_0MalformedJson(t *testing.T) {
+	test.TestMalformedJson(t, CognitoEventUserPoolsPreTokenGenV3_0{})
+}
+
 func TestCognitoEventUserPoolsDefineAuthChallengeMarshaling(t *testing.T) {
 	var inputEvent CognitoEventUserPoolsDefineAuthChallenge
 	test.AssertJsonFile(t, "./testdata/cognito-event-userpools-define-auth-challenge.json", &inputEvent)
@@ -23,14 +23,23 @@ type ConnectContactData struct {
 	Channel          string            `json:"Channel"`
 	ContactID        string            `json:"ContactId"`
 	CustomerEndpoint ConnectEndpoint   `json:"CustomerEndpoint"`
+	CustomerID       string            `json:"CustomerId,omitempty"`
+	Description      string            `json:"Description,omitempty"`
 	InitialContactID string            `json:"InitialContactId"`
 
 	// Either: INBOUND/OUTBOUND/TRANSFER/CALLBACK
-	InitiationMethod  string          `json:"InitiationMethod"`
-	PreviousContactID string          `json:"PreviousContactId"`
-	Queue             ConnectQueue    `json:"Queue"`
-	SystemEndpoint    ConnectEndpoint `json:"SystemEndpoint"`
-	InstanceARN       string          `json:"InstanceARN"`
+	InitiationMethod  string                                  `json:"InitiationMethod"`
+	PreviousContactID string                                  `json:"PreviousContactId"`
+	RelatedContactID  string                                  `json:"RelatedContactId,omitempty"`
+	Queue             ConnectQueue                            `json:"Queue"`
+	SystemEndpoint    ConnectEndpoint                         `json:"SystemEndpoint"`
+	InstanceARN       string                                  `json:"InstanceARN"`
+	LanguageCode      string                                  `json:"LanguageCode,omitempty"`
+	MediaStreams      *ConnectMediaStreams                    `json:"MediaStreams,omitempty"`
+	Name              string                                  `json:"Name,omitempty"`
+	References        map[string]ConnectReference             `json:"References,omitempty"`
+	SegmentAttributes map[string]ConnectSegmentAttributeValue `json:"SegmentAttributes,omitempty"`
+	Tags              map[string]string                       `json:"Tags,omitempty"`
 }
 
 // ConnectEndpoint represents routing information.
@@ -43,8 +52,41 @@ type ConnectEndpoint struct {
 type ConnectQueue struct {
 	Name string `json:"Name"`
 	ARN  string `json:"ARN"`
+
+	// The caller ID used for the outbound calls of the queue, when set.
+	OutboundCallerID *ConnectEndpoint `json:"OutboundCallerId,omitempty"`
+}
+
+// ConnectMediaStreams holds the media streams of the contact, when live media streaming is enabled.
+type ConnectMediaStreams struct {
+	Customer ConnectCustomerMediaStreams `json:"Customer"`
+}
+
+// ConnectCustomerMediaStreams holds the media streams of the customer.
+type ConnectCustomerMediaStreams struct {
+	Audio *ConnectAudioStream `json:"Audio,omitempty"`
+}
+
+// ConnectAudioStream locates the audio of the contact in a Kinesis video stream.
+type ConnectAudioStream struct {
+	StreamARN           string `json:"StreamARN"`
+	StartTimestamp      string `json:"StartTimestamp,omitempty"` // in milliseconds since the epoch
+	StopTimestamp       string `json:"StopTimestamp,omitempty"`  // in milliseconds since the epoch
+	StartFragmentNumber string `json:"StartFragmentNumber,omitempty"`
+}
+
+// ConnectReference is a reference attached to the contact, such as a URL or an attachment.
+type ConnectReference struct {
+	Type  string `json:"Type"`
+	Value string `json:"Value"`
+}
+
+// ConnectSegmentAttributeValue is the value of a segment attribute of the contact.
+type ConnectSegmentAttributeValue struct {
+	ValueString string `json:"ValueString,omitempty"`
 }
 
 // ConnectResponse is the structure that Connect expects to get back from Lambda.
 // These return values can be used in Connect to perform further routing decisions.
+// Connect rejects responses with nested values, see NewConnectResponse.
 type ConnectResponse map[string]string
@@ -0,0 +1,66 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// NewConnectResponse returns the response of a contact flow with values, which Connect only accepts as a flat map of
+// strings. Strings, booleans, numbers and encoding.TextMarshaler values, such as time.Time, are converted to strings,
+// and a nil value or nil pointer to an empty string. A nested value, such as a map, a slice or a struct, is rejected
+// with an error, since Connect fails the invocation of a response with nested values.
+func NewConnectResponse(values map[string]interface{}) (ConnectResponse, error) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	// report the error of the same key whatever the order of the map
+	sort.Strings(keys)
+
+	response := make(ConnectResponse, len(values))
+	for _, key := range keys {
+		value, err := connectResponseValue(values[key])
+		if err != nil {
+			return nil, fmt.Errorf("connect response value %q: %w", key, err)
+		}
+		response[key] = value
+	}
+	return response, nil
+}
+
+func connectResponseValue(value interface{}) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+	v := reflect.ValueOf(value)
+	if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+		return "", nil
+	}
+	if marshaler, ok := value.(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal text: %w", err)
+		}
+		return string(text), nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), nil
+	case reflect.Ptr:
+		return connectResponseValue(v.Elem().Interface())
+	}
+	return "", fmt.Errorf("nested value of type %T is not supported, Connect only accepts flat string values", value)
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConnectResponse(t *testing.T) {
+	type tier string
+	name := "gopher"
+	response, err := NewConnectResponse(map[string]interface{}{
+		"name":      "gopher",
+		"tier":      tier("gold"),
+		"verified":  true,
+		"attempts":  3,
+		"balance":   -12.5,
+		"ratio":     float32(0.25),
+		"accountId": uint64(12345678901234567890),
+		"large":     1e21,
+		"number":    json.Number("42.0"),
+		"since":     time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		"pointer":   &name,
+		"nilPtr":    (*string)(nil),
+		"nilTime":   (*time.Time)(nil),
+		"missing":   nil,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ConnectResponse{
+		"name":      "gopher",
+		"tier":      "gold",
+		"verified":  "true",
+		"attempts":  "3",
+		"balance":   "-12.5",
+		"ratio":     "0.25",
+		"accountId": "12345678901234567890",
+		"large":     "1000000000000000000000",
+		"number":    "42.0",
+		"since":     "2026-01-02T03:04:05Z",
+		"pointer":   "gopher",
+		"nilPtr":    "",
+		"nilTime":   "",
+		"missing":   "",
+	}, response)
+
+	response, err = NewConnectResponse(nil)
+	require.NoError(t, err)
+	assert.Equal(t, ConnectResponse{}, response)
+}
+
+func TestNewConnectResponseRejectsNestedValues(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		value interface{}
+		err   string
+	}{
+		{"map", map[string]string{"a": "b"}, `connect response value "nested": nested value of type map[string]string is not supported, Connect only accepts flat string values`},
+		{"slice", []string{"a"}, `connect response value "nested": nested value of type []string is not supported, Connect only accepts flat string values`},
+		{"struct", struct{ A string }{"a"}, `connect response value "nested": nested value of type struct { A string } is not supported, Connect only accepts flat string values`},
+		{"pointer to map", &map[string]string{}, `connect response value "nested": nested value of type map[string]string is not supported, Connect only accepts flat string values`},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			response, err := NewConnectResponse(map[string]interface{}{"flat": "ok", "nested": test.value})
+			assert.EqualError(t, err, test.err)
+			assert.Nil(t, response)
+		})
+	}
+}
+
+type failingTextMarshaler struct{}
+
+var errTextMarshal = errors.New("not representable")
+
+func (failingTextMarshaler) MarshalText() ([]byte, error) { return nil, errTextMarshal }
+
+func TestNewConnectResponseMarshalTextError(t *testing.T) {
+	response, err := NewConnectResponse(map[string]interface{}{"value": failingTextMarshaler{}})
+	assert.EqualError(t, err, `connect response value "value": failed to marshal text: not representable`)
+	assert.True(t, errors.Is(err, errTextMarshal))
+	assert.Nil(t, response)
+}
@@ -31,6 +31,36 @@ func TestConnectMarshaling(t *testing.T) {
 	assert.JSONEq(t, string(inputJSON), string(outputJSON))
 }
 
+func TestConnectContactFlowEventMarshaling(t *testing.T) {
+	inputJSON := test.ReadJSONFromFile(t, "./testdata/connect-contact-flow-event.json")
+
+	var inputEvent ConnectEvent
+	if err := json.Unmarshal(inputJSON, &inputEvent); err != nil {
+		t.Errorf("could not unmarshal event. details: %v", err)
+	}
+
+	contactData := inputEvent.Details.ContactData
+	assert.Equal(t, "VOICE", contactData.Channel)
+	assert.Equal(t, "+12065550100", contactData.CustomerEndpoint.Address)
+	assert.Equal(t, "TELEPHONE_NUMBER", contactData.SystemEndpoint.Type)
+	assert.Equal(t, "BillingQueue", contactData.Queue.Name)
+	assert.Equal(t, &ConnectEndpoint{Address: "+12065550199", Type: "TELEPHONE_NUMBER"}, contactData.Queue.OutboundCallerID)
+	assert.Equal(t, "arn:aws:connect:us-west-2:123456789012:instance/9d4a8e2c-1f6b-4c3a-8e5d-2b7f0a1c3d4e", contactData.InstanceARN)
+	if assert.NotNil(t, contactData.MediaStreams) && assert.NotNil(t, contactData.MediaStreams.Customer.Audio) {
+		assert.Equal(t, "1571360125131", contactData.MediaStreams.Customer.Audio.StartTimestamp)
+	}
+	assert.Equal(t, ConnectReference{Type: "URL", Value: "https://example.com/cases/4242"}, contactData.References["CaseUrl"])
+	assert.Equal(t, "connect:Telephony", contactData.SegmentAttributes["connect:Subtype"].ValueString)
+	assert.Equal(t, map[string]string{"lookupTable": "accounts"}, inputEvent.Details.Parameters)
+
+	outputJSON, err := json.Marshal(inputEvent)
+	if err != nil {
+		t.Errorf("could not marshal event. details: %v", err)
+	}
+
+	assert.JSONEq(t, string(inputJSON), string(outputJSON))
+}
+
 func TestConnectMarshalingMalformedJson(t *testing.T) {
 	test.TestMalformedJson(t, ConnectEvent{})
 }
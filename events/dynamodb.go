@@ -2,6 +2,8 @@
 
 package events
 
+import "fmt"
+
 // The DynamoDBEvent stream event handled to Lambda
 // http://docs.aws.amazon.com/lambda/latest/dg/eventsources.html#eventsources-ddb-update
 type DynamoDBEvent struct {
@@ -71,6 +73,59 @@ type DynamoDBEventRecord struct {
 	UserIdentity *DynamoDBUserIdentity `json:"userIdentity,omitempty"`
 }
 
+// Validate checks that the images present in the record are consistent with its StreamViewType and EventName.
+// For example, a NEW_IMAGE record for an INSERT must have a NewImage, and a KEYS_ONLY record must have neither image.
+func (r DynamoDBEventRecord) Validate() error {
+	viewType := DynamoDBStreamViewType(r.Change.StreamViewType)
+	var wantNew, wantOld bool
+	switch viewType {
+	case DynamoDBStreamViewTypeKeysOnly:
+	case DynamoDBStreamViewTypeNewImage:
+		wantNew = r.EventName != string(DynamoDBOperationTypeRemove)
+	case DynamoDBStreamViewTypeOldImage:
+		wantOld = r.EventName != string(DynamoDBOperationTypeInsert)
+	case DynamoDBStreamViewTypeNewAndOldImages:
+		wantNew = r.EventName != string(DynamoDBOperationTypeRemove)
+		wantOld = r.EventName != string(DynamoDBOperationTypeInsert)
+	default:
+		return fmt.Errorf("dynamodb record %s: unknown StreamViewType %q", r.EventID, r.Change.StreamViewType)
+	}
+
+	if len(r.Change.Keys) == 0 {
+		return fmt.Errorf("dynamodb record %s: Keys are missing", r.EventID)
+	}
+	if err := checkDynamoDBImage(r, "NewImage", r.Change.NewImage != nil, wantNew); err != nil {
+		return err
+	}
+	return checkDynamoDBImage(r, "OldImage", r.Change.OldImage != nil, wantOld)
+}
+
+func checkDynamoDBImage(r DynamoDBEventRecord, image string, present, want bool) error {
+	switch {
+	case want && !present:
+		return fmt.Errorf("dynamodb record %s: %s is missing, but is expected for a %s event with StreamViewType %s", r.EventID, image, r.EventName, r.Change.StreamViewType)
+	case !want && present:
+		return fmt.Errorf("dynamodb record %s: %s is present, but is not expected for a %s event with StreamViewType %s", r.EventID, image, r.EventName, r.Change.StreamViewType)
+	}
+	return nil
+}
+
+// NewImageOrKeys returns the NewImage of the record, or the Keys if the stream view type or operation does not include a NewImage.
+func (r DynamoDBEventRecord) NewImageOrKeys() map[string]DynamoDBAttributeValue {
+	if r.Change.NewImage != nil {
+		return r.Change.NewImage
+	}
+	return r.Change.Keys
+}
+
+// OldImageOrKeys returns the OldImage of the record, or the Keys if the stream view type or operation does not include an OldImage.
+func (r DynamoDBEventRecord) OldImageOrKeys() map[string]DynamoDBAttributeValue {
+	if r.Change.OldImage != nil {
+		return r.Change.OldImage
+	}
+	return r.Change.Keys
+}
+
 type DynamoDBUserIdentity struct {
 	Type        string `json:"type"`
 	PrincipalID string `json:"principalId"`
@@ -8,6 +8,7 @@ import (
 
 	"github.com/aws/aws-lambda-go/events/test"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDynamoDBEventMarshaling(t *testing.T) {
@@ -58,3 +59,61 @@ func TestDynamoDBTimeWindowEventMarshaling(t *testing.T) {
 func TestDynamoDBTimeWindowEventMarshalingMalformedJson(t *testing.T) {
 	test.TestMalformedJson(t, DynamoDBTimeWindowEvent{})
 }
+
+func TestDynamoDBEventRecordValidate(t *testing.T) {
+	for _, file := range []string{
+		"./testdata/dynamodb-event.json",
+		"./testdata/dynamodb-event-keys-only.json",
+		"./testdata/dynamodb-event-new-image.json",
+		"./testdata/dynamodb-event-old-image.json",
+		"./testdata/dynamodb-event-new-and-old-images.json",
+	} {
+		t.Run(file, func(t *testing.T) {
+			inputJSON := test.ReadJSONFromFile(t, file)
+
+			var inputEvent DynamoDBEvent
+			require.NoError(t, json.Unmarshal(inputJSON, &inputEvent))
+			require.NotEmpty(t, inputEvent.Records)
+			for _, record := range inputEvent.Records {
+				assert.NoError(t, record.Validate())
+				assert.NotEmpty(t, record.NewImageOrKeys())
+				assert.NotEmpty(t, record.OldImageOrKeys())
+			}
+
+			outputJSON, err := json.Marshal(inputEvent)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(inputJSON), string(outputJSON))
+		})
+	}
+}
+
+func TestDynamoDBEventRecordValidateMismatch(t *testing.T) {
+	inputJSON := test.ReadJSONFromFile(t, "./testdata/dynamodb-event-keys-only.json")
+
+	var inputEvent DynamoDBEvent
+	require.NoError(t, json.Unmarshal(inputJSON, &inputEvent))
+
+	record := inputEvent.Records[1]
+	record.Change.StreamViewType = string(DynamoDBStreamViewTypeNewImage)
+	assert.EqualError(t, record.Validate(), "dynamodb record c4ca4238a0b923820dcc509a6f758491: NewImage is missing, but is expected for a MODIFY event with StreamViewType NEW_IMAGE")
+
+	record.Change.StreamViewType = string(DynamoDBStreamViewTypeKeysOnly)
+	record.Change.OldImage = map[string]DynamoDBAttributeValue{"Id": NewNumberAttribute("101")}
+	assert.EqualError(t, record.Validate(), "dynamodb record c4ca4238a0b923820dcc509a6f758491: OldImage is present, but is not expected for a MODIFY event with StreamViewType KEYS_ONLY")
+
+	record.Change.StreamViewType = "SOMETHING_ELSE"
+	assert.EqualError(t, record.Validate(), `dynamodb record c4ca4238a0b923820dcc509a6f758491: unknown StreamViewType "SOMETHING_ELSE"`)
+}
+
+func TestDynamoDBEventRecordImageOrKeys(t *testing.T) {
+	inputJSON := test.ReadJSONFromFile(t, "./testdata/dynamodb-event-new-image.json")
+
+	var inputEvent DynamoDBEvent
+	require.NoError(t, json.Unmarshal(inputJSON, &inputEvent))
+
+	insert, remove := inputEvent.Records[0], inputEvent.Records[2]
+	assert.Equal(t, "New item!", insert.NewImageOrKeys()["Message"].String())
+	assert.Equal(t, insert.Change.Keys, insert.OldImageOrKeys())
+	assert.Equal(t, remove.Change.Keys, remove.NewImageOrKeys())
+	assert.Equal(t, "101", remove.NewImageOrKeys()["Id"].Number())
+}
@@ -0,0 +1,19 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+const (
+	EC2EventSource = "aws.ec2"
+
+	EC2InstanceStateChangeDetailType = "EC2 Instance State-change Notification"
+)
+
+// EC2InstanceStateChangeEventDetail is the detail of an "EC2 Instance State-change Notification" event.
+// Use with CloudWatchEvent by unmarshaling the Detail field.
+//
+// See https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/monitoring-instance-state-changes.html
+type EC2InstanceStateChangeEventDetail struct {
+	InstanceID string `json:"instance-id"`
+	// State is pending, running, stopping, stopped, shutting-down or terminated.
+	State string `json:"state"`
+}
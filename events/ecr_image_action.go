@@ -14,6 +14,18 @@ type ECRImageActionEvent struct {
 	Detail     ECRImageActionEventDetailType `json:"detail"`
 }
 
+// ECRImageActionEventDetail is the detail of an ECRImageActionEvent.
+type ECRImageActionEventDetail = ECRImageActionEventDetailType
+
+// The ActionType and Result values of ECRImageActionEventDetailType.
+const (
+	ECRImageActionTypePush   = "PUSH"
+	ECRImageActionTypeDelete = "DELETE"
+
+	ECRImageActionResultSuccess = "SUCCESS"
+	ECRImageActionResultFailure = "FAILURE"
+)
+
 type ECRImageActionEventDetailType struct {
 	Result         string `json:"result"`
 	RepositoryName string `json:"repository-name"`
@@ -54,3 +54,26 @@ func TestECRImageActionEventMarshaling(t *testing.T) {
 func TestECRPushMarshalingMalformedJson(t *testing.T) {
 	test.TestMalformedJson(t, ECRImageActionEvent{})
 }
+
+func TestECRDeleteEventRoundTrip(t *testing.T) {
+	var inputEvent ECRImageActionEvent
+	if err := json.Unmarshal(test.ReadJSONFromFile(t, "./testdata/ecr-image-delete-event.json"), &inputEvent); err != nil {
+		t.Errorf("could not unmarshal event. details: %v", err)
+	}
+	var detail ECRImageActionEventDetail = inputEvent.Detail
+	assert.Equal(t, ECRImageActionTypeDelete, detail.ActionType)
+	assert.Equal(t, ECRImageActionResultSuccess, detail.Result)
+	assert.Equal(t, "v1.4.1", detail.ImageTag)
+	assert.Equal(t, time.Date(2024, 6, 12, 9, 2, 11, 0, time.UTC), inputEvent.Time.UTC())
+
+	outputJSON, err := json.Marshal(inputEvent)
+	if err != nil {
+		t.Errorf("could not marshal event. details: %v", err)
+	}
+	var outputEvent ECRImageActionEvent
+	if err := json.Unmarshal(outputJSON, &outputEvent); err != nil {
+		t.Errorf("could not unmarshal event. details: %v", err)
+	}
+	assert.True(t, inputEvent.Time.Equal(outputEvent.Time))
+	assert.Equal(t, inputEvent.Detail, outputEvent.Detail)
+}
@@ -12,6 +12,15 @@ type ECRScanEvent struct {
 	Detail     ECRScanEventDetailType `json:"detail"`
 }
 
+// ECRScanEventDetail is the detail of an ECRScanEvent.
+type ECRScanEventDetail = ECRScanEventDetailType
+
+// The ScanStatus values of ECRScanEventDetailType.
+const (
+	ECRScanStatusComplete = "COMPLETE"
+	ECRScanStatusFailed   = "FAILED"
+)
+
 type ECRScanEventDetailType struct {
 	ScanStatus            string                            `json:"scan-status"`
 	RepositoryName        string                            `json:"repository-name"`
@@ -20,6 +29,8 @@ type ECRScanEventDetailType struct {
 	ImageTags             []string                          `json:"image-tags"`
 }
 
+// ECRScanEventFindingSeverityCounts counts the findings of a scan by severity. The severities without findings are
+// not sent, and are 0.
 type ECRScanEventFindingSeverityCounts struct {
 	Critical      int64 `json:"CRITICAL"`
 	High          int64 `json:"HIGH"`
@@ -51,6 +51,40 @@ func TestECRScanEventMarshaling(t *testing.T) {
 	assert.JSONEq(t, string(inputJSON), string(outputJSON))
 }
 
+func TestECRScanEventRoundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		file   string
+		status string
+		counts ECRScanEventFindingSeverityCounts
+		tags   []string
+		time   string
+	}{
+		{"./testdata/ecr-image-scan-findings-event.json", ECRScanStatusComplete, ECRScanEventFindingSeverityCounts{Critical: 1, High: 4, Informational: 12}, []string{"v1.4.2", "latest"}, "2024-06-12T08:15:42Z"},
+		{"./testdata/ecr-image-scan-failed-event.json", ECRScanStatusFailed, ECRScanEventFindingSeverityCounts{}, []string{}, "2024-06-12T08:20:03Z"},
+	} {
+		var inputEvent ECRScanEvent
+		if err := json.Unmarshal(test.ReadJSONFromFile(t, tc.file), &inputEvent); err != nil {
+			t.Errorf("could not unmarshal event. details: %v", err)
+		}
+		var detail ECRScanEventDetail = inputEvent.Detail
+		assert.Equal(t, tc.status, detail.ScanStatus)
+		assert.Equal(t, tc.counts, detail.FindingSeverityCounts)
+		assert.Equal(t, tc.tags, detail.ImageTags)
+		assert.Equal(t, tc.time, inputEvent.Time)
+
+		// the severities without findings are sent back as 0, which reads back the same
+		outputJSON, err := json.Marshal(inputEvent)
+		if err != nil {
+			t.Errorf("could not marshal event. details: %v", err)
+		}
+		var outputEvent ECRScanEvent
+		if err := json.Unmarshal(outputJSON, &outputEvent); err != nil {
+			t.Errorf("could not unmarshal event. details: %v", err)
+		}
+		assert.Equal(t, inputEvent, outputEvent)
+	}
+}
+
 func TestECRScanMarshalingMalformedJson(t *testing.T) {
 	test.TestMalformedJson(t, ECRScanEvent{})
 }
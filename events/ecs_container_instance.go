@@ -29,16 +29,24 @@ type ECSContainerInstanceEventDetailType struct {
 	Version              int                                  `json:"version"`
 	VersionInfo          ECSContainerInstanceEventVersionInfo `json:"versionInfo"`
 	UpdatedAt            time.Time                            `json:"updatedAt"`
+	RegisteredAt         *time.Time                           `json:"registeredAt,omitempty"`
+	AccountType          string                               `json:"accountType,omitempty"`
+	PendingTasksCount    int                                  `json:"pendingTasksCount,omitempty"`
+	RunningTasksCount    int                                  `json:"runningTasksCount,omitempty"`
+	AgentUpdateStatus    string                               `json:"agentUpdateStatus,omitempty"`
 }
 
 type ECSContainerInstanceEventAttribute struct {
-	Name string `json:"name"`
+	Name  string `json:"name"`
+	Value string `json:"value,omitempty"`
 }
 
 type ECSContainerInstanceEventResource struct {
 	Name           string    `json:"name"`
 	Type           string    `json:"type"`
 	IntegerValue   int       `json:"integerValue,omitempty"`
+	LongValue      int64     `json:"longValue,omitempty"`
+	DoubleValue    float64   `json:"doubleValue,omitempty"`
 	StringSetValue []*string `json:"stringSetValue,omitempty"`
 }
 
@@ -4,6 +4,8 @@ package events
 
 import (
 	"encoding/json"
+	"fmt"
+	"math"
 	"time"
 )
 
@@ -25,6 +27,11 @@ type MilliSecondsEpochTime struct {
 const secondsToNanoSecondsFactor = 1000000000
 const milliSecondsToNanoSecondsFactor = 1000000
 
+// maxEpochSeconds and maxEpochMilliSeconds bound the epoch times that can be marshaled back,
+// the range of time.UnixNano, from the years 1678 to 2262.
+const maxEpochSeconds = math.MaxInt64 / secondsToNanoSecondsFactor
+const maxEpochMilliSeconds = math.MaxInt64 / milliSecondsToNanoSecondsFactor
+
 func (e SecondsEpochTime) MarshalJSON() ([]byte, error) {
 	// UnixNano() returns the epoch in nanoseconds
 	unixTime := float64(e.UnixNano()) / float64(secondsToNanoSecondsFactor)
@@ -37,6 +44,9 @@ func (e *SecondsEpochTime) UnmarshalJSON(b []byte) error {
 	if err != nil {
 		return err
 	}
+	if epoch > maxEpochSeconds || epoch < -maxEpochSeconds {
+		return fmt.Errorf("epoch time %v seconds is out of range", epoch)
+	}
 
 	epochSec := int64(epoch)
 	epochNano := int64((epoch - float64(epochSec)) * float64(secondsToNanoSecondsFactor))
@@ -59,6 +69,9 @@ func (e *MilliSecondsEpochTime) UnmarshalJSON(b []byte) error {
 	if err != nil {
 		return err
 	}
+	if epoch > maxEpochMilliSeconds || epoch < -maxEpochMilliSeconds {
+		return fmt.Errorf("epoch time %v milliseconds is out of range", epoch)
+	}
 	*e = MilliSecondsEpochTime{time.Unix(epoch/1000, (epoch%1000)*1000000)}
 	return nil
 }
@@ -68,3 +68,27 @@ func TestMarshalMilliSecondsEpochTime(t *testing.T) {
 
 	assert.Equal(t, "1480641523476", string(marshaled))
 }
+
+func TestUnmarshalEpochTimeOutOfRange(t *testing.T) {
+	for _, input := range []string{"1e19", "-1e19", "1e300", "9223372037"} {
+		var epoch SecondsEpochTime
+		assert.Error(t, json.Unmarshal([]byte(input), &epoch), input)
+	}
+	for _, input := range []string{"9223372036854775807", "-9223372036854775808", "9223372036855"} {
+		var epoch MilliSecondsEpochTime
+		assert.Error(t, json.Unmarshal([]byte(input), &epoch), input)
+	}
+
+	// the limits still round trip
+	var seconds SecondsEpochTime
+	assert.NoError(t, json.Unmarshal([]byte("9223372036"), &seconds))
+	marshaled, err := json.Marshal(seconds)
+	assert.NoError(t, err)
+	assert.Equal(t, "9223372036", string(marshaled))
+
+	var milliSeconds MilliSecondsEpochTime
+	assert.NoError(t, json.Unmarshal([]byte("-9223372036854"), &milliSeconds))
+	marshaled, err = json.Marshal(milliSeconds)
+	assert.NoError(t, err)
+	assert.Equal(t, "-9223372036854", string(marshaled))
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+// EventBridgeSchedulerEvent is the input of a Lambda function invoked by an EventBridge Scheduler schedule.
+//
+// Scheduler invokes the function with the input of the schedule target as is, which is kept in Input. To also get the
+// context of the invocation, the target input can wrap the payload in an envelope with the context attributes:
+//
+//	{
+//	  "schedule-arn": "<aws.scheduler.schedule-arn>",
+//	  "scheduled-time": "<aws.scheduler.scheduled-time>",
+//	  "execution-id": "<aws.scheduler.execution-id>",
+//	  "attempt-number": <aws.scheduler.attempt-number>,
+//	  "input": { ... }
+//	}
+//
+// An event is read as an envelope when it has a "schedule-arn" key, and as the bare input otherwise.
+// See https://docs.aws.amazon.com/scheduler/latest/UserGuide/managing-schedule-context-attributes.html
+type EventBridgeSchedulerEvent struct {
+	ScheduleARN string `json:"schedule-arn"`
+	// ScheduledTime is the time the schedule was due, which can be earlier than the invocation with a flexible
+	// time window, or when the invocation was retried.
+	ScheduledTime time.Time `json:"scheduled-time"`
+	ExecutionID   string    `json:"execution-id"`
+	// AttemptNumber starts at 1, and is incremented for each retry of the invocation.
+	AttemptNumber int `json:"attempt-number"`
+
+	// Input is the payload of the envelope, or the whole event when it is not an envelope.
+	Input json.RawMessage `json:"input,omitempty"`
+}
+
+type eventBridgeSchedulerEvent EventBridgeSchedulerEvent
+
+// HasContext reports whether the event was delivered in an envelope with the context attributes of the invocation.
+func (e EventBridgeSchedulerEvent) HasContext() bool {
+	return e.ScheduleARN != ""
+}
+
+// UnmarshalJSON reads data as an envelope when it has a "schedule-arn" key, and as the bare input otherwise.
+func (e *EventBridgeSchedulerEvent) UnmarshalJSON(data []byte) error {
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keys); err == nil {
+		if _, ok := keys["schedule-arn"]; ok {
+			var envelope eventBridgeSchedulerEvent
+			if err := json.Unmarshal(data, &envelope); err != nil {
+				return err
+			}
+			*e = EventBridgeSchedulerEvent(envelope)
+			return nil
+		}
+	} else if _, ok := err.(*json.SyntaxError); ok {
+		return err
+	}
+	*e = EventBridgeSchedulerEvent{Input: append(json.RawMessage(nil), bytes.TrimSpace(data)...)}
+	return nil
+}
+
+// MarshalJSON writes the envelope when the event has context attributes, and the bare input otherwise.
+func (e EventBridgeSchedulerEvent) MarshalJSON() ([]byte, error) {
+	if e.HasContext() {
+		return json.Marshal(eventBridgeSchedulerEvent(e))
+	}
+	if len(e.Input) == 0 {
+		return []byte("null"), nil
+	}
+	return e.Input, nil
+}
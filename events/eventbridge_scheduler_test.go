@@ -0,0 +1,73 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventBridgeSchedulerEventContextMarshaling(t *testing.T) {
+	inputJSON := test.ReadJSONFromFile(t, "./testdata/eventbridge-scheduler-context.json")
+
+	var inputEvent EventBridgeSchedulerEvent
+	require.NoError(t, json.Unmarshal(inputJSON, &inputEvent))
+	assert.True(t, inputEvent.HasContext())
+	assert.Equal(t, "arn:aws:scheduler:us-east-1:123456789012:schedule/default/daily-sales-report", inputEvent.ScheduleARN)
+	assert.Equal(t, time.Date(2026, 3, 14, 6, 0, 0, 0, time.UTC), inputEvent.ScheduledTime)
+	assert.Equal(t, "c5c3e4f2-5b2f-4a3c-9d7e-0f1a2b3c4d5e", inputEvent.ExecutionID)
+	assert.Equal(t, 2, inputEvent.AttemptNumber)
+
+	var input struct {
+		Report string `json:"report"`
+	}
+	require.NoError(t, json.Unmarshal(inputEvent.Input, &input))
+	assert.Equal(t, "daily-sales", input.Report)
+
+	outputJSON, err := json.Marshal(inputEvent)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(inputJSON), string(outputJSON))
+}
+
+func TestEventBridgeSchedulerEventInputMarshaling(t *testing.T) {
+	inputJSON := test.ReadJSONFromFile(t, "./testdata/eventbridge-scheduler-input.json")
+
+	var inputEvent EventBridgeSchedulerEvent
+	require.NoError(t, json.Unmarshal(inputJSON, &inputEvent))
+	assert.False(t, inputEvent.HasContext())
+	assert.True(t, inputEvent.ScheduledTime.IsZero())
+	// the whole event is the input, including the fields unknown to this package
+	assert.JSONEq(t, string(inputJSON), string(inputEvent.Input))
+
+	outputJSON, err := json.Marshal(inputEvent)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(inputJSON), string(outputJSON))
+}
+
+func TestEventBridgeSchedulerEventNonObjectInput(t *testing.T) {
+	for _, inputJSON := range []string{`"run"`, `[1, 2]`, `42`, `null`} {
+		var inputEvent EventBridgeSchedulerEvent
+		require.NoError(t, json.Unmarshal([]byte(inputJSON), &inputEvent))
+		assert.False(t, inputEvent.HasContext())
+		assert.Equal(t, inputJSON, string(inputEvent.Input))
+
+		outputJSON, err := json.Marshal(inputEvent)
+		require.NoError(t, err)
+		assert.JSONEq(t, inputJSON, string(outputJSON))
+	}
+}
+
+func TestEventBridgeSchedulerEventInvalidScheduledTime(t *testing.T) {
+	var inputEvent EventBridgeSchedulerEvent
+	err := json.Unmarshal([]byte(`{"schedule-arn": "arn", "scheduled-time": "<aws.scheduler.scheduled-time>"}`), &inputEvent)
+	assert.Error(t, err)
+}
+
+func TestEventBridgeSchedulerEventMalformedJson(t *testing.T) {
+	test.TestMalformedJson(t, EventBridgeSchedulerEvent{})
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEventBridgeDetailJSONKeys checks that the types of the events delivered by EventBridge have a field for each key of
+// the samples of the EventBridge schema registry, with the exact casing, as code bindings generated from the registry
+// would, so that events passing through both are not partially unmarshaled.
+func TestEventBridgeDetailJSONKeys(t *testing.T) {
+	for _, tc := range []struct {
+		file string
+		// detailOnly is set for the detail types used with CloudWatchEvent, whose Detail is checked alone.
+		detailOnly bool
+		event      interface{}
+	}{
+		{file: "auth0-log-event.json", detailOnly: true, event: &Auth0LogEventDetail{}},
+		{file: "autoscaling-event-lifecycle-action.json", event: &AutoScalingLifecycleActionEvent{}},
+		{file: "autoscaling-event-terminate-action.json", event: &AutoScalingLifecycleActionEvent{}},
+		{file: "codebuild-phase-change.json", event: &CodeBuildEvent{}},
+		{file: "codebuild-state-change.json", event: &CodeBuildEvent{}},
+		{file: "codebuild-state-change-vpc.json", event: &CodeBuildEvent{}},
+		{file: "codedeploy-deployment-event.json", event: &CodeDeployEvent{}},
+		{file: "codedeploy-instance-event.json", event: &CodeDeployEvent{}},
+		{file: "codepipeline-action-execution-stage-change-event.json", event: &CodePipelineCloudWatchEvent{}},
+		{file: "codepipeline-execution-stage-change-event.json", event: &CodePipelineCloudWatchEvent{}},
+		{file: "codepipeline-execution-state-change-event.json", event: &CodePipelineCloudWatchEvent{}},
+		{file: "codepipeline-execution-state-change-trigger-event.json", event: &CodePipelineCloudWatchEvent{}},
+		{file: "ec2-instance-state-change.json", detailOnly: true, event: &EC2InstanceStateChangeEventDetail{}},
+		{file: "ecr-image-delete-event.json", event: &ECRImageActionEvent{}},
+		{file: "ecr-image-push-event.json", event: &ECRImageActionEvent{}},
+		{file: "ecr-image-scan-event.json", event: &ECRScanEvent{}},
+		{file: "ecr-image-scan-failed-event.json", event: &ECRScanEvent{}},
+		{file: "ecr-image-scan-findings-event.json", event: &ECRScanEvent{}},
+		{file: "ecs-container-instance-state-change.json", event: &ECSContainerInstanceEvent{}},
+		{file: "ecs-container-instance-state-change-registered.json", event: &ECSContainerInstanceEvent{}},
+		{file: "s3-eventbridge-object-created.json", event: &S3EventBridgeEvent{}},
+		{file: "s3-eventbridge-object-deleted.json", event: &S3EventBridgeEvent{}},
+		{file: "s3-eventbridge-object-restore-completed.json", event: &S3EventBridgeEvent{}},
+	} {
+		tc := tc
+		t.Run(tc.file, func(t *testing.T) {
+			test.AssertJSONKeysCovered(t, readEventBridgeFixture(t, tc.file, tc.detailOnly), tc.event)
+		})
+	}
+}
+
+func readEventBridgeFixture(t *testing.T, file string, detailOnly bool) []byte {
+	inputJSON := test.ReadJSONFromFile(t, "./testdata/"+file)
+	if !detailOnly {
+		return inputJSON
+	}
+	var event CloudWatchEvent
+	require.NoError(t, json.Unmarshal(inputJSON, &event))
+	return event.Detail
+}
+
+func TestUncoveredJSONKeys(t *testing.T) {
+	type instance struct {
+		// encoding/json would fill these with "InstanceID" and "STATE", but drop "instance-id"
+		InstanceID string `json:"instanceId"`
+		State      string `json:"state"`
+		Tags       map[string]struct {
+			Value string `json:"value"`
+		} `json:"tags"`
+	}
+	type event struct {
+		Detail    instance   `json:"detail"`
+		Instances []instance `json:"instances"`
+		Time      time.Time  `json:"time"`
+		Raw       json.RawMessage
+		Ignored   string `json:"-"`
+	}
+	inputJSON := []byte(`{
+		"detail": {"instance-id": "i-1", "InstanceID": "i-1", "STATE": "running", "tags": {"team": {"value": "a", "Value": "b"}}},
+		"instances": [{"instanceId": "i-2"}, {"ipv4-address": "10.0.0.1"}],
+		"time": "2021-11-11T21:29:54Z",
+		"Raw": {"anything": true},
+		"Ignored": "x",
+		"extra": 1
+	}`)
+
+	missing, err := test.UncoveredJSONKeys(inputJSON, &event{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		".Ignored",
+		".detail.InstanceID",
+		".detail.STATE",
+		".detail.instance-id",
+		".detail.tags.team.Value",
+		".extra",
+		".instances[].ipv4-address",
+	}, missing)
+}
+
+func TestEventBridgeDetailJSONKeysFixes(t *testing.T) {
+	var ecs ECSContainerInstanceEvent
+	require.NoError(t, json.Unmarshal(readEventBridgeFixture(t, "ecs-container-instance-state-change-registered.json", false), &ecs))
+	assert.Equal(t, "us-east-1a", ecs.Detail.Attributes[0].Value)
+	assert.Equal(t, int64(17179869184), ecs.Detail.RegisteredResources[2].LongValue)
+	assert.Equal(t, 1.5, ecs.Detail.RegisteredResources[3].DoubleValue)
+	require.NotNil(t, ecs.Detail.RegisteredAt)
+	assert.Equal(t, time.Date(2023, 6, 12, 9, 10, 3, 456000000, time.UTC), *ecs.Detail.RegisteredAt)
+	assert.Equal(t, 1, ecs.Detail.PendingTasksCount)
+	assert.Equal(t, 2, ecs.Detail.RunningTasksCount)
+	assert.Equal(t, "UPDATED", ecs.Detail.AgentUpdateStatus)
+
+	var codebuild CodeBuildEvent
+	require.NoError(t, json.Unmarshal(readEventBridgeFixture(t, "codebuild-state-change-vpc.json", false), &codebuild))
+	info := codebuild.Detail.AdditionalInformation
+	assert.Equal(t, DurationMinutes(8*time.Hour), info.QueuedTimeout)
+	assert.Equal(t, &CodeBuildCache{Type: "S3", Location: "codebuild-123456789012-cache-bucket/my-vpc-project"}, info.Cache)
+	assert.Equal(t, &CodeBuildNetworkInterface{SubnetID: "subnet-0123456789abcdef0", NetworkInterfaceID: "eni-0123456789abcdef0"}, info.NetworkInterface)
+	assert.Equal(t, "CODEBUILD", info.Environment.ImagePullCredentialsType)
+	assert.Equal(t, "buildspec.yml", info.Source.Buildspec)
+
+	var codepipeline CodePipelineCloudWatchEvent
+	require.NoError(t, json.Unmarshal(readEventBridgeFixture(t, "codepipeline-execution-state-change-trigger-event.json", false), &codepipeline))
+	require.NotNil(t, codepipeline.Detail.ExecutionTrigger)
+	assert.Equal(t, "StartPipelineExecution", codepipeline.Detail.ExecutionTrigger.TriggerType)
+
+	var ec2 EC2InstanceStateChangeEventDetail
+	require.NoError(t, json.Unmarshal(readEventBridgeFixture(t, "ec2-instance-state-change.json", true), &ec2))
+	assert.Equal(t, EC2InstanceStateChangeEventDetail{InstanceID: "i-abcd1111", State: "pending"}, ec2)
+}
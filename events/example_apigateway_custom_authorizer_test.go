@@ -31,6 +31,21 @@ func ExampleAPIGatewayCustomAuthorizerRequest() {
 	})
 }
 
+// This is a WebSocket API authorizer example. Browsers cannot set headers on a WebSocket connection,
+// so the client passes its token in the query string of the $connect request.
+func ExampleAPIGatewayWebsocketAuthorizerRequest() {
+	lambda.Start(func(ctx context.Context, event events.APIGatewayWebsocketAuthorizerRequest) (events.APIGatewayCustomAuthorizerResponse, error) {
+		switch event.QueryStringParameter("token") {
+		case "allow":
+			return event.Allow("user"), nil
+		case "":
+			return events.APIGatewayCustomAuthorizerResponse{}, errors.New("Unauthorized")
+		default:
+			return event.Deny("user"), nil
+		}
+	})
+}
+
 func generatePolicy(principalID, effect, resource string) *events.APIGatewayCustomAuthorizerResponse {
 	authResponse := &events.APIGatewayCustomAuthorizerResponse{PrincipalID: principalID}
 
@@ -18,3 +18,13 @@ func ExampleAPIGatewayProxyStreamingResponse() {
 		}, nil
 	})
 }
+
+func ExampleNewAPIGatewayProxyJSONResponse() {
+	lambda.Start(func(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		response, err := events.NewAPIGatewayProxyJSONResponse(200, map[string]string{"path": request.Path})
+		if err != nil {
+			return events.APIGatewayProxyResponse{}, err
+		}
+		return response.WithCORS("https://example.com", "GET"), nil
+	})
+}
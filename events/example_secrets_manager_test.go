@@ -16,14 +16,14 @@ func ExampleSecretsManagerSecretRotationEvent() {
 			event.SecretID, event.ClientRequestToken)
 
 		switch event.Step {
-		case "createSecret":
+		case events.SecretsManagerSecretRotationStepCreateSecret:
 			// create
-		case "setSecret":
+		case events.SecretsManagerSecretRotationStepSetSecret:
 			// set
-		case "finishSecret":
-			// finish
-		case "testSecret":
+		case events.SecretsManagerSecretRotationStepTestSecret:
 			// test
+		case events.SecretsManagerSecretRotationStepFinishSecret:
+			// finish
 		}
 
 		return nil
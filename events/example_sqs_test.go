@@ -16,3 +16,14 @@ func ExampleSQSEvent() {
 		return nil
 	})
 }
+
+func ExampleSQSEvent_BatchResponse() {
+	lambda.Start(func(ctx context.Context, sqsEvent events.SQSEvent) (events.SQSEventResponse, error) {
+		return sqsEvent.BatchResponse(func(message events.SQSMessage) error {
+			if message.Body == "" {
+				return fmt.Errorf("message %s is empty", message.MessageId)
+			}
+			return nil
+		}), nil
+	})
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"fmt"
+	"strings"
+)
+
+// KinesisFirehoseResponseBuilder builds the response of a data transformation, which Firehose only accepts with
+// exactly one record for each record of the event, in the same order.
+//
+//	builder := events.NewKinesisFirehoseResponseBuilder(event)
+//	for _, record := range event.Records {
+//		transformed, err := transform(record.Data)
+//		if err != nil {
+//			builder.ProcessingFailed(record.RecordID)
+//			continue
+//		}
+//		builder.Ok(record.RecordID, transformed)
+//	}
+//	return builder.Build()
+type KinesisFirehoseResponseBuilder struct {
+	records []KinesisFirehoseEventRecord
+	index   map[string]int
+	results map[string]KinesisFirehoseResponseRecord
+	err     error
+}
+
+// NewKinesisFirehoseResponseBuilder returns a builder of the response to event.
+func NewKinesisFirehoseResponseBuilder(event KinesisFirehoseEvent) *KinesisFirehoseResponseBuilder {
+	b := &KinesisFirehoseResponseBuilder{
+		records: event.Records,
+		index:   make(map[string]int, len(event.Records)),
+		results: make(map[string]KinesisFirehoseResponseRecord, len(event.Records)),
+	}
+	for i, record := range event.Records {
+		b.index[record.RecordID] = i
+	}
+	return b
+}
+
+// Ok reports the record recordID as transformed into data.
+func (b *KinesisFirehoseResponseBuilder) Ok(recordID string, data []byte) *KinesisFirehoseResponseBuilder {
+	return b.OkWithPartitionKeys(recordID, data, nil)
+}
+
+// OkWithPartitionKeys reports the record recordID as transformed into data, delivered to the prefix of partitionKeys
+// with dynamic partitioning.
+func (b *KinesisFirehoseResponseBuilder) OkWithPartitionKeys(recordID string, data []byte, partitionKeys map[string]string) *KinesisFirehoseResponseBuilder {
+	b.add(KinesisFirehoseResponseRecord{
+		RecordID: recordID,
+		Result:   KinesisFirehoseTransformedStateOk,
+		Data:     data,
+		Metadata: KinesisFirehoseResponseRecordMetadata{PartitionKeys: partitionKeys},
+	})
+	return b
+}
+
+// Dropped reports the record recordID as intentionally not delivered.
+func (b *KinesisFirehoseResponseBuilder) Dropped(recordID string) *KinesisFirehoseResponseBuilder {
+	b.add(KinesisFirehoseResponseRecord{RecordID: recordID, Result: KinesisFirehoseTransformedStateDropped})
+	return b
+}
+
+// ProcessingFailed reports the record recordID as failed to transform, to be delivered to the error output prefix
+// of the delivery stream.
+func (b *KinesisFirehoseResponseBuilder) ProcessingFailed(recordID string) *KinesisFirehoseResponseBuilder {
+	b.add(KinesisFirehoseResponseRecord{RecordID: recordID, Result: KinesisFirehoseTransformedStateProcessingFailed})
+	return b
+}
+
+func (b *KinesisFirehoseResponseBuilder) add(record KinesisFirehoseResponseRecord) {
+	i, ok := b.index[record.RecordID]
+	if !ok {
+		b.fail(fmt.Errorf("record %q is not in the event", record.RecordID))
+		return
+	}
+	if _, ok := b.results[record.RecordID]; ok {
+		b.fail(fmt.Errorf("record %q has more than one result", record.RecordID))
+		return
+	}
+	if record.Result != KinesisFirehoseTransformedStateOk {
+		// the records not transformed are returned with their original data
+		record.Data = b.records[i].Data
+	}
+	b.results[record.RecordID] = record
+}
+
+func (b *KinesisFirehoseResponseBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// Build returns the response with the records in the order of the event. It returns an error if a record of the
+// event has no result, or if a result was reported more than once, or for a record not in the event.
+func (b *KinesisFirehoseResponseBuilder) Build() (KinesisFirehoseResponse, error) {
+	if b.err != nil {
+		return KinesisFirehoseResponse{}, b.err
+	}
+	response := KinesisFirehoseResponse{Records: make([]KinesisFirehoseResponseRecord, 0, len(b.records))}
+	var missing []string
+	for _, record := range b.records {
+		result, ok := b.results[record.RecordID]
+		if !ok {
+			missing = append(missing, fmt.Sprintf("%q", record.RecordID))
+			continue
+		}
+		response.Records = append(response.Records, result)
+	}
+	if len(missing) > 0 {
+		return KinesisFirehoseResponse{}, fmt.Errorf("no result for the records %s", strings.Join(missing, ", "))
+	}
+	return response, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testFirehoseEvent() KinesisFirehoseEvent {
+	return KinesisFirehoseEvent{
+		InvocationID: "invocation-1",
+		Records: []KinesisFirehoseEventRecord{
+			{RecordID: "record-1", Data: []byte(`{"type":"click"}`)},
+			{RecordID: "record-2", Data: []byte(`not json`)},
+			{RecordID: "record-3", Data: []byte(`{"type":"view"}`)},
+		},
+	}
+}
+
+func TestKinesisFirehoseResponseBuilderOrder(t *testing.T) {
+	response, err := NewKinesisFirehoseResponseBuilder(testFirehoseEvent()).
+		Dropped("record-3").
+		Ok("record-1", []byte(`{"type":"CLICK"}`)).
+		ProcessingFailed("record-2").
+		Build()
+	require.NoError(t, err)
+
+	responseJSON, err := json.Marshal(response)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"records": [
+			{
+				"recordId": "record-1",
+				"result": "Ok",
+				"data": "eyJ0eXBlIjoiQ0xJQ0sifQ==",
+				"metadata": {"partitionKeys": null, "otfMetadata": {"destinationDatabaseName": "", "destinationTableName": "", "operation": ""}}
+			},
+			{
+				"recordId": "record-2",
+				"result": "ProcessingFailed",
+				"data": "bm90IGpzb24=",
+				"metadata": {"partitionKeys": null, "otfMetadata": {"destinationDatabaseName": "", "destinationTableName": "", "operation": ""}}
+			},
+			{
+				"recordId": "record-3",
+				"result": "Dropped",
+				"data": "eyJ0eXBlIjoidmlldyJ9",
+				"metadata": {"partitionKeys": null, "otfMetadata": {"destinationDatabaseName": "", "destinationTableName": "", "operation": ""}}
+			}
+		]
+	}`, string(responseJSON))
+}
+
+func TestKinesisFirehoseResponseBuilderPartitionKeys(t *testing.T) {
+	response, err := NewKinesisFirehoseResponseBuilder(testFirehoseEvent()).
+		OkWithPartitionKeys("record-1", []byte("click"), map[string]string{"type": "click", "year": "2024"}).
+		Dropped("record-2").
+		OkWithPartitionKeys("record-3", []byte("view"), map[string]string{"type": "view", "year": "2024"}).
+		Build()
+	require.NoError(t, err)
+	require.Len(t, response.Records, 3)
+	assert.Equal(t, map[string]string{"type": "click", "year": "2024"}, response.Records[0].Metadata.PartitionKeys)
+	assert.Nil(t, response.Records[1].Metadata.PartitionKeys)
+	assert.Equal(t, map[string]string{"type": "view", "year": "2024"}, response.Records[2].Metadata.PartitionKeys)
+	assert.Equal(t, []byte("view"), response.Records[2].Data)
+}
+
+func TestKinesisFirehoseResponseBuilderErrors(t *testing.T) {
+	_, err := NewKinesisFirehoseResponseBuilder(testFirehoseEvent()).
+		Ok("record-1", nil).
+		Build()
+	assert.EqualError(t, err, `no result for the records "record-2", "record-3"`)
+
+	_, err = NewKinesisFirehoseResponseBuilder(testFirehoseEvent()).
+		Ok("record-1", nil).
+		Dropped("record-2").
+		ProcessingFailed("record-1").
+		Ok("record-3", nil).
+		Build()
+	assert.EqualError(t, err, `record "record-1" has more than one result`)
+
+	_, err = NewKinesisFirehoseResponseBuilder(testFirehoseEvent()).
+		Ok("record-1", nil).
+		Ok("record-2", nil).
+		Ok("record-3", nil).
+		Ok("record-4", nil).
+		Build()
+	assert.EqualError(t, err, `record "record-4" is not in the event`)
+
+	response, err := NewKinesisFirehoseResponseBuilder(KinesisFirehoseEvent{}).Build()
+	require.NoError(t, err)
+	responseJSON, err := json.Marshal(response)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"records": []}`, string(responseJSON))
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+//go:build go1.18
+// +build go1.18
+
+package events
+
+import (
+	"crypto/md5"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fuzzUnmarshal checks that decoding arbitrary input into a new T, and encoding whatever was decoded,
+// never panics. Errors are expected, a partially decoded event is still encoded, since encoding/json
+// keeps decoding after an UnmarshalJSON method fails.
+func fuzzUnmarshal[T any](f *testing.F, seedPatterns ...string) {
+	for _, pattern := range seedPatterns {
+		files, err := filepath.Glob(filepath.Join("testdata", pattern))
+		if err != nil {
+			f.Fatal(err)
+		}
+		for _, file := range files {
+			b, err := os.ReadFile(file)
+			if err != nil {
+				f.Fatal(err)
+			}
+			f.Add(b)
+		}
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var event T
+		_ = json.Unmarshal(data, &event)
+		_, _ = json.Marshal(event)
+	})
+}
+
+func FuzzS3Event(f *testing.F) {
+	fuzzUnmarshal[S3Event](f, "s3-event*.json")
+}
+
+func FuzzSQSEvent(f *testing.F) {
+	fuzzUnmarshal[SQSEvent](f, "sqs-event*.json")
+}
+
+func FuzzDynamoDBEvent(f *testing.F) {
+	f.Add([]byte(`{"Records":[{"dynamodb":{"Keys":{"id":{"BS":[1]}}}}]}`))
+	f.Add([]byte(`{"Records":[{"dynamodb":{"Keys":{"id":{"S":1}},"ApproximateCreationDateTime":1e300}}]}`))
+	fuzzUnmarshal[DynamoDBEvent](f, "dynamodb-event*.json", "dynamodb-time-window-event.json")
+}
+
+func FuzzKinesisEvent(f *testing.F) {
+	fuzzUnmarshal[KinesisEvent](f, "kinesis-event*.json")
+}
+
+func FuzzAPIGatewayV2HTTPRequest(f *testing.F) {
+	fuzzUnmarshal[APIGatewayV2HTTPRequest](f, "apigw-v2-request-*.json")
+}
+
+func FuzzCloudWatchEvent(f *testing.F) {
+	fuzzUnmarshal[CloudWatchEvent](f, "autoscaling-event-*.json", "codebuild-*.json", "auth0-log-event.json")
+}
+
+func FuzzKinesisRecordDeaggregate(f *testing.F) {
+	var event KinesisEvent
+	b, err := os.ReadFile(filepath.Join("testdata", "kinesis-event-kpl-aggregated.json"))
+	if err != nil {
+		f.Fatal(err)
+	}
+	if err := json.Unmarshal(b, &event); err != nil {
+		f.Fatal(err)
+	}
+	for _, record := range event.Records {
+		f.Add(record.Kinesis.Data)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, _ = KinesisRecord{Data: data}.Deaggregate()
+		// with a valid checksum, so that the protobuf decoding is reached
+		if len(data) > len(kplMagic) {
+			message := data[len(kplMagic):]
+			checksum := md5.Sum(message)
+			aggregate := append(append(append([]byte(nil), kplMagic...), message...), checksum[:]...)
+			_, _ = KinesisRecord{Data: aggregate}.Deaggregate()
+		}
+	})
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package generate
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// APIGatewayV2APIID is the API of the requests generated by NewAPIGatewayV2Request.
+const APIGatewayV2APIID = "generated"
+
+// APIGatewayV2RequestOption customizes the request generated by NewAPIGatewayV2Request.
+type APIGatewayV2RequestOption func(*events.APIGatewayV2HTTPRequest)
+
+// WithHeader sets the header key of the request, lowercased as API Gateway delivers it.
+func WithHeader(key, value string) APIGatewayV2RequestOption {
+	return func(r *events.APIGatewayV2HTTPRequest) {
+		r.Headers[strings.ToLower(key)] = value
+	}
+}
+
+// WithCookies adds cookies to the request, each of the form "name=value".
+func WithCookies(cookies ...string) APIGatewayV2RequestOption {
+	return func(r *events.APIGatewayV2HTTPRequest) {
+		r.Cookies = append(r.Cookies, cookies...)
+	}
+}
+
+// WithBody sets the body of the request, and its content type.
+func WithBody(contentType string, body string) APIGatewayV2RequestOption {
+	return func(r *events.APIGatewayV2HTTPRequest) {
+		r.Headers["content-type"] = contentType
+		r.Headers["content-length"] = strconv.Itoa(len(body))
+		r.Body = body
+		r.IsBase64Encoded = false
+	}
+}
+
+// WithBinaryBody sets the body of the request, base64 encoded, and its content type.
+func WithBinaryBody(contentType string, body []byte) APIGatewayV2RequestOption {
+	return func(r *events.APIGatewayV2HTTPRequest) {
+		r.Headers["content-type"] = contentType
+		r.Headers["content-length"] = strconv.Itoa(len(body))
+		r.Body = base64.StdEncoding.EncodeToString(body)
+		r.IsBase64Encoded = true
+	}
+}
+
+// WithRoute sets the route key of the request, such as "GET /pets/{id}", and the values of the path parameters of the
+// route.
+func WithRoute(routeKey string, pathParameters map[string]string) APIGatewayV2RequestOption {
+	return func(r *events.APIGatewayV2HTTPRequest) {
+		r.RouteKey = routeKey
+		r.RequestContext.RouteKey = routeKey
+		r.PathParameters = pathParameters
+	}
+}
+
+// WithStage sets the stage of the request, and its stage variables.
+func WithStage(stage string, variables map[string]string) APIGatewayV2RequestOption {
+	return func(r *events.APIGatewayV2HTTPRequest) {
+		r.RequestContext.Stage = stage
+		r.StageVariables = variables
+	}
+}
+
+// NewAPIGatewayV2Request returns a request of an HTTP API, with the payload format version 2.0, to the $default route
+// and stage. The path can have a query string, from which the query string parameters are set, the values of a
+// repeated parameter being joined with commas as API Gateway does.
+func NewAPIGatewayV2Request(method, path string, opts ...APIGatewayV2RequestOption) events.APIGatewayV2HTTPRequest {
+	requestTime := now()
+	rawPath, rawQuery := path, ""
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		rawPath, rawQuery = path[:i], path[i+1:]
+	}
+	decodedPath, err := url.PathUnescape(rawPath)
+	if err != nil {
+		decodedPath = rawPath
+	}
+	domainName := APIGatewayV2APIID + ".execute-api." + Region + ".amazonaws.com"
+	const userAgent = "aws-lambda-go/generate"
+	const sourceIP = "198.51.100.1"
+
+	request := events.APIGatewayV2HTTPRequest{
+		Version:        "2.0",
+		RouteKey:       "$default",
+		RawPath:        rawPath,
+		RawQueryString: rawQuery,
+		Headers: map[string]string{
+			"accept":            "*/*",
+			"host":              domainName,
+			"user-agent":        userAgent,
+			"x-amzn-trace-id":   "Root=1-" + strconv.FormatInt(requestTime.Unix(), 16) + "-" + randomHex(12),
+			"x-forwarded-for":   sourceIP,
+			"x-forwarded-port":  "443",
+			"x-forwarded-proto": "https",
+		},
+		QueryStringParameters: queryStringParameters(rawQuery),
+		RequestContext: events.APIGatewayV2HTTPRequestContext{
+			RouteKey:     "$default",
+			AccountID:    AccountID,
+			Stage:        "$default",
+			RequestID:    base64.RawURLEncoding.EncodeToString(randomBytes(12)),
+			APIID:        APIGatewayV2APIID,
+			DomainName:   domainName,
+			DomainPrefix: APIGatewayV2APIID,
+			Time:         requestTime.Format("02/Jan/2006:15:04:05 -0700"),
+			TimeEpoch:    epochMilliseconds(requestTime),
+			HTTP: events.APIGatewayV2HTTPRequestContextHTTPDescription{
+				Method:    method,
+				Path:      decodedPath,
+				Protocol:  "HTTP/1.1",
+				SourceIP:  sourceIP,
+				UserAgent: userAgent,
+			},
+		},
+	}
+	for _, opt := range opts {
+		opt(&request)
+	}
+	return request
+}
+
+func queryStringParameters(rawQuery string) map[string]string {
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil || len(values) == 0 {
+		return nil
+	}
+	parameters := make(map[string]string, len(values))
+	for key, value := range values {
+		parameters[key] = strings.Join(value, ",")
+	}
+	return parameters
+}
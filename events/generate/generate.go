@@ -0,0 +1,55 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+// Package generate builds synthetic events, populated like the ones delivered to Lambda functions, such as for load
+// tests and local development.
+//
+//	event := generate.NewSQSEvent(`{"orderId": 1}`, `{"orderId": 2}`)
+//	response, err := handler(ctx, event)
+package generate
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// The region and account of the resources of generated events.
+const (
+	Region    = "us-east-1"
+	AccountID = "123456789012"
+)
+
+// Now returns the time of generated events. It can be replaced to generate events at a fixed time.
+var Now = func() time.Time {
+	return time.Now().UTC()
+}
+
+// now returns Now truncated to the milliseconds, the precision of the timestamps of most events.
+func now() time.Time {
+	return Now().Truncate(time.Millisecond)
+}
+
+func epochMilliseconds(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("generate: cannot read random bytes: %v", err))
+	}
+	return b
+}
+
+func randomHex(n int) string {
+	return hex.EncodeToString(randomBytes(n))
+}
+
+// newUUID returns a random version 4 UUID, the format of most event and message IDs.
+func newUUID() string {
+	b := randomBytes(16)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
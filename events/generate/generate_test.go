@@ -0,0 +1,148 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package generate
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assertRoundTrip asserts that event marshals, and unmarshals into its type, without loss.
+func assertRoundTrip(t *testing.T, event interface{}) {
+	eventJSON, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	delivered := reflect.New(reflect.TypeOf(event))
+	require.NoError(t, json.Unmarshal(eventJSON, delivered.Interface()))
+	deliveredJSON, err := json.Marshal(delivered.Elem().Interface())
+	require.NoError(t, err)
+	assert.JSONEq(t, string(eventJSON), string(deliveredJSON))
+}
+
+func TestRoundTrip(t *testing.T) {
+	bodies := [][]string{
+		nil,
+		{""},
+		{`{"orderId": 1}`, "plain text", "ünïcødé ✓"},
+	}
+	for _, b := range bodies {
+		assertRoundTrip(t, NewSQSEvent(b...))
+		datas := make([][]byte, len(b))
+		for i, body := range b {
+			datas[i] = []byte(body)
+		}
+		assertRoundTrip(t, NewKinesisEvent(datas...))
+		assertRoundTrip(t, NewS3Event("my-bucket", b...))
+	}
+	assertRoundTrip(t, NewKinesisEvent([]byte{0, 0xff, 0x80}))
+	assertRoundTrip(t, NewS3Event("my-bucket", "photos/2024/my photo+1.jpg", "a%b?c#d"))
+
+	for _, path := range []string{"/", "/pets/my%20cat", "/search?q=cat&tag=a&tag=b"} {
+		assertRoundTrip(t, NewAPIGatewayV2Request("GET", path))
+	}
+	assertRoundTrip(t, NewAPIGatewayV2Request("POST", "/upload",
+		WithHeader("X-Custom", "value"),
+		WithCookies("session=abc"),
+		WithBinaryBody("image/png", []byte{0x89, 'P', 'N', 'G'}),
+		WithRoute("POST /upload", nil),
+		WithStage("prod", map[string]string{"table": "uploads"}),
+	))
+}
+
+func TestFixedTime(t *testing.T) {
+	defer func(now func() time.Time) { Now = now }(Now)
+	fixed := time.Date(2024, 2, 29, 12, 30, 15, 123456789, time.UTC)
+	Now = func() time.Time { return fixed }
+
+	sqs := NewSQSEvent("a")
+	assert.Equal(t, "1709209815123", sqs.Records[0].Attributes["SentTimestamp"])
+
+	kinesis := NewKinesisEvent([]byte("a"))
+	assert.Equal(t, time.Unix(1709209815, 0), kinesis.Records[0].Kinesis.ApproximateArrivalTimestamp.Time.Local())
+
+	s3 := NewS3Event("b", "a")
+	assert.Equal(t, fixed.Truncate(time.Millisecond), s3.Records[0].EventTime)
+
+	request := NewAPIGatewayV2Request("GET", "/")
+	assert.Equal(t, "29/Feb/2024:12:30:15 +0000", request.RequestContext.Time)
+	assert.Equal(t, int64(1709209815123), request.RequestContext.TimeEpoch)
+}
+
+func TestNewSQSEvent(t *testing.T) {
+	event := NewSQSEvent("first", "second")
+	require.Len(t, event.Records, 2)
+	assert.Equal(t, "first", event.Records[0].Body)
+	assert.Equal(t, "second", event.Records[1].Body)
+	assert.Equal(t, "8b04d5e3775d298e78455efc5ca404d5", event.Records[0].Md5OfBody)
+	assert.NotEqual(t, event.Records[0].MessageId, event.Records[1].MessageId)
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), event.Records[0].MessageId)
+	assert.Equal(t, "arn:aws:sqs:us-east-1:123456789012:generated-queue", event.Records[0].EventSourceARN)
+	assert.False(t, event.Records[0].IsFIFO())
+}
+
+func TestNewKinesisEvent(t *testing.T) {
+	event := NewKinesisEvent([]byte("first"), []byte("second"))
+	require.Len(t, event.Records, 2)
+	first, second := event.Records[0], event.Records[1]
+	assert.Equal(t, []byte("first"), first.Kinesis.Data)
+	assert.Equal(t, "partitionKey-0", first.Kinesis.PartitionKey)
+	assert.Equal(t, "partitionKey-1", second.Kinesis.PartitionKey)
+	assert.Len(t, first.Kinesis.SequenceNumber, 56)
+	assert.Less(t, first.Kinesis.SequenceNumber, second.Kinesis.SequenceNumber)
+	assert.Equal(t, "shardId-000000000000:"+first.Kinesis.SequenceNumber, first.EventID)
+
+	eventJSON, err := json.Marshal(event)
+	require.NoError(t, err)
+	assert.Contains(t, string(eventJSON), `"data":"`+base64.StdEncoding.EncodeToString([]byte("first"))+`"`)
+}
+
+func TestNewS3Event(t *testing.T) {
+	event := NewS3Event("my-bucket", "photos/my photo+1.jpg")
+	require.Len(t, event.Records, 1)
+	object := event.Records[0].S3.Object
+	assert.Equal(t, "photos/my+photo%2B1.jpg", object.Key)
+	assert.Equal(t, "photos/my photo+1.jpg", object.URLDecodedKey)
+	assert.Equal(t, "arn:aws:s3:::my-bucket", event.Records[0].S3.Bucket.Arn)
+
+	// the key is decoded back when the event is delivered
+	eventJSON, err := json.Marshal(event)
+	require.NoError(t, err)
+	var delivered events.S3Event
+	require.NoError(t, json.Unmarshal(eventJSON, &delivered))
+	assert.Equal(t, "photos/my photo+1.jpg", delivered.Records[0].S3.Object.URLDecodedKey)
+}
+
+func TestNewAPIGatewayV2Request(t *testing.T) {
+	request := NewAPIGatewayV2Request("GET", "/pets/my%20cat?tag=a&tag=b&limit=10",
+		WithHeader("Authorization", "Bearer token"),
+		WithRoute("GET /pets/{name}", map[string]string{"name": "my cat"}),
+	)
+	assert.Equal(t, "/pets/my%20cat", request.RawPath)
+	assert.Equal(t, "/pets/my cat", request.RequestContext.HTTP.Path)
+	assert.Equal(t, "GET", request.RequestContext.HTTP.Method)
+	assert.Equal(t, "tag=a&tag=b&limit=10", request.RawQueryString)
+	assert.Equal(t, map[string]string{"tag": "a,b", "limit": "10"}, request.QueryStringParameters)
+	assert.Equal(t, "Bearer token", request.Headers["authorization"])
+	assert.Equal(t, "GET /pets/{name}", request.RouteKey)
+	assert.Equal(t, "GET /pets/{name}", request.RequestContext.RouteKey)
+	assert.Equal(t, "generated.execute-api.us-east-1.amazonaws.com", request.Headers["host"])
+
+	request = NewAPIGatewayV2Request("POST", "/orders", WithBody("application/json", `{"id": 1}`))
+	assert.Nil(t, request.QueryStringParameters)
+	assert.Equal(t, `{"id": 1}`, request.Body)
+	assert.False(t, request.IsBase64Encoded)
+	assert.Equal(t, "9", request.Headers["content-length"])
+	assert.Equal(t, "application/json", request.Headers["content-type"])
+
+	request = NewAPIGatewayV2Request("POST", "/upload", WithBinaryBody("application/octet-stream", []byte{0xff}))
+	assert.Equal(t, "/w==", request.Body)
+	assert.True(t, request.IsBase64Encoded)
+}
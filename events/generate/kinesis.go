@@ -0,0 +1,56 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package generate
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// KinesisStreamARN is the stream of the records of events generated by NewKinesisEvent.
+const KinesisStreamARN = "arn:aws:kinesis:" + Region + ":" + AccountID + ":stream/generated-stream"
+
+// kinesisShardID is the shard of all the records of generated events, as a batch is read from a single shard.
+const kinesisShardID = "shardId-000000000000"
+
+// NewKinesisEvent returns an event with a record of the stream KinesisStreamARN for each of datas, in order.
+// The records have increasing sequence numbers, and the partition keys "partitionKey-0", "partitionKey-1", and so on.
+// The data is base64 encoded when the event is marshaled, as Lambda delivers it.
+func NewKinesisEvent(datas ...[]byte) events.KinesisEvent {
+	// the arrival time is marshaled as fractional seconds, which do not round trip exactly
+	arrival := events.SecondsEpochTime{Time: Now().Truncate(time.Second)}
+	base := randomSequenceNumberBase()
+	event := events.KinesisEvent{Records: make([]events.KinesisEventRecord, 0, len(datas))}
+	for i, data := range datas {
+		sequenceNumber := fmt.Sprintf("%s%06d", base, i)
+		event.Records = append(event.Records, events.KinesisEventRecord{
+			AwsRegion:         Region,
+			EventID:           kinesisShardID + ":" + sequenceNumber,
+			EventName:         "aws:kinesis:record",
+			EventSource:       "aws:kinesis",
+			EventSourceArn:    KinesisStreamARN,
+			EventVersion:      "1.0",
+			InvokeIdentityArn: "arn:aws:iam::" + AccountID + ":role/generated-role",
+			Kinesis: events.KinesisRecord{
+				ApproximateArrivalTimestamp: arrival,
+				Data:                        append([]byte{}, data...),
+				PartitionKey:                fmt.Sprintf("partitionKey-%d", i),
+				SequenceNumber:              sequenceNumber,
+				KinesisSchemaVersion:        "1.0",
+			},
+		})
+	}
+	return event
+}
+
+// randomSequenceNumberBase returns the first 50 digits of the 56 digit sequence numbers of a generated batch.
+func randomSequenceNumberBase() string {
+	digits := make([]byte, 0, 50)
+	digits = append(digits, '4', '9')
+	for _, v := range randomBytes(24) {
+		digits = append(digits, '0'+v%10, '0'+(v/10)%10)
+	}
+	return string(digits)
+}
@@ -0,0 +1,54 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package generate
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// NewS3Event returns an event with an ObjectCreated:Put record of the object of bucket for each of keys, in order.
+// The keys are URL encoded in the records, as S3 sends them, and decoded in the URLDecodedKey of the objects.
+func NewS3Event(bucket string, keys ...string) events.S3Event {
+	eventTime := now()
+	event := events.S3Event{Records: make([]events.S3EventRecord, 0, len(keys))}
+	for i, key := range keys {
+		event.Records = append(event.Records, events.S3EventRecord{
+			EventVersion:      "2.1",
+			EventSource:       "aws:s3",
+			AWSRegion:         Region,
+			EventTime:         eventTime,
+			EventName:         "ObjectCreated:Put",
+			PrincipalID:       events.S3UserIdentity{PrincipalID: "AWS:" + AccountID},
+			RequestParameters: events.S3RequestParameters{SourceIPAddress: "198.51.100.1"},
+			ResponseElements: map[string]string{
+				"x-amz-request-id": strings.ToUpper(randomHex(8)),
+				"x-amz-id-2":       randomHex(32),
+			},
+			S3: events.S3Entity{
+				SchemaVersion:   "1.0",
+				ConfigurationID: "generated",
+				Bucket: events.S3Bucket{
+					Name:          bucket,
+					OwnerIdentity: events.S3UserIdentity{PrincipalID: AccountID},
+					Arn:           "arn:aws:s3:::" + bucket,
+				},
+				Object: events.S3Object{
+					Key:           encodeS3Key(key),
+					URLDecodedKey: key,
+					ETag:          randomHex(16),
+					Sequencer:     fmt.Sprintf("%016X", epochMilliseconds(eventTime)*1000+int64(i)),
+				},
+			},
+		})
+	}
+	return event
+}
+
+// encodeS3Key encodes key like S3 does in event notifications, with spaces as +, but slashes unescaped.
+func encodeS3Key(key string) string {
+	return strings.Replace(url.QueryEscape(key), "%2F", "/", -1)
+}
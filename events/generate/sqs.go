@@ -0,0 +1,41 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package generate
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// SQSQueueARN is the queue of the messages of events generated by NewSQSEvent.
+const SQSQueueARN = "arn:aws:sqs:" + Region + ":" + AccountID + ":generated-queue"
+
+// NewSQSEvent returns an event with a message of the standard queue SQSQueueARN for each of bodies, in order.
+func NewSQSEvent(bodies ...string) events.SQSEvent {
+	sent := strconv.FormatInt(epochMilliseconds(now()), 10)
+	event := events.SQSEvent{Records: make([]events.SQSMessage, 0, len(bodies))}
+	for _, body := range bodies {
+		md5OfBody := md5.Sum([]byte(body)) //nolint:gosec
+		event.Records = append(event.Records, events.SQSMessage{
+			MessageId:     newUUID(),
+			ReceiptHandle: base64.StdEncoding.EncodeToString(randomBytes(96)),
+			Body:          body,
+			Md5OfBody:     hex.EncodeToString(md5OfBody[:]),
+			Attributes: map[string]string{
+				"ApproximateReceiveCount":          "1",
+				"SentTimestamp":                    sent,
+				"SenderId":                         AccountID,
+				"ApproximateFirstReceiveTimestamp": sent,
+			},
+			MessageAttributes: map[string]events.SQSMessageAttribute{},
+			EventSourceARN:    SQSQueueARN,
+			EventSource:       "aws:sqs",
+			AWSRegion:         Region,
+		})
+	}
+	return event
+}
@@ -1,5 +1,13 @@
 package events
 
+const (
+	// IAMPolicyVersion is the current version of the IAM policy language.
+	IAMPolicyVersion = "2012-10-17"
+
+	IAMPolicyEffectAllow = "Allow"
+	IAMPolicyEffectDeny  = "Deny"
+)
+
 // IAMPolicyDocument represents an IAM policy document.
 type IAMPolicyDocument struct {
 	Version   string
@@ -10,6 +10,33 @@ type IoTCoreCustomAuthorizerRequest struct {
 	ConnectionMetadata *IoTCoreConnectionMetadata `json:"connectionMetadata,omitempty"`
 }
 
+// The Protocols of an IoTCoreCustomAuthorizerRequest. A connection over MQTT also uses TLS, and has the data of both.
+const (
+	IoTCoreProtocolTLS  = "tls"
+	IoTCoreProtocolHTTP = "http"
+	IoTCoreProtocolMQTT = "mqtt"
+)
+
+// HasProtocol reports whether the device connected with protocol, one of the IoTCoreProtocol constants.
+func (r IoTCoreCustomAuthorizerRequest) HasProtocol(protocol string) bool {
+	for _, p := range r.Protocols {
+		if p == protocol {
+			return true
+		}
+	}
+	return false
+}
+
+// MQTTCredentials returns the client ID, username and password of an MQTT connection, and false for the
+// connections without MQTT data.
+func (r IoTCoreCustomAuthorizerRequest) MQTTCredentials() (clientID, username, password string, ok bool) {
+	if r.ProtocolData == nil || r.ProtocolData.MQTT == nil {
+		return "", "", "", false
+	}
+	mqtt := r.ProtocolData.MQTT
+	return mqtt.ClientID, mqtt.Username, mqtt.PasswordString(), true
+}
+
 type IoTCoreProtocolData struct {
 	TLS  *IoTCoreTLSContext  `json:"tls,omitempty"`
 	HTTP *IoTCoreHTTPContext `json:"http,omitempty"`
@@ -27,10 +54,16 @@ type IoTCoreHTTPContext struct {
 
 type IoTCoreMQTTContext struct {
 	ClientID string `json:"clientId"`
+	// Password is sent base64 encoded, and is decoded by encoding/json.
 	Password []byte `json:"password"`
 	Username string `json:"username"`
 }
 
+// PasswordString returns the decoded password of the connection as a string.
+func (c IoTCoreMQTTContext) PasswordString() string {
+	return string(c.Password)
+}
+
 type IoTCoreConnectionMetadata struct {
 	ID string `json:"id"`
 }
@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/aws/aws-lambda-go/events/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIoTCoreCustomAuthorizerRequestMarshaling(t *testing.T) {
@@ -61,3 +63,46 @@ func TestIoTCoreCustomAuthorizerResponseMarshaling(t *testing.T) {
 func TestIoTCoreCustomAuthorizerResponseMalformedJson(t *testing.T) {
 	test.TestMalformedJson(t, IoTCoreCustomAuthorizerResponse{})
 }
+
+func TestIoTCoreCustomAuthorizerRequestMQTT(t *testing.T) {
+	// the request has no token, which is encoded back as an empty string, so it is not round tripped
+	var request IoTCoreCustomAuthorizerRequest
+	require.NoError(t, json.Unmarshal(test.ReadJSONFromFile(t, "./testdata/iot-custom-auth-request-mqtt.json"), &request))
+	assert.Empty(t, request.Token)
+
+	assert.True(t, request.HasProtocol(IoTCoreProtocolMQTT))
+	assert.True(t, request.HasProtocol(IoTCoreProtocolTLS))
+	assert.False(t, request.HasProtocol(IoTCoreProtocolHTTP))
+	assert.Nil(t, request.ProtocolData.HTTP)
+
+	clientID, username, password, ok := request.MQTTCredentials()
+	assert.True(t, ok)
+	assert.Equal(t, "device-42", clientID)
+	assert.Equal(t, "device-42?x-amz-customauthorizer-name=MyAuthorizer", username)
+	assert.Equal(t, "s3cr3t-t0k3n", password)
+}
+
+func TestIoTCoreCustomAuthorizerRequestHTTP(t *testing.T) {
+	var request IoTCoreCustomAuthorizerRequest
+	test.AssertJSONRoundTrip(t, "./testdata/iot-custom-auth-request-http.json", &request)
+
+	assert.True(t, request.HasProtocol(IoTCoreProtocolHTTP))
+	assert.False(t, request.HasProtocol(IoTCoreProtocolMQTT))
+	assert.True(t, request.SignatureVerified)
+	assert.Equal(t, request.Token, request.ProtocolData.HTTP.Headers["device-token"])
+
+	_, _, _, ok := request.MQTTCredentials()
+	assert.False(t, ok)
+	_, _, _, ok = IoTCoreCustomAuthorizerRequest{}.MQTTCredentials()
+	assert.False(t, ok)
+}
+
+func TestIoTCoreCustomAuthorizerResponseRoundTrip(t *testing.T) {
+	var response IoTCoreCustomAuthorizerResponse
+	test.AssertJSONRoundTrip(t, "./testdata/iot-custom-auth-response.json", &response)
+
+	assert.True(t, response.IsAuthenticated)
+	assert.Equal(t, uint32(86400), response.DisconnectAfterInSeconds)
+	assert.Equal(t, uint32(300), response.RefreshAfterInSeconds)
+	assert.Equal(t, []string{"iot:Publish"}, response.PolicyDocuments[0].Statement[0].Action)
+}
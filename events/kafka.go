@@ -3,7 +3,9 @@
 package events
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
 )
 
 type KafkaEvent struct {
@@ -14,14 +16,115 @@ type KafkaEvent struct {
 }
 
 type KafkaRecord struct {
-	Topic         string                       `json:"topic"`
-	Partition     int64                        `json:"partition"`
-	Offset        int64                        `json:"offset"`
-	Timestamp     MilliSecondsEpochTime        `json:"timestamp"`
-	TimestampType string                       `json:"timestampType"`
-	Key           string                       `json:"key,omitempty"`
-	Value         string                       `json:"value,omitempty"`
-	Headers       []map[string]JSONNumberBytes `json:"headers"`
+	Topic     string                `json:"topic"`
+	Partition int64                 `json:"partition"`
+	Offset    int64                 `json:"offset"`
+	Timestamp MilliSecondsEpochTime `json:"timestamp"`
+	// TimestampType is one of KafkaTimestampTypeCreateTime or KafkaTimestampTypeLogAppendTime.
+	TimestampType string `json:"timestampType"`
+	// Key and Value are base64 encoded, see DecodedKey and DecodedValue.
+	Key     string                       `json:"key,omitempty"`
+	Value   string                       `json:"value,omitempty"`
+	Headers []map[string]JSONNumberBytes `json:"headers"`
+
+	// The schema metadata are set when the event source mapping validates the key or value with a schema registry.
+	KeySchemaMetadata   *KafkaSchemaMetadata `json:"keySchemaMetadata,omitempty"`
+	ValueSchemaMetadata *KafkaSchemaMetadata `json:"valueSchemaMetadata,omitempty"`
+}
+
+// KafkaSchemaMetadata identifies the schema of the key or value of a KafkaRecord in a schema registry.
+type KafkaSchemaMetadata struct {
+	DataFormat string `json:"dataFormat"` // AVRO, PROTOBUF or JSON
+	SchemaID   string `json:"schemaId"`
+}
+
+// The TimestampType values of KafkaRecord.
+const (
+	KafkaTimestampTypeCreateTime    = "CREATE_TIME"
+	KafkaTimestampTypeLogAppendTime = "LOG_APPEND_TIME"
+)
+
+// DecodedKey returns the key of the record, or nil if the record has no key.
+func (r KafkaRecord) DecodedKey() ([]byte, error) {
+	return decodeKafkaField("key", r.Key)
+}
+
+// DecodedValue returns the value of the record, or nil for a tombstone, a record with a null value.
+// An empty value cannot be told apart from a null one, as both are delivered without a value.
+func (r KafkaRecord) DecodedValue() ([]byte, error) {
+	return decodeKafkaField("value", r.Value)
+}
+
+// IsTombstone reports whether the record has a null value, which marks the deletion of its key in a compacted topic.
+func (r KafkaRecord) IsTombstone() bool {
+	return r.Value == ""
+}
+
+// DecodedHeaders returns the headers of the record by key. When a key is repeated, the last value is returned,
+// as the lastHeader of Kafka clients.
+func (r KafkaRecord) DecodedHeaders() map[string][]byte {
+	headers := make(map[string][]byte, len(r.Headers))
+	for _, header := range r.Headers {
+		for key, value := range header {
+			headers[key] = []byte(value)
+		}
+	}
+	return headers
+}
+
+func decodeKafkaField(name, encoded string) ([]byte, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("kafka record %s is not valid base64: %w", name, err)
+	}
+	return decoded, nil
+}
+
+// ItemIdentifier returns the identifier of the record in a KafkaEventResponse: the topic, partition and offset of
+// the record joined with dashes, such as "AWSKafkaTopic-0-15".
+func (r KafkaRecord) ItemIdentifier() string {
+	return fmt.Sprintf("%s-%d-%d", r.Topic, r.Partition, r.Offset)
+}
+
+// NewKafkaEventResponse returns a response reporting no failure, to which failed records are added with MarkFailure.
+// The BatchItemFailures of the response is never nil, so that no failure is encoded as an empty list.
+func NewKafkaEventResponse() *KafkaEventResponse {
+	return &KafkaEventResponse{BatchItemFailures: []KafkaBatchItemFailure{}}
+}
+
+// MarkFailure reports record as failed, unless it already is.
+func (r *KafkaEventResponse) MarkFailure(record KafkaRecord) *KafkaEventResponse {
+	id := record.ItemIdentifier()
+	for _, failure := range r.BatchItemFailures {
+		if failure.ItemIdentifier == id {
+			return r
+		}
+	}
+	if r.BatchItemFailures == nil {
+		r.BatchItemFailures = []KafkaBatchItemFailure{}
+	}
+	r.BatchItemFailures = append(r.BatchItemFailures, KafkaBatchItemFailure{ItemIdentifier: id})
+	return r
+}
+
+// ValidateResponse returns an error if one of the failures of response is not the identifier of a record of the event.
+// Lambda treats a response with an unknown identifier as a failure of the whole batch.
+func (e KafkaEvent) ValidateResponse(response KafkaEventResponse) error {
+	inBatch := make(map[string]bool)
+	for _, records := range e.Records {
+		for _, record := range records {
+			inBatch[record.ItemIdentifier()] = true
+		}
+	}
+	for _, failure := range response.BatchItemFailures {
+		if !inBatch[failure.ItemIdentifier] {
+			return fmt.Errorf("record %q is not in the batch", failure.ItemIdentifier)
+		}
+	}
+	return nil
 }
 
 // JSONNumberBytes represents array of bytes in Headers field.
@@ -3,6 +3,7 @@ package events
 
 import (
 	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/aws/aws-lambda-go/events/test"
@@ -62,3 +63,112 @@ func TestKafkaEventMarshaling(t *testing.T) {
 func TestKafkaMarshalingMalformedJson(t *testing.T) {
 	test.TestMalformedJson(t, KafkaEvent{})
 }
+
+func TestKafkaEventResponse(t *testing.T) {
+	inputJSON := test.ReadJSONFromFile(t, "./testdata/kafka-event-multi-partition.json")
+	var event KafkaEvent
+	if err := json.Unmarshal(inputJSON, &event); err != nil {
+		t.Fatalf("could not unmarshal event. details: %v", err)
+	}
+
+	response := NewKafkaEventResponse()
+	responseJSON, err := json.Marshal(response)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"batchItemFailures": []}`, string(responseJSON))
+
+	response.
+		MarkFailure(event.Records["orders-0"][1]).
+		MarkFailure(event.Records["order-events-2"][0]).
+		MarkFailure(event.Records["orders-1"][0]).
+		MarkFailure(event.Records["orders-0"][1])
+	assert.NoError(t, event.ValidateResponse(*response))
+
+	responseJSON, err = json.Marshal(response)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"batchItemFailures": [
+			{"itemIdentifier": "orders-0-16"},
+			{"itemIdentifier": "order-events-2-1001"},
+			{"itemIdentifier": "orders-1-15"}
+		]
+	}`, string(responseJSON))
+
+	// the zero response reports the failures too
+	var zero KafkaEventResponse
+	zero.MarkFailure(event.Records["orders-0"][0])
+	assert.Equal(t, []KafkaBatchItemFailure{{ItemIdentifier: "orders-0-15"}}, zero.BatchItemFailures)
+}
+
+func TestKafkaEventValidateResponse(t *testing.T) {
+	inputJSON := test.ReadJSONFromFile(t, "./testdata/kafka-event-multi-partition.json")
+	var event KafkaEvent
+	if err := json.Unmarshal(inputJSON, &event); err != nil {
+		t.Fatalf("could not unmarshal event. details: %v", err)
+	}
+
+	assert.NoError(t, event.ValidateResponse(KafkaEventResponse{}))
+
+	// the same offset in a partition of the batch, but not in this one
+	response := KafkaEventResponse{BatchItemFailures: []KafkaBatchItemFailure{{ItemIdentifier: "orders-1-16"}}}
+	assert.EqualError(t, event.ValidateResponse(response), `record "orders-1-16" is not in the batch`)
+
+	response = KafkaEventResponse{BatchItemFailures: []KafkaBatchItemFailure{{ItemIdentifier: "16"}}}
+	assert.Error(t, event.ValidateResponse(response))
+}
+
+func TestKafkaRecordAccessors(t *testing.T) {
+	inputJSON := test.ReadJSONFromFile(t, "./testdata/kafka-event-msk-multi-topic.json")
+	var event KafkaEvent
+	if err := json.Unmarshal(inputJSON, &event); err != nil {
+		t.Fatalf("could not unmarshal event. details: %v", err)
+	}
+	assert.Equal(t, "b-1.orders-cluster.abc123.c2.kafka.us-east-1.amazonaws.com:9098,b-2.orders-cluster.abc123.c2.kafka.us-east-1.amazonaws.com:9098", event.BootstrapServers)
+	assert.Len(t, event.Records, 2)
+
+	for key, records := range event.Records {
+		for _, record := range records {
+			assert.Equal(t, fmt.Sprintf("%s-%d", record.Topic, record.Partition), key)
+			assert.Contains(t, []string{KafkaTimestampTypeCreateTime, KafkaTimestampTypeLogAppendTime}, record.TimestampType)
+			assert.Equal(t, 2024, record.Timestamp.UTC().Year())
+		}
+	}
+
+	order := event.Records["orders-0"][0]
+	assert.Equal(t, int64(120), order.Offset)
+	key, err := order.DecodedKey()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("order-1"), key)
+	value, err := order.DecodedValue()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id":1,"status":"NEW"}`, string(value))
+	assert.False(t, order.IsTombstone())
+	assert.Equal(t, map[string][]byte{
+		"source":   []byte("api"),
+		"trace-id": []byte("Root=1-667abc12-0123456789abcdef01234567"),
+	}, order.DecodedHeaders())
+	assert.Nil(t, order.ValueSchemaMetadata)
+
+	tombstone := event.Records["orders-0"][1]
+	assert.True(t, tombstone.IsTombstone())
+	value, err = tombstone.DecodedValue()
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+	assert.Empty(t, tombstone.DecodedHeaders())
+
+	payment := event.Records["payments-3"][0]
+	assert.Equal(t, KafkaTimestampTypeLogAppendTime, payment.TimestampType)
+	key, err = payment.DecodedKey()
+	assert.NoError(t, err)
+	assert.Nil(t, key)
+	assert.Equal(t, &KafkaSchemaMetadata{DataFormat: "AVRO", SchemaID: "a1b2c3d4-5678-90ab-cdef-22222EXAMPLE"}, payment.ValueSchemaMetadata)
+
+	// the tombstone is delivered again without a value
+	outputJSON, err := json.Marshal(event)
+	assert.NoError(t, err)
+	var delivered KafkaEvent
+	assert.NoError(t, json.Unmarshal(outputJSON, &delivered))
+	assert.Equal(t, event, delivered)
+
+	_, err = KafkaRecord{Value: "not base64!"}.DecodedValue()
+	assert.EqualError(t, err, "kafka record value is not valid base64: illegal base64 data at input byte 3")
+}
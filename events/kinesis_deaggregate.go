@@ -0,0 +1,187 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"bytes"
+	"crypto/md5"
+	"errors"
+	"fmt"
+)
+
+// kplMagic prefixes the records aggregated by the Kinesis Producer Library.
+// See https://github.com/awslabs/amazon-kinesis-producer/blob/master/aggregation-format.md
+var kplMagic = []byte{0xF3, 0x89, 0x9A, 0xC2}
+
+// ErrKinesisAggregateChecksum is returned by KinesisRecord.Deaggregate when a record starting with the KPL magic number
+// does not end with the MD5 checksum of its content. The record may then be a corrupted aggregate, or a plain record
+// whose data happens to start with the magic number.
+var ErrKinesisAggregateChecksum = errors.New("kinesis aggregated record checksum mismatch")
+
+// KinesisDeaggregatedRecord is a user record of a KinesisRecord, see KinesisRecord.Deaggregate.
+type KinesisDeaggregatedRecord struct {
+	Data            []byte
+	PartitionKey    string
+	ExplicitHashKey string // empty if the user record has none
+
+	// SequenceNumber is the one of the Kinesis record, shared by all its user records.
+	SequenceNumber string
+	// SubSequenceNumber is the index of the user record in the Kinesis record.
+	SubSequenceNumber int
+}
+
+// Deaggregate returns the user records aggregated in r by the Kinesis Producer Library.
+// A record that is not aggregated is returned as a single user record with the Data and PartitionKey of r.
+func (r KinesisRecord) Deaggregate() ([]KinesisDeaggregatedRecord, error) {
+	if !bytes.HasPrefix(r.Data, kplMagic) || len(r.Data) < len(kplMagic)+md5.Size {
+		return []KinesisDeaggregatedRecord{{Data: r.Data, PartitionKey: r.PartitionKey, SequenceNumber: r.SequenceNumber}}, nil
+	}
+
+	message := r.Data[len(kplMagic) : len(r.Data)-md5.Size]
+	checksum := md5.Sum(message)
+	if !bytes.Equal(checksum[:], r.Data[len(r.Data)-md5.Size:]) {
+		return nil, fmt.Errorf("kinesis record %s: %w", r.SequenceNumber, ErrKinesisAggregateChecksum)
+	}
+
+	aggregate, err := decodeKPLAggregatedRecord(message)
+	if err != nil {
+		return nil, fmt.Errorf("kinesis record %s: invalid aggregated record: %w", r.SequenceNumber, err)
+	}
+	records := make([]KinesisDeaggregatedRecord, 0, len(aggregate.records))
+	for i, record := range aggregate.records {
+		if record.partitionKeyIndex >= uint64(len(aggregate.partitionKeys)) {
+			return nil, fmt.Errorf("kinesis record %s: user record %d: partition key index %d out of range", r.SequenceNumber, i, record.partitionKeyIndex)
+		}
+		deaggregated := KinesisDeaggregatedRecord{
+			Data:              record.data,
+			PartitionKey:      aggregate.partitionKeys[record.partitionKeyIndex],
+			SequenceNumber:    r.SequenceNumber,
+			SubSequenceNumber: i,
+		}
+		if record.hasExplicitHashKey {
+			if record.explicitHashKeyIndex >= uint64(len(aggregate.explicitHashKeys)) {
+				return nil, fmt.Errorf("kinesis record %s: user record %d: explicit hash key index %d out of range", r.SequenceNumber, i, record.explicitHashKeyIndex)
+			}
+			deaggregated.ExplicitHashKey = aggregate.explicitHashKeys[record.explicitHashKeyIndex]
+		}
+		records = append(records, deaggregated)
+	}
+	return records, nil
+}
+
+// kplAggregatedRecord is the AggregatedRecord protobuf message of the KPL aggregation format.
+type kplAggregatedRecord struct {
+	partitionKeys    []string // field 1
+	explicitHashKeys []string // field 2
+	records          []kplRecord
+}
+
+// kplRecord is the Record protobuf message of the KPL aggregation format. Its tags (field 4) are ignored.
+type kplRecord struct {
+	partitionKeyIndex    uint64 // field 1
+	explicitHashKeyIndex uint64 // field 2
+	hasExplicitHashKey   bool
+	data                 []byte // field 3
+}
+
+func decodeKPLAggregatedRecord(b []byte) (kplAggregatedRecord, error) {
+	var aggregate kplAggregatedRecord
+	err := decodeProtobufFields(b, func(field uint64, value uint64, data []byte) error {
+		switch field {
+		case 1:
+			aggregate.partitionKeys = append(aggregate.partitionKeys, string(data))
+		case 2:
+			aggregate.explicitHashKeys = append(aggregate.explicitHashKeys, string(data))
+		case 3:
+			var record kplRecord
+			err := decodeProtobufFields(data, func(field uint64, value uint64, data []byte) error {
+				switch field {
+				case 1:
+					record.partitionKeyIndex = value
+				case 2:
+					record.explicitHashKeyIndex = value
+					record.hasExplicitHashKey = true
+				case 3:
+					record.data = data
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			aggregate.records = append(aggregate.records, record)
+		}
+		return nil
+	})
+	return aggregate, err
+}
+
+const (
+	protobufWireVarint  = 0
+	protobufWireFixed64 = 1
+	protobufWireBytes   = 2
+	protobufWireFixed32 = 5
+)
+
+var errProtobufTruncated = errors.New("truncated protobuf message")
+
+// decodeProtobufFields calls fn with each field of the protobuf message b, with value set for varint fields,
+// and data for length-delimited fields. Fixed size fields are skipped.
+func decodeProtobufFields(b []byte, fn func(field uint64, value uint64, data []byte) error) error {
+	for len(b) > 0 {
+		key, n := decodeProtobufVarint(b)
+		if n == 0 {
+			return errProtobufTruncated
+		}
+		b = b[n:]
+		field, wire := key>>3, key&7
+
+		var value uint64
+		var data []byte
+		switch wire {
+		case protobufWireVarint:
+			value, n = decodeProtobufVarint(b)
+			if n == 0 {
+				return errProtobufTruncated
+			}
+			b = b[n:]
+		case protobufWireBytes:
+			length, n := decodeProtobufVarint(b)
+			if n == 0 || length > uint64(len(b)-n) {
+				return errProtobufTruncated
+			}
+			data = b[n : n+int(length)]
+			b = b[n+int(length):]
+		case protobufWireFixed64:
+			if len(b) < 8 {
+				return errProtobufTruncated
+			}
+			b = b[8:]
+			continue
+		case protobufWireFixed32:
+			if len(b) < 4 {
+				return errProtobufTruncated
+			}
+			b = b[4:]
+			continue
+		default:
+			return fmt.Errorf("unsupported protobuf wire type %d", wire)
+		}
+		if err := fn(field, value, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeProtobufVarint returns the varint at the start of b, and its length, or a length of 0 if it is truncated or too long.
+func decodeProtobufVarint(b []byte) (uint64, int) {
+	var value uint64
+	for i := 0; i < len(b) && i < 10; i++ {
+		value |= uint64(b[i]&0x7F) << (7 * uint(i))
+		if b[i] < 0x80 {
+			return value, i + 1
+		}
+	}
+	return 0, 0
+}
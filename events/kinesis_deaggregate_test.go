@@ -0,0 +1,114 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"crypto/md5"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readKPLTestEvent(t *testing.T) KinesisEvent {
+	var event KinesisEvent
+	require.NoError(t, json.Unmarshal(test.ReadJSONFromFile(t, "./testdata/kinesis-event-kpl-aggregated.json"), &event))
+	require.Len(t, event.Records, 3)
+	return event
+}
+
+func TestKinesisRecordDeaggregate(t *testing.T) {
+	record := readKPLTestEvent(t).Records[0].Kinesis
+
+	records, err := record.Deaggregate()
+	require.NoError(t, err)
+	assert.Equal(t, []KinesisDeaggregatedRecord{
+		{
+			Data:              []byte(`{"event":"signup","user":1}`),
+			PartitionKey:      "user-1",
+			SequenceNumber:    record.SequenceNumber,
+			SubSequenceNumber: 0,
+		},
+		{
+			Data:              []byte(`{"event":"login","user":2}`),
+			PartitionKey:      "user-2",
+			ExplicitHashKey:   "170141183460469231731687303715884105728",
+			SequenceNumber:    record.SequenceNumber,
+			SubSequenceNumber: 1,
+		},
+		{
+			Data:              []byte(`{"event":"logout","user":1}`),
+			PartitionKey:      "user-1",
+			SequenceNumber:    record.SequenceNumber,
+			SubSequenceNumber: 2,
+		},
+	}, records)
+}
+
+func TestKinesisRecordDeaggregateChecksumMismatch(t *testing.T) {
+	record := readKPLTestEvent(t).Records[1].Kinesis
+
+	records, err := record.Deaggregate()
+	assert.Nil(t, records)
+	assert.True(t, errors.Is(err, ErrKinesisAggregateChecksum))
+	assert.EqualError(t, err, "kinesis record 49590338271490256608559692538361571095921575989136588802: kinesis aggregated record checksum mismatch")
+}
+
+func TestKinesisRecordDeaggregatePlainRecord(t *testing.T) {
+	for _, data := range [][]byte{
+		[]byte("Hello World"),
+		nil,
+		kplMagic, // too short to hold a checksum
+	} {
+		record := readKPLTestEvent(t).Records[2].Kinesis
+		record.Data = data
+
+		records, err := record.Deaggregate()
+		require.NoError(t, err)
+		assert.Equal(t, []KinesisDeaggregatedRecord{{
+			Data:           data,
+			PartitionKey:   "s1",
+			SequenceNumber: "49590338271490256608559692538361571095921575989136588803",
+		}}, records)
+	}
+}
+
+func TestKinesisRecordDeaggregateInvalidAggregate(t *testing.T) {
+	aggregate := func(message []byte) KinesisRecord {
+		checksum := md5.Sum(message)
+		data := append(append(append([]byte(nil), kplMagic...), message...), checksum[:]...)
+		return KinesisRecord{Data: data, SequenceNumber: "1"}
+	}
+	for name, test := range map[string]struct {
+		message  []byte
+		expected string
+	}{
+		"truncated": {
+			message:  []byte{0x0A, 0x06, 'u', 's'},
+			expected: "kinesis record 1: invalid aggregated record: truncated protobuf message",
+		},
+		"partition key out of range": {
+			// records { partition_key_index: 1, data: "x" }, with a single partition key
+			message:  []byte{0x0A, 0x01, 'a', 0x1A, 0x05, 0x08, 0x01, 0x1A, 0x01, 'x'},
+			expected: "kinesis record 1: user record 0: partition key index 1 out of range",
+		},
+		"explicit hash key out of range": {
+			// records { partition_key_index: 0, explicit_hash_key_index: 0, data: "x" }, without explicit hash keys
+			message:  []byte{0x0A, 0x01, 'a', 0x1A, 0x07, 0x08, 0x00, 0x10, 0x00, 0x1A, 0x01, 'x'},
+			expected: "kinesis record 1: user record 0: explicit hash key index 0 out of range",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := aggregate(test.message).Deaggregate()
+			assert.EqualError(t, err, test.expected)
+		})
+	}
+
+	// an aggregate without user records is valid
+	records, err := aggregate(nil).Deaggregate()
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
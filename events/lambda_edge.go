@@ -0,0 +1,152 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// LambdaEdgeEvent is the event of a Lambda@Edge function triggered by a CloudFront distribution.
+// A viewer or origin request function returns the LambdaEdgeRequest to forward, changed or not, or a
+// LambdaEdgeResponse to reply without forwarding it. A viewer or origin response function returns the
+// LambdaEdgeResponse.
+// See https://docs.aws.amazon.com/AmazonCloudFront/latest/DeveloperGuide/lambda-event-structure.html
+type LambdaEdgeEvent struct {
+	Records []LambdaEdgeEventRecord `json:"Records"`
+}
+
+type LambdaEdgeEventRecord struct {
+	CF LambdaEdgeEventRecordCF `json:"cf"`
+}
+
+type LambdaEdgeEventRecordCF struct {
+	Config  LambdaEdgeConfig  `json:"config"`
+	Request LambdaEdgeRequest `json:"request"`
+	// Response is only set for the origin-response and viewer-response events.
+	Response *LambdaEdgeResponse `json:"response,omitempty"`
+}
+
+// The EventType values of LambdaEdgeConfig.
+const (
+	LambdaEdgeEventTypeViewerRequest  = "viewer-request"
+	LambdaEdgeEventTypeOriginRequest  = "origin-request"
+	LambdaEdgeEventTypeOriginResponse = "origin-response"
+	LambdaEdgeEventTypeViewerResponse = "viewer-response"
+)
+
+type LambdaEdgeConfig struct {
+	DistributionDomainName string `json:"distributionDomainName"`
+	DistributionID         string `json:"distributionId"`
+	EventType              string `json:"eventType"`
+	RequestID              string `json:"requestId"`
+}
+
+// LambdaEdgeHeaders are the headers of a request or a response, by their lowercase name. Each header keeps the name
+// as sent, and is repeated for the headers with several values.
+type LambdaEdgeHeaders map[string][]LambdaEdgeHeader
+
+type LambdaEdgeHeader struct {
+	Key   string `json:"key,omitempty"`
+	Value string `json:"value"`
+}
+
+// Get returns the first value of the header name, which is matched case-insensitively.
+func (h LambdaEdgeHeaders) Get(name string) string {
+	if values := h[strings.ToLower(name)]; len(values) > 0 {
+		return values[0].Value
+	}
+	return ""
+}
+
+// Set replaces the values of the header name with value.
+func (h LambdaEdgeHeaders) Set(name, value string) {
+	h[strings.ToLower(name)] = []LambdaEdgeHeader{{Key: name, Value: value}}
+}
+
+type LambdaEdgeRequest struct {
+	ClientIP    string            `json:"clientIp"`
+	Headers     LambdaEdgeHeaders `json:"headers"`
+	Method      string            `json:"method"`
+	QueryString string            `json:"querystring"`
+	URI         string            `json:"uri"`
+	// Origin is only set for the origin-request and origin-response events. An origin request function can change
+	// it to send the request to another origin.
+	Origin *LambdaEdgeOrigin `json:"origin,omitempty"`
+	// Body is only set for the request events of functions with the include body option.
+	Body *LambdaEdgeRequestBody `json:"body,omitempty"`
+}
+
+// LambdaEdgeOrigin has either a custom or an S3 origin.
+type LambdaEdgeOrigin struct {
+	Custom *LambdaEdgeCustomOrigin `json:"custom,omitempty"`
+	S3     *LambdaEdgeS3Origin     `json:"s3,omitempty"`
+}
+
+type LambdaEdgeCustomOrigin struct {
+	CustomHeaders    LambdaEdgeHeaders `json:"customHeaders"`
+	DomainName       string            `json:"domainName"`
+	KeepaliveTimeout int               `json:"keepaliveTimeout"`
+	Path             string            `json:"path"`
+	Port             int               `json:"port"`
+	Protocol         string            `json:"protocol"`
+	ReadTimeout      int               `json:"readTimeout"`
+	SSLProtocols     []string          `json:"sslProtocols"`
+}
+
+type LambdaEdgeS3Origin struct {
+	AuthMethod    string            `json:"authMethod"`
+	CustomHeaders LambdaEdgeHeaders `json:"customHeaders"`
+	DomainName    string            `json:"domainName"`
+	Path          string            `json:"path"`
+	Region        string            `json:"region"`
+}
+
+// The Action and Encoding values of LambdaEdgeRequestBody.
+const (
+	LambdaEdgeBodyActionReadOnly = "read-only"
+	LambdaEdgeBodyActionReplace  = "replace"
+
+	LambdaEdgeBodyEncodingBase64 = "base64"
+	LambdaEdgeBodyEncodingText   = "text"
+)
+
+type LambdaEdgeRequestBody struct {
+	// InputTruncated is true when the body was larger than CloudFront includes in the event, and Data only has its start.
+	InputTruncated bool   `json:"inputTruncated"`
+	Action         string `json:"action"`
+	Encoding       string `json:"encoding"`
+	Data           string `json:"data"`
+}
+
+// Decoded returns the data of the body, decoding it when its encoding is base64.
+func (b *LambdaEdgeRequestBody) Decoded() ([]byte, error) {
+	switch b.Encoding {
+	case LambdaEdgeBodyEncodingBase64:
+		return base64.StdEncoding.DecodeString(b.Data)
+	case LambdaEdgeBodyEncodingText:
+		return []byte(b.Data), nil
+	default:
+		return nil, fmt.Errorf("unknown lambda@edge body encoding %q", b.Encoding)
+	}
+}
+
+// Replace replaces the body forwarded by CloudFront with data, setting the action and encoding CloudFront expects
+// for a changed body.
+func (b *LambdaEdgeRequestBody) Replace(data []byte) {
+	b.Action = LambdaEdgeBodyActionReplace
+	b.Encoding = LambdaEdgeBodyEncodingBase64
+	b.Data = base64.StdEncoding.EncodeToString(data)
+	b.InputTruncated = false
+}
+
+// LambdaEdgeResponse is the response from the origin in the response events, or the response a request function
+// replies with. Body and BodyEncoding are only used for the responses generated by a function.
+type LambdaEdgeResponse struct {
+	Status            string            `json:"status"`
+	StatusDescription string            `json:"statusDescription,omitempty"`
+	Headers           LambdaEdgeHeaders `json:"headers,omitempty"`
+	Body              string            `json:"body,omitempty"`
+	BodyEncoding      string            `json:"bodyEncoding,omitempty"`
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLambdaEdgeOriginRequestEvent(t *testing.T) {
+	var event LambdaEdgeEvent
+	test.AssertJSONRoundTrip(t, "./testdata/lambda-edge-origin-request-event.json", &event)
+
+	cf := event.Records[0].CF
+	assert.Equal(t, LambdaEdgeEventTypeOriginRequest, cf.Config.EventType)
+	assert.Nil(t, cf.Response)
+	assert.Equal(t, "application/x-www-form-urlencoded", cf.Request.Headers.Get("Content-Type"))
+	assert.Len(t, cf.Request.Headers["cookie"], 2)
+
+	origin := cf.Request.Origin
+	require.NotNil(t, origin.Custom)
+	assert.Nil(t, origin.S3)
+	assert.Equal(t, "b9c4e1f0", origin.Custom.CustomHeaders.Get("x-origin-secret"))
+	assert.Equal(t, 443, origin.Custom.Port)
+	assert.Equal(t, []string{"TLSv1.2"}, origin.Custom.SSLProtocols)
+
+	body := cf.Request.Body
+	assert.Equal(t, LambdaEdgeBodyActionReadOnly, body.Action)
+	data, err := body.Decoded()
+	require.NoError(t, err)
+	assert.Equal(t, "comment=Hello+from+the+viewer", string(data))
+}
+
+func TestLambdaEdgeOriginResponseEvent(t *testing.T) {
+	var event LambdaEdgeEvent
+	test.AssertJSONRoundTrip(t, "./testdata/lambda-edge-origin-response-event.json", &event)
+
+	cf := event.Records[0].CF
+	assert.Equal(t, LambdaEdgeEventTypeOriginResponse, cf.Config.EventType)
+	assert.Nil(t, cf.Request.Body)
+	require.NotNil(t, cf.Request.Origin.S3)
+	assert.Equal(t, "origin-access-identity", cf.Request.Origin.S3.AuthMethod)
+	assert.Equal(t, "us-east-1", cf.Request.Origin.S3.Region)
+
+	require.NotNil(t, cf.Response)
+	assert.Equal(t, "200", cf.Response.Status)
+	assert.Equal(t, "OK", cf.Response.StatusDescription)
+	assert.Equal(t, `"c3d7b2a9f0e1"`, cf.Response.Headers.Get("ETag"))
+}
+
+func TestLambdaEdgeRequestBodyReplace(t *testing.T) {
+	body := &LambdaEdgeRequestBody{InputTruncated: true, Action: LambdaEdgeBodyActionReadOnly, Encoding: LambdaEdgeBodyEncodingText, Data: "comment=Hello"}
+	data, err := body.Decoded()
+	require.NoError(t, err)
+	assert.Equal(t, "comment=Hello", string(data))
+
+	body.Replace([]byte("comment=Bye"))
+	outputJSON, err := json.Marshal(body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"inputTruncated": false, "action": "replace", "encoding": "base64", "data": "Y29tbWVudD1CeWU="}`, string(outputJSON))
+	data, err = body.Decoded()
+	require.NoError(t, err)
+	assert.Equal(t, "comment=Bye", string(data))
+
+	_, err = (&LambdaEdgeRequestBody{Encoding: "gzip"}).Decoded()
+	assert.EqualError(t, err, `unknown lambda@edge body encoding "gzip"`)
+}
+
+func TestLambdaEdgeHeaders(t *testing.T) {
+	headers := LambdaEdgeHeaders{}
+	headers.Set("Cache-Control", "max-age=60")
+	assert.Equal(t, LambdaEdgeHeaders{"cache-control": {{Key: "Cache-Control", Value: "max-age=60"}}}, headers)
+	assert.Equal(t, "max-age=60", headers.Get("CACHE-CONTROL"))
+	assert.Equal(t, "", headers.Get("expires"))
+
+	// a generated response
+	outputJSON, err := json.Marshal(LambdaEdgeResponse{Status: "302", Headers: LambdaEdgeHeaders{"location": {{Key: "Location", Value: "https://example.org/"}}}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"status": "302", "headers": {"location": [{"key": "Location", "value": "https://example.org/"}]}}`, string(outputJSON))
+}
+
+func TestLambdaEdgeEventMalformedJson(t *testing.T) {
+	test.TestMalformedJson(t, LambdaEdgeEvent{})
+}
@@ -68,9 +68,19 @@ type LambdaFunctionURLResponse struct {
 	Cookies         []string          `json:"cookies"`
 }
 
+// The InvokeMode values of a Lambda Function URL, which choose between LambdaFunctionURLResponse and
+// LambdaFunctionURLStreamingResponse for the response of the function.
+const (
+	LambdaFunctionURLInvokeModeBuffered       = "BUFFERED"
+	LambdaFunctionURLInvokeModeResponseStream = "RESPONSE_STREAM"
+)
+
 // LambdaFunctionURLStreamingResponse models the response to a Lambda Function URL when InvokeMode is RESPONSE_STREAM.
 // If the InvokeMode of the Function URL is BUFFERED (default), use LambdaFunctionURLResponse instead.
 //
+// The response is read as a prelude with the status code, headers and cookies as JSON, followed by 8 NUL bytes and
+// the Body, which is read as the response is sent rather than all at once.
+//
 // Note: This response type requires compiling with `-tags lambda.norpc`, or choosing the `provided` or `provided.al2` runtime.
 type LambdaFunctionURLStreamingResponse struct {
 	prelude *bytes.Buffer
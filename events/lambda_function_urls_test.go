@@ -3,8 +3,10 @@
 package events
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"io"
 	"io/ioutil" //nolint: staticcheck
 	"net/http"
 	"strings"
@@ -108,6 +110,63 @@ func TestLambdaFunctionURLStreamingResponseMarshaling(t *testing.T) {
 	}
 }
 
+func TestLambdaFunctionURLStreamingResponsePrelude(t *testing.T) {
+	response := &LambdaFunctionURLStreamingResponse{
+		StatusCode: http.StatusCreated,
+		Headers:    map[string]string{"Content-Type": "text/plain"},
+		Cookies:    []string{"a=1"},
+		Body:       strings.NewReader("hello"),
+	}
+	// read a byte at a time, as the runtime API client may read in any size
+	var output bytes.Buffer
+	p := make([]byte, 1)
+	for {
+		n, err := response.Read(p)
+		output.Write(p[:n])
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+	}
+	assert.Equal(t, `{"statusCode":201,"headers":{"Content-Type":"text/plain"},"cookies":["a=1"]}`+"\x00\x00\x00\x00\x00\x00\x00\x00hello", output.String())
+	assert.Equal(t, "application/vnd.awslambda.http-integration-response", response.ContentType())
+}
+
+// lazyBody produces size bytes as they are read, recording the largest read.
+type lazyBody struct {
+	size    int
+	maxRead int
+}
+
+func (b *lazyBody) Read(p []byte) (int, error) {
+	if b.size == 0 {
+		return 0, io.EOF
+	}
+	n := len(p)
+	if n > b.size {
+		n = b.size
+	}
+	for i := range p[:n] {
+		p[i] = 'x'
+	}
+	b.size -= n
+	if n > b.maxRead {
+		b.maxRead = n
+	}
+	return n, nil
+}
+
+func TestLambdaFunctionURLStreamingResponseLargeBody(t *testing.T) {
+	const size = 64 << 20
+	body := &lazyBody{size: size}
+	response := &LambdaFunctionURLStreamingResponse{Body: body}
+
+	n, err := io.Copy(ioutil.Discard, response)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(`{"statusCode":200}`)+8+size), n)
+	assert.LessOrEqual(t, body.maxRead, 32<<10, "the body should be read in chunks as it is sent")
+}
+
 type readCloser struct {
 	closed bool
 	err    error
@@ -0,0 +1,58 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IsAccessPoint reports whether the event was generated through an S3 access point.
+// The bucket Arn is then the access point ARN, and Name is the access point alias.
+func (b S3Bucket) IsAccessPoint() bool {
+	arn := splitARN(b.Arn)
+	return arn != nil && arn[2] == "s3" && strings.HasPrefix(arn[5], "accesspoint/")
+}
+
+// UnderlyingBucketName returns the name of the bucket the event refers to, falling back to the bucket ARN when Name is empty.
+// Records generated through an access point do not include the name of the bucket behind it, so an error is returned
+// for them; use URI, or the access point ARN or alias in Name, to address the object instead.
+func (b S3Bucket) UnderlyingBucketName() (string, error) {
+	if b.IsAccessPoint() {
+		return "", fmt.Errorf("bucket name is not available for access point %s", b.Arn)
+	}
+	if b.Name != "" {
+		return b.Name, nil
+	}
+	if arn := splitARN(b.Arn); arn != nil && arn[2] == "s3" && arn[5] != "" {
+		return arn[5], nil
+	}
+	return "", fmt.Errorf("bucket has neither a name nor a bucket ARN")
+}
+
+// URI returns the s3:// URI of the object the event refers to, using the decoded object key.
+// For records generated through an access point the URI is built from the access point ARN,
+// which the AWS CLI and SDKs accept in place of a bucket name.
+func (e S3Entity) URI() string {
+	bucket, err := e.Bucket.UnderlyingBucketName()
+	if err != nil {
+		bucket = e.Bucket.Arn
+	}
+	return "s3://" + bucket + "/" + e.Object.URLDecodedKey
+}
+
+// RequestID returns the x-amz-request-id response element, the ID of the request that caused the event.
+func (r S3EventRecord) RequestID() string {
+	return r.ResponseElements["x-amz-request-id"]
+}
+
+// HostID returns the x-amz-id-2 response element, the ID of the host that processed the request.
+// Together with RequestID it is needed by AWS Support to trace the request.
+func (r S3EventRecord) HostID() string {
+	return r.ResponseElements["x-amz-id-2"]
+}
+
+// SourceIPAddress returns the IP address the request that caused the event was made from.
+func (r S3EventRecord) SourceIPAddress() string {
+	return r.RequestParameters.SourceIPAddress
+}
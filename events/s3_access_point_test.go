@@ -0,0 +1,66 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestS3AccessPointEventMarshaling(t *testing.T) {
+	inputJSON := test.ReadJSONFromFile(t, "./testdata/s3-event-access-point.json")
+
+	var inputEvent S3Event
+	require.NoError(t, json.Unmarshal(inputJSON, &inputEvent))
+
+	outputJSON, err := json.Marshal(inputEvent)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(inputJSON), string(outputJSON))
+
+	record := inputEvent.Records[0]
+	assert.True(t, record.S3.Bucket.IsAccessPoint())
+	_, err = record.S3.Bucket.UnderlyingBucketName()
+	assert.EqualError(t, err, "bucket name is not available for access point arn:aws:s3:us-east-1:123456789012:accesspoint/my-access-point")
+	assert.Equal(t, "s3://arn:aws:s3:us-east-1:123456789012:accesspoint/my-access-point/reports/2026/Q1 summary.csv", record.S3.URI())
+	assert.Equal(t, "D82B88E5F771F645", record.RequestID())
+	assert.Equal(t, "vlR7PnpV2Ce81l0PRw6jlUpck7Jo5ZsQjryTjKlc5aLWGVHPZLj5NeC6qMa0emYBDXOo6QBU0Wo=", record.HostID())
+	assert.Equal(t, "203.0.113.10", record.SourceIPAddress())
+}
+
+func TestS3BucketEventAccessors(t *testing.T) {
+	inputJSON := test.ReadJSONFromFile(t, "./testdata/s3-event.json")
+
+	var inputEvent S3Event
+	require.NoError(t, json.Unmarshal(inputJSON, &inputEvent))
+
+	record := inputEvent.Records[0]
+	assert.False(t, record.S3.Bucket.IsAccessPoint())
+	name, err := record.S3.Bucket.UnderlyingBucketName()
+	require.NoError(t, err)
+	assert.Equal(t, "sourcebucket", name)
+	assert.Equal(t, "s3://sourcebucket/Happy Face.jpg", record.S3.URI())
+	assert.Equal(t, "C3D13FE58DE4C810", record.RequestID())
+	assert.Equal(t, "FMyUVURIY8/IgAtTv8xRjskZQpcIZ9KG4V5Wp6S7S/JRWeUWerMUE5JgHvANOjpD", record.HostID())
+	assert.Equal(t, "127.0.0.1", record.SourceIPAddress())
+}
+
+func TestS3BucketUnderlyingBucketName(t *testing.T) {
+	for _, tc := range []struct {
+		bucket      S3Bucket
+		expected    string
+		expectError bool
+	}{
+		{S3Bucket{Name: "mybucket", Arn: "arn:aws:s3:::mybucket"}, "mybucket", false},
+		{S3Bucket{Arn: "arn:aws-cn:s3:::mybucket"}, "mybucket", false},
+		{S3Bucket{Name: "alias-s3alias", Arn: "arn:aws:s3:us-west-2:123456789012:accesspoint/ap"}, "", true},
+		{S3Bucket{}, "", true},
+	} {
+		name, err := tc.bucket.UnderlyingBucketName()
+		assert.Equal(t, tc.expected, name)
+		assert.Equal(t, tc.expectError, err != nil, "bucket %+v", tc.bucket)
+	}
+}
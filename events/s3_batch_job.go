@@ -2,6 +2,11 @@
 
 package events
 
+import (
+	"net/url"
+	"strings"
+)
+
 // S3BatchJobEvent encapsulates the detail of a s3 batch job
 type S3BatchJobEvent struct {
 	InvocationSchemaVersion string           `json:"invocationSchemaVersion"`
@@ -23,6 +28,16 @@ type S3BatchJobTask struct {
 	S3BucketARN string `json:"s3BucketArn"`
 }
 
+// Bucket returns the name of the bucket of the object of the task, from its ARN.
+func (t S3BatchJobTask) Bucket() string {
+	return t.S3BucketARN[strings.LastIndex(t.S3BucketARN, ":")+1:]
+}
+
+// DecodedKey returns the key of the object of the task, which the invocation schema 1.0 URL encodes.
+func (t S3BatchJobTask) DecodedKey() (string, error) {
+	return url.QueryUnescape(t.S3Key)
+}
+
 // S3BatchJobEventV2 encapsulates the detail of a s3 batch job
 type S3BatchJobEventV2 struct {
 	InvocationSchemaVersion string             `json:"invocationSchemaVersion"`
@@ -45,6 +60,19 @@ type S3BatchJobTaskV2 struct {
 	S3Bucket    string `json:"s3Bucket"`
 }
 
+// Bucket returns the name of the bucket of the object of the task, like S3BatchJobTask.Bucket.
+// The key of the object is not encoded in the invocation schema 2.0, and is S3Key as is.
+func (t S3BatchJobTaskV2) Bucket() string {
+	return t.S3Bucket
+}
+
+// The ResultCode values of S3BatchJobResult, and the TreatMissingKeysAs values of S3BatchJobResponse.
+const (
+	S3BatchJobResultCodeSucceeded        = "Succeeded"
+	S3BatchJobResultCodeTemporaryFailure = "TemporaryFailure"
+	S3BatchJobResultCodePermanentFailure = "PermanentFailure"
+)
+
 // S3BatchJobResponse is the response of a iven s3 batch job with the results
 type S3BatchJobResponse struct {
 	InvocationSchemaVersion string             `json:"invocationSchemaVersion"`
@@ -71,3 +71,39 @@ func TestS3BatchJobResponseMarshaling(t *testing.T) {
 	// 4. check result
 	assert.JSONEq(t, string(inputJSON), string(outputJSON))
 }
+
+func TestS3BatchJobTaskBucket(t *testing.T) {
+	var event S3BatchJobEvent
+	assert.NoError(t, json.Unmarshal(test.ReadJSONFromFile(t, "./testdata/s3-batch-job-event-request-1.0.json"), &event))
+	assert.Equal(t, "awsexamplebucket", event.Tasks[0].Bucket())
+
+	var eventV2 S3BatchJobEventV2
+	assert.NoError(t, json.Unmarshal(test.ReadJSONFromFile(t, "./testdata/s3-batch-job-event-request-2.0.json"), &eventV2))
+	assert.Equal(t, "awsexamplebucket", eventV2.Tasks[0].Bucket())
+	assert.Equal(t, map[string]string{"k1": "v1", "k2": "v2"}, eventV2.Job.UserArguments)
+
+	assert.Equal(t, "my-bucket", S3BatchJobTask{S3BucketARN: "arn:aws:s3:::my-bucket"}.Bucket())
+}
+
+func TestS3BatchJobTaskDecodedKey(t *testing.T) {
+	key, err := S3BatchJobTask{S3Key: "photos/my+photo%281%29.jpg"}.DecodedKey()
+	assert.NoError(t, err)
+	assert.Equal(t, "photos/my photo(1).jpg", key)
+
+	_, err = S3BatchJobTask{S3Key: "100%"}.DecodedKey()
+	assert.Error(t, err)
+}
+
+func TestS3BatchJobResponseEncoding(t *testing.T) {
+	response := S3BatchJobResponse{
+		InvocationSchemaVersion: "2.0",
+		TreatMissingKeysAs:      S3BatchJobResultCodePermanentFailure,
+		InvocationID:            "YXNkbGZqYWRmaiBhc2RmdW9hZHNmZGpmaGFzbGtkaGZza2RmaAo",
+		Results: []S3BatchJobResult{
+			{TaskID: "dGFza2lkZ29lc2hlcmUK", ResultCode: S3BatchJobResultCodeSucceeded, ResultString: "done"},
+		},
+	}
+	outputJSON, err := json.Marshal(response)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"invocationSchemaVersion":"2.0","treatMissingKeysAs":"PermanentFailure","invocationId":"YXNkbGZqYWRmaiBhc2RmdW9hZHNmZGpmaGFzbGtkaGZza2RmaAo","results":[{"taskId":"dGFza2lkZ29lc2hlcmUK","resultCode":"Succeeded","resultString":"done"}]}`, string(outputJSON))
+}
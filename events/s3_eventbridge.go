@@ -0,0 +1,77 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"time"
+)
+
+const (
+	S3EventBridgeEventSource = "aws.s3"
+
+	S3ObjectCreatedDetailType             = "Object Created"
+	S3ObjectDeletedDetailType             = "Object Deleted"
+	S3ObjectRestoreInitiatedDetailType    = "Object Restore Initiated"
+	S3ObjectRestoreCompletedDetailType    = "Object Restore Completed"
+	S3ObjectRestoreExpiredDetailType      = "Object Restore Expired"
+	S3ObjectTagsAddedDetailType           = "Object Tags Added"
+	S3ObjectTagsDeletedDetailType         = "Object Tags Deleted"
+	S3ObjectACLUpdatedDetailType          = "Object ACL Updated"
+	S3ObjectStorageClassChangedDetailType = "Object Storage Class Changed"
+	S3ObjectAccessTierChangedDetailType   = "Object Access Tier Changed"
+)
+
+// S3EventBridgeEvent is an S3 event notification delivered through EventBridge.
+// It has the shape of an EventBridgeEvent, with the Detail decoded.
+// See https://docs.aws.amazon.com/AmazonS3/latest/userguide/ev-events.html
+type S3EventBridgeEvent struct {
+	Version    string `json:"version"`
+	ID         string `json:"id"`
+	DetailType string `json:"detail-type"`
+	// Source should be equal to S3EventBridgeEventSource.
+	Source    string    `json:"source"`
+	AccountID string    `json:"account"`
+	Time      time.Time `json:"time"`
+	Region    string    `json:"region"`
+	// Resources holds the ARN of the bucket.
+	Resources []string                 `json:"resources"`
+	Detail    S3EventBridgeEventDetail `json:"detail"`
+}
+
+// S3EventBridgeEventDetail is the detail of every S3 EventBridge event type. Which of the optional
+// fields are set depends on the DetailType of the event.
+type S3EventBridgeEventDetail struct {
+	Version         string                   `json:"version"`
+	Bucket          S3EventBridgeEventBucket `json:"bucket"`
+	Object          S3EventBridgeEventObject `json:"object"`
+	RequestID       string                   `json:"request-id"`
+	Requester       string                   `json:"requester"`
+	SourceIPAddress string                   `json:"source-ip-address,omitempty"`
+	// Reason is the API call that caused the event, for example "PutObject", "CopyObject",
+	// "CompleteMultipartUpload", "DeleteObject" or "Lifecycle Expiration".
+	Reason string `json:"reason,omitempty"`
+	// DeletionType is set on Object Deleted events, to "Permanently Deleted" or "Delete Marker Created".
+	DeletionType string `json:"deletion-type,omitempty"`
+	// RestoreExpiryTime is set on Object Restore Completed events.
+	RestoreExpiryTime *time.Time `json:"restore-expiry-time,omitempty"`
+	// SourceStorageClass is set on Object Restore Initiated and Object Restore Completed events.
+	SourceStorageClass string `json:"source-storage-class,omitempty"`
+	// DestinationStorageClass is set on Object Storage Class Changed events.
+	DestinationStorageClass string `json:"destination-storage-class,omitempty"`
+	// DestinationAccessTier is set on Object Access Tier Changed events.
+	DestinationAccessTier string `json:"destination-access-tier,omitempty"`
+}
+
+type S3EventBridgeEventBucket struct {
+	Name string `json:"name"`
+}
+
+// S3EventBridgeEventObject describes the object of the event. Size is not set on Object Deleted events,
+// and VersionID only when versioning is enabled on the bucket.
+type S3EventBridgeEventObject struct {
+	Key       string `json:"key"`
+	Size      int64  `json:"size,omitempty"`
+	ETag      string `json:"etag,omitempty"`
+	VersionID string `json:"version-id,omitempty"`
+	Sequencer string `json:"sequencer,omitempty"`
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestS3EventBridgeEventMarshaling(t *testing.T) {
+	for _, file := range []string{
+		"./testdata/s3-eventbridge-object-created.json",
+		"./testdata/s3-eventbridge-object-deleted.json",
+		"./testdata/s3-eventbridge-object-restore-completed.json",
+	} {
+		t.Run(file, func(t *testing.T) {
+			inputJSON := test.ReadJSONFromFile(t, file)
+
+			var inputEvent S3EventBridgeEvent
+			if err := json.Unmarshal(inputJSON, &inputEvent); err != nil {
+				t.Errorf("could not unmarshal event. details: %v", err)
+			}
+
+			outputJSON, err := json.Marshal(inputEvent)
+			if err != nil {
+				t.Errorf("could not marshal event. details: %v", err)
+			}
+
+			assert.JSONEq(t, string(inputJSON), string(outputJSON))
+		})
+	}
+}
+
+func TestS3EventBridgeEventDetail(t *testing.T) {
+	var created S3EventBridgeEvent
+	require.NoError(t, json.Unmarshal(test.ReadJSONFromFile(t, "./testdata/s3-eventbridge-object-created.json"), &created))
+	assert.Equal(t, S3EventBridgeEventSource, created.Source)
+	assert.Equal(t, S3ObjectCreatedDetailType, created.DetailType)
+	assert.Equal(t, "example-bucket", created.Detail.Bucket.Name)
+	assert.Equal(t, S3EventBridgeEventObject{
+		Key:       "example-key",
+		Size:      5,
+		ETag:      "b1946ac92492d2347c6235b4d2611184",
+		VersionID: "IYV3p45BT0ac8hjHg1houSdS1a.Mro8e",
+		Sequencer: "00617F08299329D189",
+	}, created.Detail.Object)
+	assert.Equal(t, "PutObject", created.Detail.Reason)
+	assert.Equal(t, "1.2.3.4", created.Detail.SourceIPAddress)
+
+	var deleted S3EventBridgeEvent
+	require.NoError(t, json.Unmarshal(test.ReadJSONFromFile(t, "./testdata/s3-eventbridge-object-deleted.json"), &deleted))
+	assert.Equal(t, S3ObjectDeletedDetailType, deleted.DetailType)
+	assert.Equal(t, "Delete Marker Created", deleted.Detail.DeletionType)
+	assert.Zero(t, deleted.Detail.Object.Size)
+
+	var restored S3EventBridgeEvent
+	require.NoError(t, json.Unmarshal(test.ReadJSONFromFile(t, "./testdata/s3-eventbridge-object-restore-completed.json"), &restored))
+	assert.Equal(t, S3ObjectRestoreCompletedDetailType, restored.DetailType)
+	assert.Equal(t, "GLACIER", restored.Detail.SourceStorageClass)
+	require.NotNil(t, restored.Detail.RestoreExpiryTime)
+	assert.Equal(t, time.Date(2021, 11, 13, 0, 0, 0, 0, time.UTC), *restored.Detail.RestoreExpiryTime)
+}
+
+func TestS3EventBridgeEventMarshalingMalformedJson(t *testing.T) {
+	test.TestMalformedJson(t, S3EventBridgeEvent{})
+}
@@ -12,6 +12,46 @@ type S3ObjectLambdaEvent struct {
 	ProtocolVersion      string                              `json:"protocolVersion"`
 }
 
+// The operations of the requests S3 Object Lambda invokes a function for, as returned by S3ObjectLambdaEvent.Operation.
+const (
+	S3ObjectLambdaGetObject     = "GetObject"
+	S3ObjectLambdaHeadObject    = "HeadObject"
+	S3ObjectLambdaListObjects   = "ListObjects"
+	S3ObjectLambdaListObjectsV2 = "ListObjectsV2"
+)
+
+// Operation returns the operation of the request the function is invoked for, according to the context set in the
+// event, or "" if none is.
+func (e S3ObjectLambdaEvent) Operation() string {
+	switch {
+	case e.GetObjectContext != nil:
+		return S3ObjectLambdaGetObject
+	case e.HeadObjectContext != nil:
+		return S3ObjectLambdaHeadObject
+	case e.ListObjectsContext != nil:
+		return S3ObjectLambdaListObjects
+	case e.ListObjectsV2Context != nil:
+		return S3ObjectLambdaListObjectsV2
+	}
+	return ""
+}
+
+// InputS3URL returns the presigned URL to fetch the original object or listing from, of whichever context is set in
+// the event, or "" if none is.
+func (e S3ObjectLambdaEvent) InputS3URL() string {
+	switch {
+	case e.GetObjectContext != nil:
+		return e.GetObjectContext.InputS3URL
+	case e.HeadObjectContext != nil:
+		return e.HeadObjectContext.InputS3URL
+	case e.ListObjectsContext != nil:
+		return e.ListObjectsContext.InputS3URL
+	case e.ListObjectsV2Context != nil:
+		return e.ListObjectsV2Context.InputS3URL
+	}
+	return ""
+}
+
 type S3ObjectLambdaGetObjectContext struct {
 	InputS3URL  string `json:"inputS3Url"`
 	OutputRoute string `json:"outputRoute"`
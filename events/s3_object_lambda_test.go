@@ -10,13 +10,14 @@ import (
 
 func TestS3ObjectLambdaEventMarshaling(t *testing.T) {
 	tests := []struct {
-		file string
+		file      string
+		operation string
 	}{
-		{"./testdata/s3-object-lambda-event-get-object-iam.json"},
-		{"./testdata/s3-object-lambda-event-get-object-assumed-role.json"},
-		{"./testdata/s3-object-lambda-event-head-object-iam.json"},
-		{"./testdata/s3-object-lambda-event-list-objects-iam.json"},
-		{"./testdata/s3-object-lambda-event-list-objects-v2-iam.json"},
+		{"./testdata/s3-object-lambda-event-get-object-iam.json", S3ObjectLambdaGetObject},
+		{"./testdata/s3-object-lambda-event-get-object-assumed-role.json", S3ObjectLambdaGetObject},
+		{"./testdata/s3-object-lambda-event-head-object-iam.json", S3ObjectLambdaHeadObject},
+		{"./testdata/s3-object-lambda-event-list-objects-iam.json", S3ObjectLambdaListObjects},
+		{"./testdata/s3-object-lambda-event-list-objects-v2-iam.json", S3ObjectLambdaListObjectsV2},
 	}
 
 	for _, tc := range tests {
@@ -28,6 +29,8 @@ func TestS3ObjectLambdaEventMarshaling(t *testing.T) {
 			if err := json.Unmarshal(inputJSON, &inputEvent); err != nil {
 				t.Errorf("could not unmarshal event. details: %v", err)
 			}
+			assert.Equal(t, tc.operation, inputEvent.Operation())
+			assert.Contains(t, inputEvent.InputS3URL(), "X-Amz-Security-Token=")
 
 			outputJSON, err := json.Marshal(inputEvent)
 			if err != nil {
@@ -39,6 +42,12 @@ func TestS3ObjectLambdaEventMarshaling(t *testing.T) {
 	}
 }
 
+func TestS3ObjectLambdaEventWithoutContext(t *testing.T) {
+	var event S3ObjectLambdaEvent
+	assert.Equal(t, "", event.Operation())
+	assert.Equal(t, "", event.InputS3URL())
+}
+
 func TestS3ObjectLambdaMarshalingMalformedJson(t *testing.T) {
 	test.TestMalformedJson(t, S3ObjectLambdaEvent{})
 }
@@ -5,8 +5,18 @@ package events
 //
 // https://docs.aws.amazon.com/secretsmanager/latest/userguide/rotating-secrets.html#rotate-secrets_how
 type SecretsManagerSecretRotationEvent struct {
-	Step               string `json:"Step"`
+	Step               string `json:"Step"` // one of the SecretsManagerSecretRotationStep* constants
 	SecretID           string `json:"SecretId"`
 	ClientRequestToken string `json:"ClientRequestToken"`
-	RotationToken      string `json:"RotationToken"`
+	// RotationToken is set when the secret is rotated by a function in another account, which passes it to the
+	// Secrets Manager calls of the rotation.
+	RotationToken string `json:"RotationToken"`
 }
+
+// The Step values of SecretsManagerSecretRotationEvent, in the order the function is invoked for a rotation.
+const (
+	SecretsManagerSecretRotationStepCreateSecret = "createSecret"
+	SecretsManagerSecretRotationStepSetSecret    = "setSecret"
+	SecretsManagerSecretRotationStepTestSecret   = "testSecret"
+	SecretsManagerSecretRotationStepFinishSecret = "finishSecret"
+)
@@ -28,3 +28,26 @@ func TestSecretsManagerSecretRotationEventMarshaling(t *testing.T) {
 	// 4. check result
 	assert.JSONEq(t, string(inputJSON), string(outputJSON))
 }
+
+func TestSecretsManagerSecretRotationEventSteps(t *testing.T) {
+	for file, expected := range map[string]SecretsManagerSecretRotationEvent{
+		"./testdata/secretsmanager-secret-rotation-event.json": {
+			Step:               SecretsManagerSecretRotationStepCreateSecret,
+			SecretID:           "arn:aws:secretsmanager:us-east-1:111122223333:secret:id-ABCD1E",
+			ClientRequestToken: "1ab23456-cde7-8912-34fg-h56i78j9k12l",
+			RotationToken:      "abcd1234-efgh-5678-ijkl-8ab4515a7db0",
+		},
+		"./testdata/secretsmanager-secret-rotation-event-same-account.json": {
+			Step:               SecretsManagerSecretRotationStepFinishSecret,
+			SecretID:           "arn:aws:secretsmanager:us-east-1:111122223333:secret:id-ABCD1E",
+			ClientRequestToken: "1ab23456-cde7-8912-34fg-h56i78j9k12l",
+		},
+	} {
+		inputJSON := test.ReadJSONFromFile(t, file)
+		var inputEvent SecretsManagerSecretRotationEvent
+		if err := json.Unmarshal(inputJSON, &inputEvent); err != nil {
+			t.Errorf("could not unmarshal event. details: %v", err)
+		}
+		assert.Equal(t, expected, inputEvent)
+	}
+}
@@ -34,7 +34,7 @@ type SimpleEmailReceipt struct {
 	SpamVerdict          SimpleEmailVerdict       `json:"spamVerdict"`
 	DKIMVerdict          SimpleEmailVerdict       `json:"dkimVerdict"`
 	DMARCVerdict         SimpleEmailVerdict       `json:"dmarcVerdict"`
-	DMARCPolicy          string                   `json:"dmarcPolicy"`
+	DMARCPolicy          string                   `json:"dmarcPolicy,omitempty"` // only sent when the DMARC verdict is FAIL
 	SPFVerdict           SimpleEmailVerdict       `json:"spfVerdict"`
 	VirusVerdict         SimpleEmailVerdict       `json:"virusVerdict"`
 	Action               SimpleEmailReceiptAction `json:"action"`
@@ -56,15 +56,35 @@ type SimpleEmailCommonHeaders struct {
 	Subject    string   `json:"subject"`
 }
 
+// The Type values of SimpleEmailReceiptAction.
+const (
+	SimpleEmailActionTypeS3       = "S3"
+	SimpleEmailActionTypeSNS      = "SNS"
+	SimpleEmailActionTypeLambda   = "Lambda"
+	SimpleEmailActionTypeBounce   = "Bounce"
+	SimpleEmailActionTypeStop     = "Stop"
+	SimpleEmailActionTypeWorkMail = "WorkMail"
+)
+
 // SimpleEmailReceiptAction is a logical union of fields present in all action
 // Types. For example, the FunctionARN and InvocationType fields are only
 // present for the Lambda Type, and the BucketName and ObjectKey fields are only
 // present for the S3 Type.
+//
+//   - S3: TopicARN, BucketName, ObjectKeyPrefix, ObjectKey, KMSKeyARN
+//   - SNS: TopicARN, Encoding
+//   - Lambda: TopicARN, FunctionARN, InvocationType
+//   - Bounce: TopicARN, SMTPReplyCode, StatusCode, Message, Sender
+//   - Stop: TopicARN
+//   - WorkMail: TopicARN, OrganizationARN
 type SimpleEmailReceiptAction struct {
 	Type            string `json:"type"`
 	TopicARN        string `json:"topicArn,omitempty"`
 	BucketName      string `json:"bucketName,omitempty"`
+	ObjectKeyPrefix string `json:"objectKeyPrefix,omitempty"`
 	ObjectKey       string `json:"objectKey,omitempty"`
+	KMSKeyARN       string `json:"kmsKeyArn,omitempty"`
+	Encoding        string `json:"encoding,omitempty"`
 	SMTPReplyCode   string `json:"smtpReplyCode,omitempty"`
 	StatusCode      string `json:"statusCode,omitempty"`
 	Message         string `json:"message,omitempty"`
@@ -16,6 +16,11 @@ func TestSESEventMarshaling(t *testing.T) {
 		{"./testdata/ses-lambda-event.json"},
 		{"./testdata/ses-s3-event.json"},
 		{"./testdata/ses-sns-event.json"},
+		{"./testdata/ses-sns-encoding-event.json"},
+		{"./testdata/ses-s3-key-prefix-event.json"},
+		{"./testdata/ses-bounce-event.json"},
+		{"./testdata/ses-stop-event.json"},
+		{"./testdata/ses-workmail-event.json"},
 	}
 
 	for _, tc := range tests {
@@ -38,6 +43,50 @@ func TestSESEventMarshaling(t *testing.T) {
 	}
 }
 
+func TestSESReceiptActions(t *testing.T) {
+	receipt := func(file string) SimpleEmailReceipt {
+		var event SimpleEmailEvent
+		test.AssertJSONRoundTrip(t, file, &event)
+		return event.Records[0].SES.Receipt
+	}
+
+	r := receipt("./testdata/ses-lambda-event.json")
+	assert.Equal(t, SimpleEmailActionTypeLambda, r.Action.Type)
+	assert.Equal(t, "Event", r.Action.InvocationType)
+	assert.Equal(t, "reject", r.DMARCPolicy)
+
+	r = receipt("./testdata/ses-s3-key-prefix-event.json")
+	assert.Equal(t, SimpleEmailActionTypeS3, r.Action.Type)
+	assert.Equal(t, "inbound/", r.Action.ObjectKeyPrefix)
+	assert.Equal(t, "arn:aws:kms:us-east-1:012345678912:alias/aws/ses", r.Action.KMSKeyARN)
+
+	r = receipt("./testdata/ses-sns-encoding-event.json")
+	assert.Equal(t, SimpleEmailActionTypeSNS, r.Action.Type)
+	assert.Equal(t, "BASE64", r.Action.Encoding)
+
+	r = receipt("./testdata/ses-bounce-event.json")
+	assert.Equal(t, SimpleEmailActionTypeBounce, r.Action.Type)
+	assert.Equal(t, SimpleEmailReceiptAction{
+		Type:          SimpleEmailActionTypeBounce,
+		TopicARN:      "arn:aws:sns:us-east-1:012345678912:example-topic",
+		SMTPReplyCode: "550",
+		StatusCode:    "5.1.1",
+		Message:       "Mailbox does not exist",
+		Sender:        "mailer-daemon@example.com",
+	}, r.Action)
+	assert.Equal(t, "FAIL", r.DMARCVerdict.Status)
+	assert.Equal(t, "quarantine", r.DMARCPolicy)
+
+	r = receipt("./testdata/ses-stop-event.json")
+	assert.Equal(t, SimpleEmailReceiptAction{Type: SimpleEmailActionTypeStop, TopicARN: "arn:aws:sns:us-east-1:012345678912:example-topic"}, r.Action)
+	assert.Equal(t, "GRAY", r.DMARCVerdict.Status)
+	assert.Empty(t, r.DMARCPolicy)
+
+	r = receipt("./testdata/ses-workmail-event.json")
+	assert.Equal(t, SimpleEmailActionTypeWorkMail, r.Action.Type)
+	assert.Equal(t, "arn:aws:workmail:us-east-1:012345678912:organization/m-68755160c4cb4e29a2b2f8fb58f359d7", r.Action.OrganizationARN)
+}
+
 func TestSESMarshalingMalformedJson(t *testing.T) {
 	test.TestMalformedJson(t, SimpleEmailEvent{})
 }
@@ -0,0 +1,188 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// SigV4Option configures how SigV4CanonicalRequest builds a canonical request.
+type SigV4Option func(*sigV4Options)
+
+type sigV4Options struct {
+	singleEncodePath bool
+}
+
+// WithSigV4Service sets the service of the signing scope, such as lambda or execute-api. The path of the canonical
+// request is URI encoded twice for every service but s3, for which it is encoded once, as by the AWS SDKs.
+// Without this option, the path is encoded twice.
+func WithSigV4Service(service string) SigV4Option {
+	return func(o *sigV4Options) {
+		o.singleEncodePath = service == "s3"
+	}
+}
+
+// SigV4CanonicalRequest returns the AWS Signature Version 4 canonical request for the Function URL request,
+// using the headers listed in signedHeaders, as found in the SignedHeaders component of the Authorization header.
+// The result can be passed to a SigV4 or HMAC verification library to check the signature of the original HTTP request.
+// See canonicalRequest for how the event is mapped back to the signed request.
+func (r LambdaFunctionURLRequest) SigV4CanonicalRequest(signedHeaders []string, options ...SigV4Option) (string, error) {
+	return canonicalRequest(r.RequestContext.HTTP.Method, r.RawPath, r.RawQueryString, r.Headers, r.Cookies, r.Body, r.IsBase64Encoded, signedHeaders, options)
+}
+
+// SigV4CanonicalRequest returns the AWS Signature Version 4 canonical request for the API Gateway HTTP API request,
+// using the headers listed in signedHeaders, as found in the SignedHeaders component of the Authorization header.
+// The result can be passed to a SigV4 or HMAC verification library to check the signature of the original HTTP request.
+// See canonicalRequest for how the event is mapped back to the signed request.
+func (r APIGatewayV2HTTPRequest) SigV4CanonicalRequest(signedHeaders []string, options ...SigV4Option) (string, error) {
+	return canonicalRequest(r.RequestContext.HTTP.Method, r.RawPath, r.RawQueryString, r.Headers, r.Cookies, r.Body, r.IsBase64Encoded, signedHeaders, options)
+}
+
+// canonicalRequest builds a SigV4 canonical request from the parts of a payload format 2.0 event:
+//   - the path is URI encoded segment by segment, after decoding the raw path: twice, as the already encoded path
+//     of the request is encoded again, or once for the s3 service
+//   - query parameters are decoded, re-encoded and sorted by name, then value
+//   - header values are trimmed and sequential spaces are folded into one; values of repeated headers are
+//     already joined with commas in the event. The cookie header is rebuilt from the cookies field.
+//   - the payload hash is taken from the x-amz-content-sha256 header when it is signed, otherwise it is
+//     computed from the body, decoded from base64 when needed.
+func canonicalRequest(method, rawPath, rawQuery string, headers map[string]string, cookies []string, body string, isBase64Encoded bool, signedHeaders []string, options []SigV4Option) (string, error) {
+	var opts sigV4Options
+	for _, option := range options {
+		option(&opts)
+	}
+
+	lowerHeaders := make(map[string]string, len(headers)+1)
+	for k, v := range headers {
+		lowerHeaders[strings.ToLower(k)] = v
+	}
+	if len(cookies) > 0 {
+		lowerHeaders["cookie"] = strings.Join(cookies, "; ")
+	}
+
+	names := make([]string, 0, len(signedHeaders))
+	for _, name := range signedHeaders {
+		names = append(names, strings.ToLower(strings.TrimSpace(name)))
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	signedPayloadHash := false
+	for _, name := range names {
+		signedPayloadHash = signedPayloadHash || name == "x-amz-content-sha256"
+		value, ok := lowerHeaders[name]
+		if !ok {
+			return "", fmt.Errorf("signed header %q is not present in the request", name)
+		}
+		canonicalHeaders.WriteString(name)
+		canonicalHeaders.WriteByte(':')
+		canonicalHeaders.WriteString(strings.Join(strings.Fields(value), " "))
+		canonicalHeaders.WriteByte('\n')
+	}
+
+	payloadHash := lowerHeaders["x-amz-content-sha256"]
+	if !signedPayloadHash {
+		payload := []byte(body)
+		if isBase64Encoded {
+			decoded, err := base64.StdEncoding.DecodeString(body)
+			if err != nil {
+				return "", fmt.Errorf("decoding base64 body: %w", err)
+			}
+			payload = decoded
+		}
+		sum := sha256.Sum256(payload)
+		payloadHash = hex.EncodeToString(sum[:])
+	}
+
+	canonicalQuery, err := canonicalQueryString(rawQuery)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join([]string{
+		strings.ToUpper(method),
+		canonicalURI(rawPath, !opts.singleEncodePath),
+		canonicalQuery,
+		canonicalHeaders.String(),
+		strings.Join(names, ";"),
+		payloadHash,
+	}, "\n"), nil
+}
+
+func canonicalURI(rawPath string, doubleEncode bool) string {
+	if rawPath == "" {
+		return "/"
+	}
+	segments := strings.Split(rawPath, "/")
+	for i, segment := range segments {
+		if decoded, err := url.PathUnescape(segment); err == nil {
+			segment = decoded
+		}
+		segment = sigV4Escape(segment)
+		if doubleEncode {
+			segment = sigV4Escape(segment)
+		}
+		segments[i] = segment
+	}
+	return strings.Join(segments, "/")
+}
+
+func canonicalQueryString(rawQuery string) (string, error) {
+	if rawQuery == "" {
+		return "", nil
+	}
+	var params [][2]string
+	for _, param := range strings.Split(rawQuery, "&") {
+		if param == "" {
+			continue
+		}
+		var value string
+		key := param
+		if i := strings.IndexByte(param, '='); i >= 0 {
+			key, value = param[:i], param[i+1:]
+		}
+		key, err := url.QueryUnescape(key)
+		if err != nil {
+			return "", fmt.Errorf("decoding query string: %w", err)
+		}
+		value, err = url.QueryUnescape(value)
+		if err != nil {
+			return "", fmt.Errorf("decoding query string: %w", err)
+		}
+		params = append(params, [2]string{sigV4Escape(key), sigV4Escape(value)})
+	}
+	sort.Slice(params, func(i, j int) bool {
+		if params[i][0] != params[j][0] {
+			return params[i][0] < params[j][0]
+		}
+		return params[i][1] < params[j][1]
+	})
+	pairs := make([]string, len(params))
+	for i, param := range params {
+		pairs[i] = param[0] + "=" + param[1]
+	}
+	return strings.Join(pairs, "&"), nil
+}
+
+// sigV4Escape percent-encodes every byte except the RFC 3986 unreserved characters.
+func sigV4Escape(s string) string {
+	const hexDigits = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(hexDigits[c>>4])
+		b.WriteByte(hexDigits[c&0xF])
+	}
+	return b.String()
+}
@@ -0,0 +1,212 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// test vectors adapted from the AWS Signature Version 4 test suite
+func TestSigV4CanonicalRequest(t *testing.T) {
+	vanillaHeaders := map[string]string{
+		"host":       "example.amazonaws.com",
+		"x-amz-date": "20150830T123600Z",
+	}
+	for name, tc := range map[string]struct {
+		method          string
+		rawPath         string
+		rawQuery        string
+		headers         map[string]string
+		body            string
+		isBase64Encoded bool
+		signedHeaders   []string
+		options         []SigV4Option
+		expected        string
+	}{
+		"get-vanilla": {
+			method:        "GET",
+			rawPath:       "/",
+			headers:       vanillaHeaders,
+			signedHeaders: []string{"host", "x-amz-date"},
+			expected:      "GET\n/\n\nhost:example.amazonaws.com\nx-amz-date:20150830T123600Z\n\nhost;x-amz-date\n" + emptyPayloadHash,
+		},
+		"get-vanilla-query-order-key-case": {
+			method:        "GET",
+			rawPath:       "/",
+			rawQuery:      "Param2=value2&Param1=value1",
+			headers:       vanillaHeaders,
+			signedHeaders: []string{"x-amz-date", "host"},
+			expected:      "GET\n/\nParam1=value1&Param2=value2\nhost:example.amazonaws.com\nx-amz-date:20150830T123600Z\n\nhost;x-amz-date\n" + emptyPayloadHash,
+		},
+		"get-vanilla-query-order-value": {
+			method:        "GET",
+			rawPath:       "/",
+			rawQuery:      "Param1=value2&Param1=Value1",
+			headers:       vanillaHeaders,
+			signedHeaders: []string{"host", "x-amz-date"},
+			expected:      "GET\n/\nParam1=Value1&Param1=value2\nhost:example.amazonaws.com\nx-amz-date:20150830T123600Z\n\nhost;x-amz-date\n" + emptyPayloadHash,
+		},
+		"get-vanilla-utf8-query": {
+			method:        "GET",
+			rawPath:       "/",
+			rawQuery:      "%E1%88%B4=bar",
+			headers:       vanillaHeaders,
+			signedHeaders: []string{"host", "x-amz-date"},
+			expected:      "GET\n/\n%E1%88%B4=bar\nhost:example.amazonaws.com\nx-amz-date:20150830T123600Z\n\nhost;x-amz-date\n" + emptyPayloadHash,
+		},
+		"get-space": {
+			method:        "GET",
+			rawPath:       "/example%20space/",
+			headers:       vanillaHeaders,
+			signedHeaders: []string{"host", "x-amz-date"},
+			expected:      "GET\n/example%2520space/\n\nhost:example.amazonaws.com\nx-amz-date:20150830T123600Z\n\nhost;x-amz-date\n" + emptyPayloadHash,
+		},
+		"get-space-execute-api": {
+			method:        "GET",
+			rawPath:       "/example%20space/",
+			headers:       vanillaHeaders,
+			signedHeaders: []string{"host", "x-amz-date"},
+			options:       []SigV4Option{WithSigV4Service("execute-api")},
+			expected:      "GET\n/example%2520space/\n\nhost:example.amazonaws.com\nx-amz-date:20150830T123600Z\n\nhost;x-amz-date\n" + emptyPayloadHash,
+		},
+		"get-space-s3": {
+			method:        "GET",
+			rawPath:       "/example%20space/",
+			headers:       vanillaHeaders,
+			signedHeaders: []string{"host", "x-amz-date"},
+			options:       []SigV4Option{WithSigV4Service("s3")},
+			expected:      "GET\n/example%20space/\n\nhost:example.amazonaws.com\nx-amz-date:20150830T123600Z\n\nhost;x-amz-date\n" + emptyPayloadHash,
+		},
+		"get-utf8": {
+			method:        "GET",
+			rawPath:       "/ሴ",
+			headers:       vanillaHeaders,
+			signedHeaders: []string{"host", "x-amz-date"},
+			expected:      "GET\n/%25E1%2588%25B4\n\nhost:example.amazonaws.com\nx-amz-date:20150830T123600Z\n\nhost;x-amz-date\n" + emptyPayloadHash,
+		},
+		"get-utf8-s3": {
+			method:        "GET",
+			rawPath:       "/ሴ",
+			headers:       vanillaHeaders,
+			signedHeaders: []string{"host", "x-amz-date"},
+			options:       []SigV4Option{WithSigV4Service("s3")},
+			expected:      "GET\n/%E1%88%B4\n\nhost:example.amazonaws.com\nx-amz-date:20150830T123600Z\n\nhost;x-amz-date\n" + emptyPayloadHash,
+		},
+		"get-header-value-trim": {
+			method:  "GET",
+			rawPath: "/",
+			headers: map[string]string{
+				"host":       "example.amazonaws.com",
+				"my-header1": " value1",
+				"my-header2": ` "a   b   c"`,
+				"x-amz-date": "20150830T123600Z",
+			},
+			signedHeaders: []string{"host", "my-header1", "my-header2", "x-amz-date"},
+			expected:      "GET\n/\n\nhost:example.amazonaws.com\nmy-header1:value1\nmy-header2:\"a b c\"\nx-amz-date:20150830T123600Z\n\nhost;my-header1;my-header2;x-amz-date\n" + emptyPayloadHash,
+		},
+		"get-header-value-multiline": {
+			method:  "GET",
+			rawPath: "/",
+			headers: map[string]string{
+				"host":       "example.amazonaws.com",
+				"my-header1": "value1,value2,value3",
+				"x-amz-date": "20150830T123600Z",
+			},
+			signedHeaders: []string{"host", "my-header1", "x-amz-date"},
+			expected:      "GET\n/\n\nhost:example.amazonaws.com\nmy-header1:value1,value2,value3\nx-amz-date:20150830T123600Z\n\nhost;my-header1;x-amz-date\n" + emptyPayloadHash,
+		},
+		"post-x-www-form-urlencoded": {
+			method:  "POST",
+			rawPath: "/",
+			headers: map[string]string{
+				"content-type": "application/x-www-form-urlencoded",
+				"host":         "example.amazonaws.com",
+				"x-amz-date":   "20150830T123600Z",
+			},
+			body:          "Param1=value1",
+			signedHeaders: []string{"content-type", "host", "x-amz-date"},
+			expected:      "POST\n/\n\ncontent-type:application/x-www-form-urlencoded\nhost:example.amazonaws.com\nx-amz-date:20150830T123600Z\n\ncontent-type;host;x-amz-date\n9095672bbd1f56dfc5b65f3e153adc8731a4a654192329106275f4c7b24d0b6e",
+		},
+		"post-x-www-form-urlencoded base64 body": {
+			method:  "POST",
+			rawPath: "/",
+			headers: map[string]string{
+				"content-type": "application/x-www-form-urlencoded",
+				"host":         "example.amazonaws.com",
+				"x-amz-date":   "20150830T123600Z",
+			},
+			body:            base64.StdEncoding.EncodeToString([]byte("Param1=value1")),
+			isBase64Encoded: true,
+			signedHeaders:   []string{"content-type", "host", "x-amz-date"},
+			expected:        "POST\n/\n\ncontent-type:application/x-www-form-urlencoded\nhost:example.amazonaws.com\nx-amz-date:20150830T123600Z\n\ncontent-type;host;x-amz-date\n9095672bbd1f56dfc5b65f3e153adc8731a4a654192329106275f4c7b24d0b6e",
+		},
+		"signed content hash": {
+			method:  "PUT",
+			rawPath: "/",
+			headers: map[string]string{
+				"host":                 "example.amazonaws.com",
+				"x-amz-content-sha256": "UNSIGNED-PAYLOAD",
+			},
+			body:          "ignored",
+			signedHeaders: []string{"host", "x-amz-content-sha256"},
+			expected:      "PUT\n/\n\nhost:example.amazonaws.com\nx-amz-content-sha256:UNSIGNED-PAYLOAD\n\nhost;x-amz-content-sha256\nUNSIGNED-PAYLOAD",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			urlRequest := LambdaFunctionURLRequest{
+				RawPath:         tc.rawPath,
+				RawQueryString:  tc.rawQuery,
+				Headers:         tc.headers,
+				Body:            tc.body,
+				IsBase64Encoded: tc.isBase64Encoded,
+			}
+			urlRequest.RequestContext.HTTP.Method = tc.method
+			actual, err := urlRequest.SigV4CanonicalRequest(tc.signedHeaders, tc.options...)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+
+			httpRequest := APIGatewayV2HTTPRequest{
+				RawPath:         tc.rawPath,
+				RawQueryString:  tc.rawQuery,
+				Headers:         tc.headers,
+				Body:            tc.body,
+				IsBase64Encoded: tc.isBase64Encoded,
+			}
+			httpRequest.RequestContext.HTTP.Method = tc.method
+			actual, err = httpRequest.SigV4CanonicalRequest(tc.signedHeaders, tc.options...)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestSigV4CanonicalRequestCookies(t *testing.T) {
+	request := LambdaFunctionURLRequest{
+		RawPath: "/",
+		Cookies: []string{"a=1", "b=2"},
+		Headers: map[string]string{"host": "example.amazonaws.com"},
+	}
+	request.RequestContext.HTTP.Method = "GET"
+	actual, err := request.SigV4CanonicalRequest([]string{"cookie", "host"})
+	require.NoError(t, err)
+	assert.Equal(t, "GET\n/\n\ncookie:a=1; b=2\nhost:example.amazonaws.com\n\ncookie;host\n"+emptyPayloadHash, actual)
+}
+
+func TestSigV4CanonicalRequestErrors(t *testing.T) {
+	request := LambdaFunctionURLRequest{RawPath: "/", Headers: map[string]string{"host": "example.amazonaws.com"}}
+	request.RequestContext.HTTP.Method = "GET"
+
+	_, err := request.SigV4CanonicalRequest([]string{"host", "x-amz-date"})
+	assert.EqualError(t, err, `signed header "x-amz-date" is not present in the request`)
+
+	request.Body = "not base64!"
+	request.IsBase64Encoded = true
+	_, err = request.SigV4CanonicalRequest([]string{"host"})
+	assert.Error(t, err)
+}
@@ -3,6 +3,8 @@
 package events
 
 import (
+	"encoding/json"
+	"strings"
 	"time"
 )
 
@@ -29,6 +31,39 @@ type SNSEntity struct {
 	Message           string                 `json:"Message"`
 	UnsubscribeURL    string                 `json:"UnsubscribeUrl"`
 	Subject           string                 `json:"Subject"`
+
+	// The FIFO fields are only set for messages published to a FIFO topic, see IsFIFO.
+	MessageGroupID         string `json:"MessageGroupId,omitempty"`
+	MessageDeduplicationID string `json:"MessageDeduplicationId,omitempty"`
+	SequenceNumber         string `json:"SequenceNumber,omitempty"`
+}
+
+// IsFIFO reports whether the message was published to a FIFO topic.
+func (e SNSEntity) IsFIFO() bool {
+	return strings.HasSuffix(e.TopicArn, ".fifo")
+}
+
+// SNSMessageAttribute is a message attribute of an SNSEntity. The Type is String, String.Array, Number or Binary,
+// the Value of a String.Array being a JSON array, and the one of a Binary being base64 encoded.
+type SNSMessageAttribute struct {
+	Type  string `json:"Type"`
+	Value string `json:"Value"`
+}
+
+// TypedMessageAttributes returns the MessageAttributes of the entity as SNSMessageAttribute values.
+func (e SNSEntity) TypedMessageAttributes() (map[string]SNSMessageAttribute, error) {
+	if e.MessageAttributes == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(e.MessageAttributes)
+	if err != nil {
+		return nil, err
+	}
+	var attributes map[string]SNSMessageAttribute
+	if err := json.Unmarshal(data, &attributes); err != nil {
+		return nil, err
+	}
+	return attributes, nil
 }
 
 type CloudWatchAlarmSNSPayload struct {
@@ -0,0 +1,76 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"encoding/json"
+)
+
+// SNSDeliveryStatus is the outcome reported in an SNS delivery status log record.
+type SNSDeliveryStatus string
+
+const (
+	SNSDeliveryStatusSuccess SNSDeliveryStatus = "SUCCESS"
+	SNSDeliveryStatusFailure SNSDeliveryStatus = "FAILURE"
+)
+
+// SNSDeliveryStatusRecord is a delivery status log record written by SNS for mobile push and SMS deliveries.
+// SNS writes these records to the CloudWatch Logs groups sns/<region>/<account>/<topic> and
+// sns/<region>/<account>/DirectPublishToPhoneNumber, use ParseSNSDeliveryStatusRecord to decode a log event.
+//
+// See https://docs.aws.amazon.com/sns/latest/dg/sns-topic-attributes.html and https://docs.aws.amazon.com/sns/latest/dg/sms_stats_cloudwatch.html
+type SNSDeliveryStatusRecord struct {
+	Notification SNSDeliveryStatusNotification `json:"notification"`
+	Delivery     SNSDeliveryStatusDelivery     `json:"delivery"`
+	Status       SNSDeliveryStatus             `json:"status"`
+}
+
+// SNSDeliveryStatusNotification identifies the message a delivery status record refers to.
+// Timestamp uses the SNS log format "2006-01-02 15:04:05.000" and is kept as a string.
+type SNSDeliveryStatusNotification struct {
+	MessageID     string `json:"messageId"`
+	MessageMD5Sum string `json:"messageMD5Sum,omitempty"`
+	TopicArn      string `json:"topicArn,omitempty"` //nolint: staticcheck
+	Timestamp     string `json:"timestamp"`
+}
+
+// SNSDeliveryStatusDelivery describes a single delivery attempt.
+// Token, StatusCode, DeliveryID, and Attempts are only set for mobile push deliveries,
+// the phone carrier and pricing fields are only set for SMS deliveries.
+type SNSDeliveryStatusDelivery struct {
+	Destination      string `json:"destination"`
+	ProviderResponse string `json:"providerResponse"`
+	DwellTimeMs      int64  `json:"dwellTimeMs"`
+
+	// mobile push
+	DeliveryID string `json:"deliveryId,omitempty"`
+	Token      string `json:"token,omitempty"`
+	StatusCode int    `json:"statusCode,omitempty"`
+	Attempts   int    `json:"attempts,omitempty"`
+
+	// SMS
+	SMSType                   string  `json:"smsType,omitempty"`
+	PhoneCarrier              string  `json:"phoneCarrier,omitempty"`
+	MCC                       int     `json:"mcc,omitempty"`
+	MNC                       int     `json:"mnc,omitempty"`
+	NumberOfMessageParts      int     `json:"numberOfMessageParts,omitempty"`
+	PriceInUSD                float64 `json:"priceInUSD,omitempty"`
+	DwellTimeMsUntilDeviceAck int64   `json:"dwellTimeMsUntilDeviceAck,omitempty"`
+}
+
+// IsSMS reports whether the record describes an SMS delivery rather than a mobile push delivery.
+func (r SNSDeliveryStatusRecord) IsSMS() bool {
+	return r.Delivery.SMSType != "" || r.Delivery.PhoneCarrier != "" || r.Delivery.NumberOfMessageParts > 0
+}
+
+// Succeeded reports whether SNS considered the delivery successful.
+func (r SNSDeliveryStatusRecord) Succeeded() bool {
+	return r.Status == SNSDeliveryStatusSuccess
+}
+
+// ParseSNSDeliveryStatusRecord decodes the message of a CloudWatch Logs log event written by SNS delivery status logging.
+func ParseSNSDeliveryStatusRecord(event CloudwatchLogsLogEvent) (SNSDeliveryStatusRecord, error) {
+	var record SNSDeliveryStatusRecord
+	err := json.Unmarshal([]byte(event.Message), &record)
+	return record, err
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSNSDeliveryStatusRecordMarshaling(t *testing.T) {
+	for _, tc := range []struct {
+		file      string
+		succeeded bool
+		sms       bool
+	}{
+		{"./testdata/sns-delivery-status-apns-success.json", true, false},
+		{"./testdata/sns-delivery-status-apns-failure.json", false, false},
+		{"./testdata/sns-delivery-status-sms.json", true, true},
+	} {
+		t.Run(tc.file, func(t *testing.T) {
+			inputJSON := test.ReadJSONFromFile(t, tc.file)
+
+			var record SNSDeliveryStatusRecord
+			require.NoError(t, json.Unmarshal(inputJSON, &record))
+			assert.Equal(t, tc.succeeded, record.Succeeded())
+			assert.Equal(t, tc.sms, record.IsSMS())
+
+			outputJSON, err := json.Marshal(record)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(inputJSON), string(outputJSON))
+		})
+	}
+}
+
+func TestParseSNSDeliveryStatusRecord(t *testing.T) {
+	message := test.ReadJSONFromFile(t, "./testdata/sns-delivery-status-apns-failure.json")
+
+	record, err := ParseSNSDeliveryStatusRecord(CloudwatchLogsLogEvent{ID: "1", Timestamp: 1773511661207, Message: string(message)})
+	require.NoError(t, err)
+	assert.Equal(t, SNSDeliveryStatusFailure, record.Status)
+	assert.Equal(t, "2a6f8e3c-1b4d-5f7a-9c2e-4d6f8a0b2c4e", record.Notification.MessageID)
+	assert.Equal(t, 400, record.Delivery.StatusCode)
+	assert.Equal(t, `{"reason":"BadDeviceToken"}`, record.Delivery.ProviderResponse)
+	assert.Equal(t, int64(112), record.Delivery.DwellTimeMs)
+
+	_, err = ParseSNSDeliveryStatusRecord(CloudwatchLogsLogEvent{Message: "not json"})
+	assert.Error(t, err)
+}
+
+func TestSNSDeliveryStatusRecordMarshalingMalformedJson(t *testing.T) {
+	test.TestMalformedJson(t, SNSDeliveryStatusRecord{})
+}
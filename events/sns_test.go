@@ -10,24 +10,34 @@ import (
 )
 
 func TestSnsEventMarshaling(t *testing.T) {
+	test.AssertJSONRoundTrip(t, "./testdata/sns-event.json", &SNSEvent{})
+}
 
-	// 1. read JSON from file
-	inputJSON := test.ReadJSONFromFile(t, "./testdata/sns-event.json")
-
-	// 2. de-serialize into Go object
-	var inputEvent SNSEvent
-	if err := json.Unmarshal(inputJSON, &inputEvent); err != nil {
+func TestSnsEntityFIFO(t *testing.T) {
+	var event SNSEvent
+	if err := json.Unmarshal(test.ReadJSONFromFile(t, "./testdata/sns-event.json"), &event); err != nil {
 		t.Errorf("could not unmarshal event. details: %v", err)
 	}
+	assert.False(t, event.Records[0].SNS.IsFIFO())
 
-	// 3. serialize to JSON
-	outputJSON, err := json.Marshal(inputEvent)
+	entity := SNSEntity{
+		TopicArn:               "arn:aws:sns:us-east-1:123456789012:orders.fifo",
+		MessageGroupID:         "order-1234",
+		MessageDeduplicationID: "7c2b5d0f1e3a4b6c8d9e0f1a2b3c4d5e",
+		SequenceNumber:         "10000000000000012000",
+	}
+	assert.True(t, entity.IsFIFO())
+	outputJSON, err := json.Marshal(entity)
 	if err != nil {
 		t.Errorf("could not marshal event. details: %v", err)
 	}
-
-	// 4. check result
-	assert.JSONEq(t, string(inputJSON), string(outputJSON))
+	var fields map[string]interface{}
+	if err := json.Unmarshal(outputJSON, &fields); err != nil {
+		t.Errorf("could not unmarshal event. details: %v", err)
+	}
+	assert.Equal(t, "order-1234", fields["MessageGroupId"])
+	assert.Equal(t, "7c2b5d0f1e3a4b6c8d9e0f1a2b3c4d5e", fields["MessageDeduplicationId"])
+	assert.Equal(t, "10000000000000012000", fields["SequenceNumber"])
 }
 
 func TestSnsMarshalingMalformedJson(t *testing.T) {
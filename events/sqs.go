@@ -2,6 +2,13 @@
 
 package events
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
 type SQSEvent struct {
 	Records []SQSMessage `json:"Records"`
 }
@@ -19,6 +26,56 @@ type SQSMessage struct {
 	AWSRegion              string                         `json:"awsRegion"`
 }
 
+// IsFIFO reports whether the message was received from a FIFO queue.
+func (m SQSMessage) IsFIFO() bool {
+	return strings.HasSuffix(m.EventSourceARN, ".fifo")
+}
+
+// MessageGroupID returns the message group of a message from a FIFO queue.
+func (m SQSMessage) MessageGroupID() string {
+	return m.Attributes["MessageGroupId"]
+}
+
+// MessageDeduplicationID returns the deduplication ID of a message from a FIFO queue.
+func (m SQSMessage) MessageDeduplicationID() string {
+	return m.Attributes["MessageDeduplicationId"]
+}
+
+// SequenceNumber returns the sequence number of a message from a FIFO queue.
+func (m SQSMessage) SequenceNumber() string {
+	return m.Attributes["SequenceNumber"]
+}
+
+// ErrNotSNSEnvelope is returned by SQSMessage.SNSEntity for a message whose Body is not an SNS notification,
+// such as a message delivered by an SNS subscription with raw message delivery, whose Body is the payload itself.
+var ErrNotSNSEnvelope = errors.New("not an SNS notification")
+
+// SNSEntity decodes the Body of a message delivered to the queue by an SNS subscription without raw message delivery.
+// For a message from a FIFO topic, the FIFO fields that are not part of the body are taken from the attributes
+// of the message, the SequenceNumber of the body being the one assigned by the topic.
+//
+// The returned error wraps ErrNotSNSEnvelope when the Body is not a JSON object with the Type "Notification",
+// so that the Body can be handled as the payload instead.
+func (m SQSMessage) SNSEntity() (SNSEntity, error) {
+	var envelope struct {
+		Type string `json:"Type"`
+	}
+	if err := json.Unmarshal([]byte(m.Body), &envelope); err != nil || envelope.Type != "Notification" {
+		return SNSEntity{}, fmt.Errorf("message %q: %w", m.MessageId, ErrNotSNSEnvelope)
+	}
+	var entity SNSEntity
+	if err := json.Unmarshal([]byte(m.Body), &entity); err != nil {
+		return SNSEntity{}, fmt.Errorf("message %q is not a valid SNS notification: %w", m.MessageId, err)
+	}
+	if entity.MessageGroupID == "" {
+		entity.MessageGroupID = m.MessageGroupID()
+	}
+	if entity.MessageDeduplicationID == "" {
+		entity.MessageDeduplicationID = m.MessageDeduplicationID()
+	}
+	return entity, nil
+}
+
 type SQSMessageAttribute struct {
 	StringValue      *string  `json:"stringValue,omitempty"`
 	BinaryValue      []byte   `json:"binaryValue,omitempty"`
@@ -26,3 +83,54 @@ type SQSMessageAttribute struct {
 	BinaryListValues [][]byte `json:"binaryListValues"`
 	DataType         string   `json:"dataType"`
 }
+
+// NewSQSBatchItemFailures returns a response reporting failedMessageIDs as failed, in order, without duplicates.
+// The BatchItemFailures of the response is never nil, so that no failure is encoded as an empty list.
+func NewSQSBatchItemFailures(failedMessageIDs ...string) SQSEventResponse {
+	response := SQSEventResponse{BatchItemFailures: make([]SQSBatchItemFailure, 0, len(failedMessageIDs))}
+	seen := make(map[string]bool, len(failedMessageIDs))
+	for _, id := range failedMessageIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		response.BatchItemFailures = append(response.BatchItemFailures, SQSBatchItemFailure{ItemIdentifier: id})
+	}
+	return response
+}
+
+// BatchItemFailures is like NewSQSBatchItemFailures, but returns an error if one of failedMessageIDs is not
+// the MessageId of a record of the event. Lambda treats a response with an unknown ID as a failure of the whole batch.
+func (e SQSEvent) BatchItemFailures(failedMessageIDs ...string) (SQSEventResponse, error) {
+	inBatch := make(map[string]bool, len(e.Records))
+	for _, record := range e.Records {
+		inBatch[record.MessageId] = true
+	}
+	for _, id := range failedMessageIDs {
+		if !inBatch[id] {
+			return SQSEventResponse{}, fmt.Errorf("message %q is not in the batch", id)
+		}
+	}
+	return NewSQSBatchItemFailures(failedMessageIDs...), nil
+}
+
+// BatchResponse calls process for each record, in order, and reports the records for which it returns an error
+// as failed.
+//
+// For a FIFO queue, the records after the first failure are not processed and are reported as failed too,
+// so that they are retried after the failed record, in order.
+func (e SQSEvent) BatchResponse(process func(SQSMessage) error) SQSEventResponse {
+	var failed []string
+	for i, record := range e.Records {
+		if err := process(record); err != nil {
+			failed = append(failed, record.MessageId)
+			if record.IsFIFO() {
+				for _, rest := range e.Records[i+1:] {
+					failed = append(failed, rest.MessageId)
+				}
+				break
+			}
+		}
+	}
+	return NewSQSBatchItemFailures(failed...)
+}
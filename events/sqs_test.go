@@ -3,33 +3,168 @@ package events
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/aws/aws-lambda-go/events/test"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSqsEventMarshaling(t *testing.T) {
+	test.AssertJSONRoundTrip(t, "./testdata/sqs-event.json", &SQSEvent{})
+}
+
+func TestSqsEventSNSFIFOMarshaling(t *testing.T) {
+	test.AssertJSONRoundTrip(t, "./testdata/sqs-event-sns-fifo.json", &SQSEvent{})
+}
 
-	// 1. read JSON from file
-	inputJSON := test.ReadJSONFromFile(t, "./testdata/sqs-event.json")
+func TestSqsMessageFIFOAttributes(t *testing.T) {
+	var event SQSEvent
+	require.NoError(t, json.Unmarshal(test.ReadJSONFromFile(t, "./testdata/sqs-event-sns-fifo.json"), &event))
+	message := event.Records[0]
 
-	// 2. de-serialize into Go object
-	var inputEvent SQSEvent
-	if err := json.Unmarshal(inputJSON, &inputEvent); err != nil {
-		t.Errorf("could not unmarshal event. details: %v", err)
-	}
+	assert.True(t, message.IsFIFO())
+	assert.Equal(t, "order-1234", message.MessageGroupID())
+	assert.Equal(t, "7c2b5d0f1e3a4b6c8d9e0f1a2b3c4d5e", message.MessageDeduplicationID())
+	assert.Equal(t, "18881740239417626112", message.SequenceNumber())
+
+	var standard SQSEvent
+	require.NoError(t, json.Unmarshal(test.ReadJSONFromFile(t, "./testdata/sqs-event.json"), &standard))
+	message = standard.Records[0]
+	assert.False(t, message.IsFIFO())
+	assert.Equal(t, "", message.MessageGroupID())
+	assert.Equal(t, "", message.MessageDeduplicationID())
+	assert.Equal(t, "", message.SequenceNumber())
+}
+
+func TestSqsMessageSNSEntity(t *testing.T) {
+	var event SQSEvent
+	require.NoError(t, json.Unmarshal(test.ReadJSONFromFile(t, "./testdata/sqs-event-sns-fifo.json"), &event))
+
+	entity, err := event.Records[0].SNSEntity()
+	require.NoError(t, err)
+	assert.True(t, entity.IsFIFO())
+	assert.Equal(t, "Notification", entity.Type)
+	assert.Equal(t, "4e0b3f1a-7d59-5c1e-9b3a-2f6c8d0e1a22", entity.MessageID)
+	assert.Equal(t, `{"orderId":"1234","status":"SHIPPED"}`, entity.Message)
+	assert.Equal(t, "order-1234", entity.MessageGroupID)
+	assert.Equal(t, "7c2b5d0f1e3a4b6c8d9e0f1a2b3c4d5e", entity.MessageDeduplicationID)
+	// the sequence number assigned by the topic, not by the queue
+	assert.Equal(t, "10000000000000012000", entity.SequenceNumber)
+	assert.Equal(t, "https://sns.us-east-1.amazonaws.com/?Action=Unsubscribe&SubscriptionArn=arn:aws:sns:us-east-1:123456789012:orders.fifo:0a1b2c3d-1234-5678-9abc-def012345678", entity.UnsubscribeURL)
+	assert.Equal(t, map[string]interface{}{"Type": "String", "Value": "OrderShipped"}, entity.MessageAttributes["eventType"])
 
-	// 3. serialize to JSON
-	outputJSON, err := json.Marshal(inputEvent)
-	if err != nil {
-		t.Errorf("could not marshal event. details: %v", err)
+	attributes, err := entity.TypedMessageAttributes()
+	require.NoError(t, err)
+	assert.Equal(t, SNSMessageAttribute{Type: "String", Value: "OrderShipped"}, attributes["eventType"])
+
+	_, err = SQSMessage{MessageId: "MessageID_1", Body: "Message Body"}.SNSEntity()
+	assert.True(t, errors.Is(err, ErrNotSNSEnvelope))
+}
+
+func TestSqsMessageSNSEntityRawDelivery(t *testing.T) {
+	for _, body := range []string{
+		`{"orderId":"1234","status":"SHIPPED"}`,
+		`{"Type":"SubscriptionConfirmation","Message":"confirm"}`,
+		`["Notification"]`,
+		`{"Type": "Notification"`,
+		``,
+	} {
+		_, err := SQSMessage{MessageId: "MessageID_1", Body: body}.SNSEntity()
+		assert.True(t, errors.Is(err, ErrNotSNSEnvelope), "body %q", body)
 	}
 
-	// 4. check result
-	assert.JSONEq(t, string(inputJSON), string(outputJSON))
+	// a notification that cannot be decoded is not mistaken for a raw payload
+	_, err := SQSMessage{MessageId: "MessageID_1", Body: `{"Type":"Notification","Timestamp":"yesterday"}`}.SNSEntity()
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrNotSNSEnvelope))
+}
+
+func TestSNSEntityTypedMessageAttributes(t *testing.T) {
+	attributes, err := SNSEntity{}.TypedMessageAttributes()
+	assert.NoError(t, err)
+	assert.Nil(t, attributes)
+
+	entity := SNSEntity{MessageAttributes: map[string]interface{}{
+		"count":  map[string]interface{}{"Type": "Number", "Value": "3"},
+		"labels": map[string]interface{}{"Type": "String.Array", "Value": `["a","b"]`},
+	}}
+	attributes, err = entity.TypedMessageAttributes()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]SNSMessageAttribute{
+		"count":  {Type: "Number", Value: "3"},
+		"labels": {Type: "String.Array", Value: `["a","b"]`},
+	}, attributes)
+
+	_, err = SNSEntity{MessageAttributes: map[string]interface{}{"count": 3}}.TypedMessageAttributes()
+	assert.Error(t, err)
 }
 
 func TestSqsMarshalingMalformedJson(t *testing.T) {
 	test.TestMalformedJson(t, SQSEvent{})
 }
+
+func TestNewSQSBatchItemFailures(t *testing.T) {
+	response := NewSQSBatchItemFailures()
+	outputJSON, err := json.Marshal(response)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"batchItemFailures":[]}`, string(outputJSON))
+
+	response = NewSQSBatchItemFailures("b", "a", "b", "c", "a")
+	assert.Equal(t, []SQSBatchItemFailure{{ItemIdentifier: "b"}, {ItemIdentifier: "a"}, {ItemIdentifier: "c"}}, response.BatchItemFailures)
+}
+
+func TestSQSEventBatchItemFailures(t *testing.T) {
+	event := SQSEvent{Records: []SQSMessage{{MessageId: "a"}, {MessageId: "b"}}}
+
+	response, err := event.BatchItemFailures("b", "b")
+	require.NoError(t, err)
+	assert.Equal(t, []SQSBatchItemFailure{{ItemIdentifier: "b"}}, response.BatchItemFailures)
+
+	response, err = event.BatchItemFailures()
+	require.NoError(t, err)
+	assert.NotNil(t, response.BatchItemFailures)
+	assert.Empty(t, response.BatchItemFailures)
+
+	_, err = event.BatchItemFailures("a", "unknown")
+	assert.EqualError(t, err, `message "unknown" is not in the batch`)
+}
+
+func TestSQSEventBatchResponse(t *testing.T) {
+	fail := map[string]bool{"b": true, "d": true}
+	process := func(processed *[]string) func(SQSMessage) error {
+		return func(message SQSMessage) error {
+			*processed = append(*processed, message.MessageId)
+			if fail[message.MessageId] {
+				return errors.New("failed")
+			}
+			return nil
+		}
+	}
+	records := func(arn string) []SQSMessage {
+		var records []SQSMessage
+		for _, id := range []string{"a", "b", "c", "d"} {
+			records = append(records, SQSMessage{MessageId: id, EventSourceARN: arn})
+		}
+		return records
+	}
+
+	var processed []string
+	event := SQSEvent{Records: records("arn:aws:sqs:us-east-2:123456789012:my-queue")}
+	response := event.BatchResponse(process(&processed))
+	assert.Equal(t, []string{"a", "b", "c", "d"}, processed)
+	assert.Equal(t, []SQSBatchItemFailure{{ItemIdentifier: "b"}, {ItemIdentifier: "d"}}, response.BatchItemFailures)
+
+	processed = nil
+	event = SQSEvent{Records: records("arn:aws:sqs:us-east-2:123456789012:my-queue.fifo")}
+	response = event.BatchResponse(process(&processed))
+	assert.Equal(t, []string{"a", "b"}, processed)
+	assert.Equal(t, []SQSBatchItemFailure{{ItemIdentifier: "b"}, {ItemIdentifier: "c"}, {ItemIdentifier: "d"}}, response.BatchItemFailures)
+
+	fail = nil
+	response = event.BatchResponse(process(&processed))
+	outputJSON, err := json.Marshal(response)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"batchItemFailures":[]}`, string(outputJSON))
+}
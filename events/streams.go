@@ -29,3 +29,13 @@ type SQSEventResponse struct {
 type SQSBatchItemFailure struct {
 	ItemIdentifier string `json:"itemIdentifier"`
 }
+
+// KafkaEventResponse is the outer structure to report batch item failures for KafkaEvent.
+type KafkaEventResponse struct {
+	BatchItemFailures []KafkaBatchItemFailure `json:"batchItemFailures"`
+}
+
+// KafkaBatchItemFailure is the individual record which failed processing.
+type KafkaBatchItemFailure struct {
+	ItemIdentifier string `json:"itemIdentifier"`
+}
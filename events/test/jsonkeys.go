@@ -0,0 +1,116 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package test
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// AssertJSONKeysCovered asserts that UncoveredJSONKeys finds no key of inputJSON missing from o.
+func AssertJSONKeysCovered(t *testing.T, inputJSON []byte, o interface{}) {
+	t.Helper()
+	missing, err := UncoveredJSONKeys(inputJSON, o)
+	if err != nil {
+		t.Errorf("could not unmarshal event. details: %v", err)
+	}
+	for _, key := range missing {
+		t.Errorf("%s: no field of %T is tagged with the key", key, o)
+	}
+}
+
+// UncoveredJSONKeys returns the paths of the keys of the objects in inputJSON that are not the exact json tag, or
+// field name, of a field of the struct at the same place in o, which must be a struct or a pointer to one.
+// encoding/json matches keys case-insensitively, and drops the keys matching no field, so a key
+// such as "instance-id" is silently lost by a field tagged "instanceId", and a round trip may not catch it.
+// Values of maps, interfaces, and types implementing json.Unmarshaler or encoding.TextUnmarshaler are not checked.
+func UncoveredJSONKeys(inputJSON []byte, o interface{}) ([]string, error) {
+	var input interface{}
+	if err := json.Unmarshal(inputJSON, &input); err != nil {
+		return nil, err
+	}
+	var missing []string
+	collectUncoveredJSONKeys(input, reflect.TypeOf(o), "", &missing)
+	sort.Strings(missing)
+	return missing, nil
+}
+
+func collectUncoveredJSONKeys(input interface{}, typ reflect.Type, path string, missing *[]string) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Implements(jsonUnmarshalerType) || reflect.PtrTo(typ).Implements(jsonUnmarshalerType) ||
+		typ.Implements(textUnmarshalerType) || reflect.PtrTo(typ).Implements(textUnmarshalerType) {
+		return
+	}
+	switch value := input.(type) {
+	case map[string]interface{}:
+		switch typ.Kind() {
+		case reflect.Struct:
+			fields := jsonFieldTypes(typ)
+			for key, v := range value {
+				fieldType, ok := fields[key]
+				if !ok {
+					*missing = append(*missing, path+"."+key)
+					continue
+				}
+				collectUncoveredJSONKeys(v, fieldType, path+"."+key, missing)
+			}
+		case reflect.Map:
+			for key, v := range value {
+				collectUncoveredJSONKeys(v, typ.Elem(), path+"."+key, missing)
+			}
+		}
+	case []interface{}:
+		if typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array {
+			for _, v := range value {
+				collectUncoveredJSONKeys(v, typ.Elem(), path+"[]", missing)
+			}
+		}
+	}
+}
+
+// jsonFieldTypes returns the types of the fields of the struct typ, by their json key, including the fields of
+// embedded structs.
+func jsonFieldTypes(typ reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for key, fieldType := range jsonFieldTypes(embedded) {
+					if _, ok := fields[key]; !ok {
+						fields[key] = fieldType
+					}
+				}
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
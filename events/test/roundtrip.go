@@ -0,0 +1,130 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package test
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// AssertJSONRoundTrip reads file into o, which must be a pointer, marshals o back to JSON, and asserts it is
+// semantically equal to the file. The keys of the file the output is missing, which o silently dropped, are reported
+// by their path, such as ".Records[].eventVersion", before the whole documents are compared. The paths of
+// allowDropped are the keys o is expected to drop, and are left out of the comparison.
+func AssertJSONRoundTrip(t *testing.T, file string, o interface{}, allowDropped ...string) {
+	t.Helper()
+	AssertJSONBytesRoundTrip(t, ReadJSONFromFile(t, file), o, allowDropped...)
+}
+
+// AssertJSONBytesRoundTrip is AssertJSONRoundTrip for a JSON document given as bytes.
+func AssertJSONBytesRoundTrip(t *testing.T, inputJSON []byte, o interface{}, allowDropped ...string) {
+	t.Helper()
+	if err := json.Unmarshal(inputJSON, o); err != nil {
+		t.Errorf("could not unmarshal event. details: %v", err)
+		return
+	}
+	outputJSON, err := json.Marshal(o)
+	if err != nil {
+		t.Errorf("could not marshal event. details: %v", err)
+		return
+	}
+
+	dropped, err := DroppedJSONKeys(inputJSON, outputJSON)
+	if err != nil {
+		t.Errorf("could not compare the keys of the event. details: %v", err)
+		return
+	}
+	allowed := make(map[string]bool, len(allowDropped))
+	for _, path := range allowDropped {
+		allowed[path] = true
+	}
+	for _, path := range dropped {
+		if !allowed[path] {
+			t.Errorf("%s: the key was dropped by %T", path, o)
+		}
+	}
+
+	var expected interface{}
+	if err := json.Unmarshal(inputJSON, &expected); err != nil {
+		t.Errorf("could not unmarshal event. details: %v", err)
+		return
+	}
+	expected = removeJSONKeys(expected, "", allowed)
+	expectedJSON, err := json.Marshal(expected)
+	if err != nil {
+		t.Errorf("could not marshal event. details: %v", err)
+		return
+	}
+	assert.JSONEq(t, string(expectedJSON), string(outputJSON))
+}
+
+// DroppedJSONKeys returns the paths of the keys of the objects in inputJSON that are missing from the object at the
+// same place in outputJSON. The keys under a dropped key are not returned, and the elements of arrays are compared
+// by their index, with the path of all of them ending in "[]".
+func DroppedJSONKeys(inputJSON, outputJSON []byte) ([]string, error) {
+	var input, output interface{}
+	if err := json.Unmarshal(inputJSON, &input); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(outputJSON, &output); err != nil {
+		return nil, err
+	}
+	dropped := make(map[string]bool)
+	collectDroppedJSONKeys(input, output, "", dropped)
+	paths := make([]string, 0, len(dropped))
+	for path := range dropped {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func collectDroppedJSONKeys(input, output interface{}, path string, dropped map[string]bool) {
+	switch in := input.(type) {
+	case map[string]interface{}:
+		out, _ := output.(map[string]interface{})
+		for key, v := range in {
+			outValue, ok := out[key]
+			if !ok {
+				dropped[path+"."+key] = true
+				continue
+			}
+			collectDroppedJSONKeys(v, outValue, path+"."+key, dropped)
+		}
+	case []interface{}:
+		out, _ := output.([]interface{})
+		for i, v := range in {
+			var outValue interface{}
+			if i < len(out) {
+				outValue = out[i]
+			}
+			collectDroppedJSONKeys(v, outValue, path+"[]", dropped)
+		}
+	}
+}
+
+// removeJSONKeys removes the keys at the paths of remove from the decoded JSON value v.
+func removeJSONKeys(v interface{}, path string, remove map[string]bool) interface{} {
+	if len(remove) == 0 {
+		return v
+	}
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			childPath := path + "." + key
+			if remove[childPath] {
+				delete(value, key)
+				continue
+			}
+			value[key] = removeJSONKeys(child, childPath, remove)
+		}
+	case []interface{}:
+		for i, child := range value {
+			value[i] = removeJSONKeys(child, path+"[]", remove)
+		}
+	}
+	return v
+}
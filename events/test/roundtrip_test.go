@@ -0,0 +1,79 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripItem struct {
+	Name string `json:"name"`
+}
+
+type roundTripEvent struct {
+	ID      string                   `json:"id"`
+	Items   []roundTripItem          `json:"items"`
+	Nested  struct{ Count int }      `json:"nested"`
+	Grid    [][]roundTripItem        `json:"grid"`
+	Details map[string]roundTripItem `json:"details"`
+}
+
+func TestDroppedJSONKeys(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		input    string
+		output   string
+		expected []string
+	}{
+		{"same", `{"a": 1, "b": {"c": [1, 2]}}`, `{"b": {"c": [1, 2]}, "a": 1}`, []string{}},
+		{"added keys are not dropped", `{"a": 1}`, `{"a": 1, "b": 2}`, []string{}},
+		{"top level", `{"a": 1, "b": 2}`, `{"a": 1}`, []string{".b"}},
+		{"nested object", `{"a": {"b": 1, "c": {"d": 2}}}`, `{"a": {"b": 1, "c": {}}}`, []string{".a.c.d"}},
+		{"keys under a dropped key", `{"a": {"b": {"c": 1}}}`, `{}`, []string{".a"}},
+		{"array elements", `{"a": [{"b": 1, "c": 2}, {"b": 3, "c": 4}]}`, `{"a": [{"b": 1}, {"b": 3}]}`, []string{".a[].c"}},
+		{"missing array elements", `{"a": [{"b": 1}, {"b": 2}]}`, `{"a": [{"b": 1}]}`, []string{".a[].b"}},
+		{"nested arrays", `[[{"a": 1, "b": 2}]]`, `[[{"a": 1}]]`, []string{"[][].b"}},
+		{"object replaced by a value", `{"a": {"b": 1}}`, `{"a": "b"}`, []string{".a.b"}},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dropped, err := DroppedJSONKeys([]byte(tc.input), []byte(tc.output))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, dropped)
+		})
+	}
+
+	_, err := DroppedJSONKeys([]byte(`{"a": `), []byte(`{}`))
+	assert.Error(t, err)
+}
+
+func TestAssertJSONBytesRoundTrip(t *testing.T) {
+	inputJSON := []byte(`{
+		"id": "1",
+		"items": [{"name": "a"}, {"name": "b"}],
+		"nested": {"Count": 2},
+		"grid": [[{"name": "c"}]],
+		"details": {"x": {"name": "d"}}
+	}`)
+	var event roundTripEvent
+	AssertJSONBytesRoundTrip(t, inputJSON, &event)
+	assert.Equal(t, "b", event.Items[1].Name)
+	assert.Equal(t, "c", event.Grid[0][0].Name)
+
+	// the keys the struct has no field for are allowed to be dropped
+	inputJSON = []byte(`{
+		"id": "1",
+		"version": "0",
+		"items": [{"name": "a", "size": 1}],
+		"nested": {"Count": 2},
+		"grid": [[{"name": "c", "color": "red"}]],
+		"details": {"x": {"name": "d"}}
+	}`)
+	dropped, err := DroppedJSONKeys(inputJSON, []byte(`{"id": "1", "items": [{"name": "a"}], "nested": {"Count": 2}, "grid": [[{"name": "c"}]], "details": {"x": {"name": "d"}}}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{".grid[][].color", ".items[].size", ".version"}, dropped)
+	AssertJSONBytesRoundTrip(t, inputJSON, &roundTripEvent{}, dropped...)
+}
@@ -1,5 +1,7 @@
 package events
 
+import "encoding/json"
+
 // Window is the object that captures the time window for the records in the event when using the tumbling windows feature
 // Kinesis: https://docs.aws.amazon.com/lambda/latest/dg/with-kinesis.html#services-kinesis-windows
 // DDB: https://docs.aws.amazon.com/lambda/latest/dg/with-ddb.html#services-ddb-windows
@@ -16,7 +18,7 @@ type TimeWindowProperties struct {
 	Window Window `json:"window"`
 
 	// State being built up to this invoke in the time window.
-	State map[string]string `json:"state"`
+	State TimeWindowState `json:"state"`
 
 	// Shard id of the records
 	ShardID string `json:"shardId"`
@@ -38,5 +40,29 @@ type TimeWindowProperties struct {
 // DDB: https://docs.aws.amazon.com/lambda/latest/dg/with-ddb.html#services-ddb-windows
 type TimeWindowEventResponseProperties struct {
 	// State being built up to this invoke in the time window.
-	State map[string]string `json:"state"`
+	State TimeWindowState `json:"state"`
+}
+
+// TimeWindowState is the state passed from an invoke to the next one of the same time window.
+// A nil state marshals to an empty object, as Lambda expects an object in the response.
+type TimeWindowState map[string]string
+
+// MarshalJSON writes a nil state as {}.
+func (s TimeWindowState) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(map[string]string(s))
+}
+
+// TimeWindowEventResponse is the response to either a KinesisTimeWindowEvent or a DynamoDBTimeWindowEvent, passing
+// the state on to the next invoke of the window and reporting the records that failed processing.
+type TimeWindowEventResponse struct {
+	TimeWindowEventResponseProperties
+	BatchItemFailures []TimeWindowBatchItemFailure `json:"batchItemFailures"`
+}
+
+// TimeWindowBatchItemFailure is the individual record which failed processing.
+type TimeWindowBatchItemFailure struct {
+	ItemIdentifier string `json:"itemIdentifier"`
 }
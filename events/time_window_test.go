@@ -0,0 +1,75 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKinesisTimeWindowEventFinalInvoke(t *testing.T) {
+	var event KinesisTimeWindowEvent
+	test.AssertJSONRoundTrip(t, "./testdata/kinesis-time-window-event-final.json", &event)
+
+	assert.True(t, event.IsFinalInvokeForWindow)
+	assert.False(t, event.IsWindowTerminatedEarly)
+	assert.Equal(t, TimeWindowState{}, event.State)
+	assert.Empty(t, event.Records)
+	assert.Equal(t, time.Date(2020, 12, 9, 7, 6, 0, 0, time.UTC), event.Window.Start.UTC())
+	assert.Equal(t, time.Date(2020, 12, 9, 7, 8, 0, 0, time.UTC), event.Window.End.UTC())
+}
+
+func TestDynamoDBTimeWindowEventTerminatedEarly(t *testing.T) {
+	var event DynamoDBTimeWindowEvent
+	test.AssertJSONRoundTrip(t, "./testdata/dynamodb-time-window-event-terminated-early.json", &event)
+
+	assert.True(t, event.IsWindowTerminatedEarly)
+	assert.Equal(t, TimeWindowState{"count": "17"}, event.State)
+	assert.Equal(t, "shard123456789", event.ShardID)
+	assert.Equal(t, 5*time.Minute, event.Window.End.Sub(event.Window.Start.Time))
+}
+
+func TestTimeWindowEventResponseMarshaling(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		response interface{}
+		expected string
+	}{
+		{"nil state",
+			TimeWindowEventResponse{},
+			`{"state": {}, "batchItemFailures": null}`},
+		{"state and failures",
+			TimeWindowEventResponse{
+				TimeWindowEventResponseProperties: TimeWindowEventResponseProperties{State: TimeWindowState{"count": "3"}},
+				BatchItemFailures:                 []TimeWindowBatchItemFailure{{ItemIdentifier: "49590338271490256608559692538361571095921575989136588898"}},
+			},
+			`{"state": {"count": "3"}, "batchItemFailures": [{"itemIdentifier": "49590338271490256608559692538361571095921575989136588898"}]}`},
+		{"kinesis response with nil state",
+			KinesisTimeWindowEventResponse{BatchItemFailures: []KinesisBatchItemFailure{}},
+			`{"state": {}, "batchItemFailures": []}`},
+		{"dynamodb response with nil state",
+			DynamoDBTimeWindowEventResponse{BatchItemFailures: []DynamoDBBatchItemFailure{}},
+			`{"state": {}, "batchItemFailures": []}`},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			outputJSON, err := json.Marshal(tc.response)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expected, string(outputJSON))
+		})
+	}
+
+	// the state returned by a function is the state of the next invoke
+	var response TimeWindowEventResponse
+	require.NoError(t, json.Unmarshal([]byte(`{"state": {"count": "3"}, "batchItemFailures": []}`), &response))
+	stateJSON, err := json.Marshal(response.State)
+	require.NoError(t, err)
+	var event KinesisTimeWindowEvent
+	require.NoError(t, json.Unmarshal([]byte(`{"Records": [], "state": `+string(stateJSON)+`}`), &event))
+	assert.Equal(t, response.State, event.State)
+}
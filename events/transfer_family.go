@@ -0,0 +1,116 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// TransferFamilyProtocol is the protocol a Transfer Family user is connecting with.
+type TransferFamilyProtocol string
+
+const (
+	TransferFamilyProtocolSFTP TransferFamilyProtocol = "SFTP"
+	TransferFamilyProtocolFTP  TransferFamilyProtocol = "FTP"
+	TransferFamilyProtocolFTPS TransferFamilyProtocol = "FTPS"
+)
+
+// TransferFamilyIdentityProviderRequest is the request sent to a Transfer Family custom identity provider.
+// Password is empty when the user authenticates with an SSH key, in which case the response lists the keys allowed.
+// See https://docs.aws.amazon.com/transfer/latest/userguide/custom-lambda-idp.html
+type TransferFamilyIdentityProviderRequest struct {
+	Username string                 `json:"username"`
+	Password string                 `json:"password,omitempty"`
+	Protocol TransferFamilyProtocol `json:"protocol"`
+	ServerID string                 `json:"serverId"`
+	SourceIP string                 `json:"sourceIp"`
+}
+
+// IsPasswordAuth reports whether the user is authenticating with a password rather than an SSH key.
+func (r TransferFamilyIdentityProviderRequest) IsPasswordAuth() bool {
+	return r.Password != ""
+}
+
+// TransferFamilyHomeDirectoryType is how the home directory of a Transfer Family user is presented.
+type TransferFamilyHomeDirectoryType string
+
+const (
+	TransferFamilyHomeDirectoryTypePath    TransferFamilyHomeDirectoryType = "PATH"
+	TransferFamilyHomeDirectoryTypeLogical TransferFamilyHomeDirectoryType = "LOGICAL"
+)
+
+// TransferFamilyHomeDirectoryMapping maps a path visible to the user to an S3 or EFS location.
+type TransferFamilyHomeDirectoryMapping struct {
+	Entry  string `json:"Entry"`
+	Target string `json:"Target"`
+	Type   string `json:"Type,omitempty"` // FILE or DIRECTORY
+}
+
+// TransferFamilyPosixProfile is the POSIX identity used to access EFS file systems.
+type TransferFamilyPosixProfile struct {
+	UID           int64   `json:"Uid"`
+	GID           int64   `json:"Gid"`
+	SecondaryGIDs []int64 `json:"SecondaryGids,omitempty"`
+}
+
+// TransferFamilyIdentityProviderResponse is the response of a Transfer Family custom identity provider.
+// An empty response denies access to the user.
+// HomeDirectoryDetails holds the mappings encoded as a JSON string, use HomeDirectoryMappings and
+// SetHomeDirectoryMappings rather than setting it directly.
+// See https://docs.aws.amazon.com/transfer/latest/userguide/custom-lambda-idp.html
+type TransferFamilyIdentityProviderResponse struct {
+	Role                 string                          `json:"Role,omitempty"`
+	Policy               string                          `json:"Policy,omitempty"`
+	HomeDirectory        string                          `json:"HomeDirectory,omitempty"`
+	HomeDirectoryType    TransferFamilyHomeDirectoryType `json:"HomeDirectoryType,omitempty"`
+	HomeDirectoryDetails string                          `json:"HomeDirectoryDetails,omitempty"`
+	PosixProfile         *TransferFamilyPosixProfile     `json:"PosixProfile,omitempty"`
+	PublicKeys           []string                        `json:"PublicKeys,omitempty"`
+}
+
+// HomeDirectoryMappings decodes HomeDirectoryDetails. It returns nil when no mappings are set.
+func (r TransferFamilyIdentityProviderResponse) HomeDirectoryMappings() ([]TransferFamilyHomeDirectoryMapping, error) {
+	if r.HomeDirectoryDetails == "" {
+		return nil, nil
+	}
+	var mappings []TransferFamilyHomeDirectoryMapping
+	if err := json.Unmarshal([]byte(r.HomeDirectoryDetails), &mappings); err != nil {
+		return nil, fmt.Errorf("decoding HomeDirectoryDetails: %w", err)
+	}
+	return mappings, nil
+}
+
+// SetHomeDirectoryMappings encodes mappings into HomeDirectoryDetails and sets HomeDirectoryType to LOGICAL.
+func (r *TransferFamilyIdentityProviderResponse) SetHomeDirectoryMappings(mappings []TransferFamilyHomeDirectoryMapping) error {
+	details, err := json.Marshal(mappings)
+	if err != nil {
+		return err
+	}
+	r.HomeDirectoryType = TransferFamilyHomeDirectoryTypeLogical
+	r.HomeDirectoryDetails = string(details)
+	return nil
+}
+
+// Validate checks the response for mistakes Transfer Family would reject the login for,
+// a LOGICAL home directory without mappings or with HomeDirectoryDetails that are not valid JSON.
+func (r TransferFamilyIdentityProviderResponse) Validate() error {
+	mappings, err := r.HomeDirectoryMappings()
+	if err != nil {
+		return err
+	}
+	switch r.HomeDirectoryType {
+	case "", TransferFamilyHomeDirectoryTypePath:
+		if len(mappings) > 0 {
+			return errors.New("HomeDirectoryDetails requires HomeDirectoryType LOGICAL")
+		}
+	case TransferFamilyHomeDirectoryTypeLogical:
+		if len(mappings) == 0 {
+			return errors.New("HomeDirectoryType LOGICAL requires HomeDirectoryDetails")
+		}
+	default:
+		return fmt.Errorf("unknown HomeDirectoryType %q", r.HomeDirectoryType)
+	}
+	return nil
+}
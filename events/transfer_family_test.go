@@ -0,0 +1,130 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferFamilyIdentityProviderRequestMarshaling(t *testing.T) {
+	for sampleFile, passwordAuth := range map[string]bool{
+		"transfer-family-identity-provider-password-request.json": true,
+		"transfer-family-identity-provider-key-request.json":      false,
+	} {
+		t.Run(sampleFile, func(t *testing.T) {
+			inputJSON := test.ReadJSONFromFile(t, "./testdata/"+sampleFile)
+
+			var inputEvent TransferFamilyIdentityProviderRequest
+			require.NoError(t, json.Unmarshal(inputJSON, &inputEvent))
+			assert.Equal(t, passwordAuth, inputEvent.IsPasswordAuth())
+
+			outputJSON, err := json.Marshal(inputEvent)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(inputJSON), string(outputJSON))
+		})
+	}
+}
+
+func TestTransferFamilyIdentityProviderResponseMarshaling(t *testing.T) {
+	for _, sampleFile := range []string{
+		"transfer-family-identity-provider-password-response.json",
+		"transfer-family-identity-provider-key-response.json",
+	} {
+		t.Run(sampleFile, func(t *testing.T) {
+			inputJSON := test.ReadJSONFromFile(t, "./testdata/"+sampleFile)
+
+			var inputEvent TransferFamilyIdentityProviderResponse
+			require.NoError(t, json.Unmarshal(inputJSON, &inputEvent))
+			assert.NoError(t, inputEvent.Validate())
+
+			outputJSON, err := json.Marshal(inputEvent)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(inputJSON), string(outputJSON))
+		})
+	}
+}
+
+func TestTransferFamilyIdentityProviderRequestMarshalingMalformedJson(t *testing.T) {
+	test.TestMalformedJson(t, TransferFamilyIdentityProviderRequest{})
+}
+
+func TestTransferFamilyIdentityProviderResponseMarshalingMalformedJson(t *testing.T) {
+	test.TestMalformedJson(t, TransferFamilyIdentityProviderResponse{})
+}
+
+func TestTransferFamilyHomeDirectoryMappings(t *testing.T) {
+	inputJSON := test.ReadJSONFromFile(t, "./testdata/transfer-family-identity-provider-password-response.json")
+	var response TransferFamilyIdentityProviderResponse
+	require.NoError(t, json.Unmarshal(inputJSON, &response))
+
+	mappings, err := response.HomeDirectoryMappings()
+	require.NoError(t, err)
+	assert.Equal(t, []TransferFamilyHomeDirectoryMapping{
+		{Entry: "/", Target: "/example-bucket/alice"},
+		{Entry: "/shared", Target: "/example-bucket/shared", Type: "DIRECTORY"},
+	}, mappings)
+
+	// the mappings are sent as a string holding JSON, not as a nested array
+	var roundTrip TransferFamilyIdentityProviderResponse
+	require.NoError(t, roundTrip.SetHomeDirectoryMappings(mappings))
+	assert.Equal(t, TransferFamilyHomeDirectoryTypeLogical, roundTrip.HomeDirectoryType)
+	assert.Equal(t, response.HomeDirectoryDetails, roundTrip.HomeDirectoryDetails)
+	outputJSON, err := json.Marshal(roundTrip)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"HomeDirectoryType":"LOGICAL","HomeDirectoryDetails":"[{\"Entry\":\"/\",\"Target\":\"/example-bucket/alice\"},{\"Entry\":\"/shared\",\"Target\":\"/example-bucket/shared\",\"Type\":\"DIRECTORY\"}]"}`, string(outputJSON))
+
+	mappings, err = TransferFamilyIdentityProviderResponse{}.HomeDirectoryMappings()
+	assert.NoError(t, err)
+	assert.Nil(t, mappings)
+
+	_, err = TransferFamilyIdentityProviderResponse{HomeDirectoryDetails: `[{"Entry":`}.HomeDirectoryMappings()
+	assert.Error(t, err)
+}
+
+func TestTransferFamilyIdentityProviderResponseValidate(t *testing.T) {
+	for name, test := range map[string]struct {
+		response    TransferFamilyIdentityProviderResponse
+		expectedErr string
+	}{
+		"empty denies access": {TransferFamilyIdentityProviderResponse{}, ""},
+		"path": {TransferFamilyIdentityProviderResponse{
+			HomeDirectoryType: TransferFamilyHomeDirectoryTypePath,
+			HomeDirectory:     "/example-bucket/alice",
+		}, ""},
+		"logical": {TransferFamilyIdentityProviderResponse{
+			HomeDirectoryType:    TransferFamilyHomeDirectoryTypeLogical,
+			HomeDirectoryDetails: `[{"Entry":"/","Target":"/example-bucket/alice"}]`,
+		}, ""},
+		"logical without mappings": {TransferFamilyIdentityProviderResponse{
+			HomeDirectoryType: TransferFamilyHomeDirectoryTypeLogical,
+		}, "HomeDirectoryType LOGICAL requires HomeDirectoryDetails"},
+		"logical with empty mappings": {TransferFamilyIdentityProviderResponse{
+			HomeDirectoryType:    TransferFamilyHomeDirectoryTypeLogical,
+			HomeDirectoryDetails: `[]`,
+		}, "HomeDirectoryType LOGICAL requires HomeDirectoryDetails"},
+		"mappings without logical": {TransferFamilyIdentityProviderResponse{
+			HomeDirectoryDetails: `[{"Entry":"/","Target":"/example-bucket/alice"}]`,
+		}, "HomeDirectoryDetails requires HomeDirectoryType LOGICAL"},
+		"unknown type": {TransferFamilyIdentityProviderResponse{
+			HomeDirectoryType: "SYMLINK",
+		}, `unknown HomeDirectoryType "SYMLINK"`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := test.response.Validate()
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedErr)
+			}
+		})
+	}
+
+	err := TransferFamilyIdentityProviderResponse{
+		HomeDirectoryType:    TransferFamilyHomeDirectoryTypeLogical,
+		HomeDirectoryDetails: `{"Entry":"/"}`,
+	}.Validate()
+	assert.Error(t, err)
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import "strings"
+
+// UserIdentity is a normalized view of the userIdentity block carried by S3 event records and DynamoDB stream records.
+// PrincipalID may take any of the following forms:
+//
+//	AWS:AIDAJDPLRKLG7UEXAMPLE                          an IAM user, by unique ID
+//	AWS:AROAJDPLRKLG7UEXAMPLE:my-session               an assumed role, by role unique ID and session name
+//	AWS:123456789012                                   an AWS account
+//	arn:aws:sts::123456789012:assumed-role/Role/name   an ARN
+//	dynamodb.amazonaws.com                             an AWS service, for example DynamoDB Time to Live deletes
+//	anonymous                                          an unauthenticated request
+type UserIdentity struct {
+	Type        string
+	PrincipalID string
+}
+
+// IsService reports whether the identity is an AWS service principal.
+func (u UserIdentity) IsService() bool {
+	return u.Type == "Service" || strings.HasSuffix(u.PrincipalID, ".amazonaws.com")
+}
+
+// IsAnonymous reports whether the identity is an unauthenticated principal.
+func (u UserIdentity) IsAnonymous() bool {
+	switch u.PrincipalID {
+	case "anonymous", "ANONYMOUS_PRINCIPAL", "*":
+		return true
+	}
+	return false
+}
+
+// IsAssumedRole reports whether the identity is a role session.
+func (u UserIdentity) IsAssumedRole() bool {
+	if arn := u.arn(); arn != nil {
+		return strings.HasPrefix(arn[5], "assumed-role/")
+	}
+	id := strings.TrimPrefix(u.PrincipalID, "AWS:")
+	return strings.HasPrefix(id, "AROA") && strings.Contains(id, ":")
+}
+
+// RoleSessionName returns the session name of an assumed role identity, or an empty string for other identities.
+func (u UserIdentity) RoleSessionName() string {
+	if !u.IsAssumedRole() {
+		return ""
+	}
+	if arn := u.arn(); arn != nil {
+		parts := strings.SplitN(arn[5], "/", 3)
+		if len(parts) < 3 {
+			return ""
+		}
+		return parts[2]
+	}
+	id := strings.TrimPrefix(u.PrincipalID, "AWS:")
+	return id[strings.Index(id, ":")+1:]
+}
+
+// AccountID returns the AWS account ID of the identity, when the principal ID contains one.
+// Principal IDs made of IAM unique IDs do not identify the account, and return an empty string.
+func (u UserIdentity) AccountID() string {
+	if arn := u.arn(); arn != nil {
+		return arn[4]
+	}
+	if id := strings.TrimPrefix(u.PrincipalID, "AWS:"); isAWSAccountID(id) {
+		return id
+	}
+	return ""
+}
+
+// arn returns the six colon separated parts of the principal ID, if it is an ARN.
+func (u UserIdentity) arn() []string {
+	return splitARN(u.PrincipalID)
+}
+
+func isAWSAccountID(s string) bool {
+	if len(s) != 12 {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Identity returns the normalized identity of the principal that caused the event.
+func (r S3EventRecord) Identity() UserIdentity {
+	return UserIdentity{PrincipalID: r.PrincipalID.PrincipalID}
+}
+
+// Identity returns the normalized identity of the principal that caused the change.
+// DynamoDB only includes a userIdentity for items deleted by Time to Live, so a zero value is returned otherwise.
+func (r DynamoDBEventRecord) Identity() UserIdentity {
+	if r.UserIdentity == nil {
+		return UserIdentity{}
+	}
+	return UserIdentity{Type: r.UserIdentity.Type, PrincipalID: r.UserIdentity.PrincipalID}
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+package events
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserIdentity(t *testing.T) {
+	tests := []struct {
+		name            string
+		identity        UserIdentity
+		isService       bool
+		isAnonymous     bool
+		isAssumedRole   bool
+		roleSessionName string
+		accountID       string
+	}{
+		{
+			name:     "iam user",
+			identity: UserIdentity{PrincipalID: "AWS:AIDAJDPLRKLG7UEXAMPLE"},
+		},
+		{
+			name:            "assumed role",
+			identity:        UserIdentity{PrincipalID: "AWS:AROAJDPLRKLG7UEXAMPLE:my-session"},
+			isAssumedRole:   true,
+			roleSessionName: "my-session",
+		},
+		{
+			name:            "assumed role with email session name",
+			identity:        UserIdentity{PrincipalID: "AROAJDPLRKLG7UEXAMPLE:user@example.com"},
+			isAssumedRole:   true,
+			roleSessionName: "user@example.com",
+		},
+		{
+			name:            "assumed role arn",
+			identity:        UserIdentity{PrincipalID: "arn:aws:sts::123456789012:assumed-role/MyRole/my-session"},
+			isAssumedRole:   true,
+			roleSessionName: "my-session",
+			accountID:       "123456789012",
+		},
+		{
+			name:      "iam user arn",
+			identity:  UserIdentity{PrincipalID: "arn:aws:iam::123456789012:user/alice"},
+			accountID: "123456789012",
+		},
+		{
+			name:      "account",
+			identity:  UserIdentity{PrincipalID: "AWS:123456789012"},
+			accountID: "123456789012",
+		},
+		{
+			name:      "dynamodb ttl service principal",
+			identity:  UserIdentity{Type: "Service", PrincipalID: "dynamodb.amazonaws.com"},
+			isService: true,
+		},
+		{
+			name:      "s3 service principal",
+			identity:  UserIdentity{PrincipalID: "s3.amazonaws.com"},
+			isService: true,
+		},
+		{
+			name:        "anonymous",
+			identity:    UserIdentity{PrincipalID: "anonymous"},
+			isAnonymous: true,
+		},
+		{
+			name:     "zero value",
+			identity: UserIdentity{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.isService, tt.identity.IsService())
+			assert.Equal(t, tt.isAnonymous, tt.identity.IsAnonymous())
+			assert.Equal(t, tt.isAssumedRole, tt.identity.IsAssumedRole())
+			assert.Equal(t, tt.roleSessionName, tt.identity.RoleSessionName())
+			assert.Equal(t, tt.accountID, tt.identity.AccountID())
+		})
+	}
+}
+
+func TestUserIdentityFromRecords(t *testing.T) {
+	var s3Event S3Event
+	require.NoError(t, json.Unmarshal(test.ReadJSONFromFile(t, "./testdata/s3-event.json"), &s3Event))
+	assert.Equal(t, UserIdentity{PrincipalID: "EXAMPLE"}, s3Event.Records[0].Identity())
+
+	var dynamoDBEvent DynamoDBEvent
+	require.NoError(t, json.Unmarshal(test.ReadJSONFromFile(t, "./testdata/dynamodb-event.json"), &dynamoDBEvent))
+	assert.True(t, dynamoDBEvent.Records[0].Identity().IsService())
+	assert.Equal(t, UserIdentity{}, dynamoDBEvent.Records[1].Identity())
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// VPCLatticeRequestV2 contains data coming from a VPC Lattice service to a Lambda target, when the event structure
+// version of the target group is V2.
+// See https://docs.aws.amazon.com/vpc-lattice/latest/ug/lambda-functions.html
+type VPCLatticeRequestV2 struct {
+	Version               string                     `json:"version"` // Version is expected to be `"2.0"`
+	Path                  string                     `json:"path"`
+	Method                string                     `json:"method"`
+	Headers               map[string][]string        `json:"headers"`
+	QueryStringParameters map[string][]string        `json:"queryStringParameters,omitempty"`
+	Body                  string                     `json:"body"`
+	IsBase64Encoded       bool                       `json:"isBase64Encoded"`
+	RequestContext        VPCLatticeRequestV2Context `json:"requestContext"`
+}
+
+// VPCLatticeRequestV2Context contains the information to identify the service and the caller of the request.
+type VPCLatticeRequestV2Context struct {
+	ServiceNetworkARN string                    `json:"serviceNetworkArn"`
+	ServiceARN        string                    `json:"serviceArn"`
+	TargetGroupARN    string                    `json:"targetGroupArn"`
+	Identity          VPCLatticeRequestIdentity `json:"identity"`
+	Region            string                    `json:"region"`
+	// TimeEpoch is the time of the request in microseconds since the epoch, sent as a string.
+	TimeEpoch string `json:"timeEpoch"`
+}
+
+// The Type values of VPCLatticeRequestIdentity.
+const (
+	VPCLatticeIdentityTypeIAM  = "AWS_IAM"
+	VPCLatticeIdentityTypeNone = "NONE"
+)
+
+// VPCLatticeRequestIdentity describes the caller of a request. Only SourceVPCARN is set for anonymous requests,
+// the other fields are set when the service network or the service uses IAM auth.
+type VPCLatticeRequestIdentity struct {
+	SourceVPCARN   string `json:"sourceVpcArn,omitempty"`
+	Type           string `json:"type,omitempty"`
+	Principal      string `json:"principal,omitempty"`
+	PrincipalOrgID string `json:"principalOrgID,omitempty"`
+	SessionName    string `json:"sessionName,omitempty"`
+	X509IssuerOU   string `json:"x509IssuerOu,omitempty"`
+	X509SanDNS     string `json:"x509SanDns,omitempty"`
+	X509SanNameCN  string `json:"x509SanNameCn,omitempty"`
+	X509SanURI     string `json:"x509SanUri,omitempty"`
+	X509SubjectCN  string `json:"x509SubjectCn,omitempty"`
+}
+
+// Time returns the time of the request from the TimeEpoch of its context.
+func (c VPCLatticeRequestV2Context) Time() (time.Time, error) {
+	micros, err := strconv.ParseInt(c.TimeEpoch, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(0, micros*int64(time.Microsecond)), nil
+}
+
+// Header returns the first value of the header name. Header names are matched case-insensitively.
+func (r VPCLatticeRequestV2) Header(name string) string {
+	for k, vs := range r.Headers {
+		if strings.EqualFold(k, name) && len(vs) > 0 {
+			return vs[0]
+		}
+	}
+	return ""
+}
+
+// DecodedBody returns the body of the request, decoding it when it is base64 encoded.
+func (r VPCLatticeRequestV2) DecodedBody() ([]byte, error) {
+	if r.IsBase64Encoded {
+		return base64.StdEncoding.DecodeString(r.Body)
+	}
+	return []byte(r.Body), nil
+}
+
+// VPCLatticeResponse configures the response to be returned by VPC Lattice for the request.
+type VPCLatticeResponse struct {
+	StatusCode        int               `json:"statusCode"`
+	StatusDescription string            `json:"statusDescription,omitempty"`
+	Headers           map[string]string `json:"headers,omitempty"`
+	Body              string            `json:"body,omitempty"`
+	IsBase64Encoded   bool              `json:"isBase64Encoded"`
+}
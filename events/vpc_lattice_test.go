@@ -0,0 +1,69 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package events
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVPCLatticeRequestV2IAM(t *testing.T) {
+	var request VPCLatticeRequestV2
+	test.AssertJSONRoundTrip(t, "./testdata/vpc-lattice-v2-request-iam.json", &request)
+
+	assert.Equal(t, "2.0", request.Version)
+	assert.Equal(t, []string{"one", "two"}, request.Headers["x-custom"])
+	assert.Equal(t, []string{"items", "customer"}, request.QueryStringParameters["expand"])
+	assert.Equal(t, "application/json", request.Header("Content-Type"))
+	assert.Equal(t, "", request.Header("authorization"))
+
+	identity := request.RequestContext.Identity
+	assert.Equal(t, VPCLatticeIdentityTypeIAM, identity.Type)
+	assert.Equal(t, "arn:aws:sts::123456789012:assumed-role/example-role/057d00f8b51257ba3c853a0f248943cf", identity.Principal)
+	assert.Equal(t, "o-50dc6c495c0c9188", identity.PrincipalOrgID)
+
+	body, err := request.DecodedBody()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"quantity": 2}`, string(body))
+
+	requestTime, err := request.RequestContext.Time()
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 7, 27, 22, 39, 59, 177430000, time.UTC), requestTime.UTC())
+}
+
+func TestVPCLatticeRequestV2Anonymous(t *testing.T) {
+	var request VPCLatticeRequestV2
+	test.AssertJSONRoundTrip(t, "./testdata/vpc-lattice-v2-request-anonymous.json", &request)
+
+	assert.Nil(t, request.QueryStringParameters)
+	assert.Equal(t, VPCLatticeRequestIdentity{SourceVPCARN: "arn:aws:ec2:us-east-1:123456789012:vpc/vpc-0b8276c84697e7339"}, request.RequestContext.Identity)
+
+	body, err := request.DecodedBody()
+	require.NoError(t, err)
+	assert.Empty(t, body)
+
+	// the microseconds of the epoch are kept
+	requestTime, err := request.RequestContext.Time()
+	require.NoError(t, err)
+	assert.Equal(t, 1000, requestTime.Nanosecond())
+
+	_, err = VPCLatticeRequestV2Context{TimeEpoch: "not a time"}.Time()
+	assert.Error(t, err)
+}
+
+func TestVPCLatticeResponseMarshaling(t *testing.T) {
+	test.AssertJSONRoundTrip(t, "./testdata/vpc-lattice-response.json", &VPCLatticeResponse{})
+
+	outputJSON, err := json.Marshal(VPCLatticeResponse{StatusCode: 204})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"statusCode": 204, "isBase64Encoded": false}`, string(outputJSON))
+}
+
+func TestVPCLatticeRequestV2MalformedJson(t *testing.T) {
+	test.TestMalformedJson(t, VPCLatticeRequestV2{})
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"context"
+	"io"
+
+	"github.com/aws/aws-lambda-go/lambda/invokeclient"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+)
+
+type baggageKey struct{}
+
+// WithContextPropagationFromClientContext copies the listed keys of the invocation's ClientContext.Custom map
+// into the handler context, where they can be read with BaggageFromContext.
+// Keys not in the list are ignored, so callers cannot inject arbitrary values.
+//
+// Callers can build a matching ClientContext for their Invoke requests with EncodeBaggageClientContext.
+func WithContextPropagationFromClientContext(keys ...string) Option {
+	return Option(func(h *handlerOptions) {
+		h.baggageKeys = append(h.baggageKeys, keys...)
+	})
+}
+
+// BaggageFromContext returns the ClientContext.Custom values propagated into ctx by
+// WithContextPropagationFromClientContext. The returned map must not be modified.
+func BaggageFromContext(ctx context.Context) (map[string]string, bool) {
+	baggage, ok := ctx.Value(baggageKey{}).(map[string]string)
+	return baggage, ok
+}
+
+// BaggageValue returns a single value propagated into ctx by WithContextPropagationFromClientContext.
+func BaggageValue(ctx context.Context, key string) (string, bool) {
+	baggage, _ := BaggageFromContext(ctx)
+	value, ok := baggage[key]
+	return value, ok
+}
+
+// EncodeBaggageClientContext returns the base64 encoded ClientContext JSON carrying baggage in its Custom map,
+// suitable for the ClientContext parameter of a Lambda Invoke request. It returns an error when the encoded
+// context is longer than the 3583 bytes Invoke accepts. See invokeclient.BuildClientContext.
+func EncodeBaggageClientContext(baggage map[string]string) (string, error) {
+	return invokeclient.BuildClientContext(baggage, lambdacontext.ClientApplication{})
+}
+
+func propagateBaggage(keys []string, f handlerFunc) handlerFunc {
+	return func(ctx context.Context, payload []byte) (io.Reader, error) {
+		if lc, ok := lambdacontext.FromContext(ctx); ok && len(lc.ClientContext.Custom) > 0 {
+			baggage := make(map[string]string, len(keys))
+			for _, key := range keys {
+				if value, ok := lc.ClientContext.Custom[key]; ok {
+					baggage[key] = value
+				}
+			}
+			ctx = context.WithValue(ctx, baggageKey{}, baggage)
+		}
+		return f(ctx, payload)
+	}
+}
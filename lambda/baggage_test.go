@@ -0,0 +1,58 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextPropagationFromClientContext(t *testing.T) {
+	clientContext, err := EncodeBaggageClientContext(map[string]string{
+		"tenant":        "acme",
+		"correlationId": "corr-123",
+		"notAllowed":    "ignored",
+	})
+	require.NoError(t, err)
+	clientContextJSON, err := base64.StdEncoding.DecodeString(clientContext)
+	require.NoError(t, err)
+
+	withBaggage := defaultInvokeMetadata()
+	withBaggage.clientContext = string(clientContextJSON)
+	withoutClientContext := defaultInvokeMetadata()
+	withoutClientContext.clientContext = ""
+
+	ts, record := runtimeAPIServer(`{}`, 2, withBaggage, withoutClientContext)
+	defer ts.Close()
+
+	handler := NewHandlerWithOptions(func(ctx context.Context) (map[string]interface{}, error) {
+		baggage, ok := BaggageFromContext(ctx)
+		tenant, _ := BaggageValue(ctx, "tenant")
+		return map[string]interface{}{"ok": ok, "baggage": baggage, "tenant": tenant}, nil
+	}, WithContextPropagationFromClientContext("tenant", "correlationId", "missing"))
+	endpoint := strings.Split(ts.URL, "://")[1]
+	_ = startRuntimeAPILoop(endpoint, handler)
+
+	require.Len(t, record.responses, 2)
+	assert.JSONEq(t, `{"ok":true,"baggage":{"tenant":"acme","correlationId":"corr-123"},"tenant":"acme"}`, string(record.responses[0]))
+	assert.JSONEq(t, `{"ok":false,"baggage":null,"tenant":""}`, string(record.responses[1]))
+}
+
+func TestEncodeBaggageClientContext(t *testing.T) {
+	clientContext, err := EncodeBaggageClientContext(map[string]string{"tenant": "acme"})
+	require.NoError(t, err)
+	decoded, err := base64.StdEncoding.DecodeString(clientContext)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"client": {"installation_id": "", "app_title": "", "app_version_code": "", "app_package_name": ""},
+		"custom": {"tenant": "acme"}
+	}`, string(decoded))
+
+	_, err = EncodeBaggageClientContext(map[string]string{"blob": strings.Repeat("x", 3000)})
+	assert.EqualError(t, err, "client context is 4152 bytes once encoded, more than the 3583 allowed")
+}
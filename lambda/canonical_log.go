@@ -0,0 +1,105 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambda/handlertrace"
+	"github.com/aws/aws-lambda-go/lambda/messages"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+)
+
+// CanonicalEntry is the single wide log entry summarizing an invocation, see WithCanonicalLog.
+type CanonicalEntry struct {
+	RequestID          string                 `json:"requestId"`
+	InvokedFunctionArn string                 `json:"functionArn,omitempty"`
+	TenantID           string                 `json:"tenantId,omitempty"`
+	TraceID            string                 `json:"traceId,omitempty"`
+	Start              time.Time              `json:"start"`
+	Duration           time.Duration          `json:"-"`
+	DurationMs         float64                `json:"durationMs"`
+	Outcome            string                 `json:"outcome"`
+	ErrorType          string                 `json:"errorType,omitempty"`
+	ErrorMessage       string                 `json:"errorMessage,omitempty"`
+	ResponseBytes      int64                  `json:"responseBytes,omitempty"`
+	ResponseSHA256     string                 `json:"responseSha256,omitempty"`
+	Fields             map[string]interface{} `json:"fields,omitempty"`
+}
+
+const (
+	canonicalOutcomeSuccess = "success"
+	canonicalOutcomeError   = "error"
+	canonicalOutcomePanic   = "panic"
+
+	canonicalOutcomeClientDisconnected = "clientDisconnected"
+)
+
+// WithCanonicalLog emits one CanonicalEntry per invocation, after the response or error has been sent.
+// The entry carries the request metadata, the outcome and duration of the handler, and any fields added during
+// the invocation with lambdacontext.AddCanonicalField, including from handlertrace callbacks.
+//
+// The entry is passed to sink. If sink is nil, the entry is written to the standard logger as JSON.
+func WithCanonicalLog(sink func(ctx context.Context, entry *CanonicalEntry)) Option {
+	return Option(func(h *handlerOptions) {
+		if sink == nil {
+			sink = logCanonicalEntry
+		}
+		h.canonicalLogSink = sink
+	})
+}
+
+func logCanonicalEntry(_ context.Context, entry *CanonicalEntry) {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		log.Printf("failed to marshal canonical log entry: %v", err)
+		return
+	}
+	log.Printf("%s", b)
+}
+
+// startCanonicalLog prepares ctx to collect canonical fields, and returns the function that emits the entry.
+// The function takes the error reported for the invocation, or the response that was posted.
+// When canonical logging is not enabled, ctx is returned unchanged along with a no-op.
+func (h *handlerOptions) startCanonicalLog(ctx context.Context, traceID string) (context.Context, func(*messages.InvokeResponse_Error, handlertrace.ResponsePostedEvent)) {
+	if h.canonicalLogSink == nil {
+		return ctx, func(*messages.InvokeResponse_Error, handlertrace.ResponsePostedEvent) {}
+	}
+	start := time.Now()
+	ctx = lambdacontext.NewCanonicalContext(ctx)
+	return ctx, func(invokeErr *messages.InvokeResponse_Error, posted handlertrace.ResponsePostedEvent) {
+		entry := &CanonicalEntry{
+			TraceID:  traceID,
+			Start:    start,
+			Duration: time.Since(start),
+			Outcome:  canonicalOutcomeSuccess,
+		}
+		entry.DurationMs = float64(entry.Duration) / float64(time.Millisecond)
+		if lc, ok := lambdacontext.FromContext(ctx); ok {
+			entry.RequestID = lc.AwsRequestID
+			entry.InvokedFunctionArn = lc.InvokedFunctionArn
+			entry.TenantID = lc.TenantID
+		}
+		if invokeErr != nil {
+			entry.Outcome = canonicalOutcomeError
+			if invokeErr.ShouldExit {
+				entry.Outcome = canonicalOutcomePanic
+			}
+			entry.ErrorType = invokeErr.Type
+			entry.ErrorMessage = invokeErr.Message
+		} else {
+			if posted.ClientDisconnected {
+				entry.Outcome = canonicalOutcomeClientDisconnected
+			}
+			entry.ResponseBytes = posted.Bytes
+			entry.ResponseSHA256 = posted.SHA256
+		}
+		if fields, _ := lambdacontext.CanonicalFieldsFromContext(ctx); len(fields) > 0 {
+			entry.Fields = fields
+		}
+		h.canonicalLogSink(ctx, entry)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/lambda/handlertrace"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalLog(t *testing.T) {
+	ts, record := runtimeAPIServer(`{"orderId": "order-123"}`, 2)
+	defer ts.Close()
+
+	var lock sync.Mutex
+	var entries []*CanonicalEntry
+	sink := func(ctx context.Context, entry *CanonicalEntry) {
+		lock.Lock()
+		defer lock.Unlock()
+		entries = append(entries, entry)
+	}
+	trace := handlertrace.NewContext(context.Background(), handlertrace.HandlerTrace{
+		RequestEvent: func(ctx context.Context, event interface{}) {
+			lambdacontext.AddCanonicalField(ctx, "traced", true)
+		},
+	})
+
+	n := 0
+	handler := NewHandlerWithOptions(func(ctx context.Context, event struct{ OrderID string }) (string, error) {
+		n++
+		lambdacontext.AddCanonicalField(ctx, "orderId", event.OrderID)
+		if n == 2 {
+			return "", errors.New("payment declined")
+		}
+		return "ok", nil
+	}, WithContext(trace), WithCanonicalLog(sink))
+	endpoint := strings.Split(ts.URL, "://")[1]
+	_ = startRuntimeAPILoop(endpoint, handler)
+
+	require.Equal(t, 2, record.nPosts)
+	require.Len(t, entries, 2)
+
+	success := entries[0]
+	assert.Equal(t, "dummyid", success.RequestID)
+	assert.Equal(t, "dummyarn", success.InvokedFunctionArn)
+	assert.Equal(t, "its-xray-time", success.TraceID)
+	assert.Equal(t, "success", success.Outcome)
+	assert.Empty(t, success.ErrorType)
+	assert.Empty(t, success.ErrorMessage)
+	assert.Equal(t, map[string]interface{}{"orderId": "order-123", "traced": true}, success.Fields)
+	assert.False(t, success.Start.IsZero())
+	assert.True(t, success.Duration > 0)
+	assert.Equal(t, record.responseSHA256s[0], success.ResponseSHA256)
+	assert.Equal(t, int64(len(record.responses[0])), success.ResponseBytes)
+
+	failure := entries[1]
+	assert.Equal(t, "error", failure.Outcome)
+	assert.Equal(t, "errorString", failure.ErrorType)
+	assert.Equal(t, "payment declined", failure.ErrorMessage)
+	assert.Empty(t, failure.ResponseSHA256)
+	assert.Zero(t, failure.ResponseBytes)
+	assert.Equal(t, map[string]interface{}{"orderId": "order-123", "traced": true}, failure.Fields)
+}
+
+func TestCanonicalLogDisabled(t *testing.T) {
+	ts, _ := runtimeAPIServer(`{}`, 1)
+	defer ts.Close()
+
+	var enabled bool
+	handler := NewHandler(func(ctx context.Context) error {
+		_, enabled = lambdacontext.CanonicalFieldsFromContext(ctx)
+		return nil
+	})
+	endpoint := strings.Split(ts.URL, "://")[1]
+	_ = startRuntimeAPILoop(endpoint, handler)
+	assert.False(t, enabled)
+}
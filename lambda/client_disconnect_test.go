@@ -0,0 +1,181 @@
+//go:build go1.20
+// +build go1.20
+
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambda/handlertrace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// disconnectingRuntimeAPIServer serves one invoke, and stops reading the response after readBeforeClose bytes.
+// With status, it answers the POST with status, the way the Runtime API does when the client of a streamed response
+// goes away. Otherwise it resets the connection.
+func disconnectingRuntimeAPIServer(t *testing.T, readBeforeClose int64, status int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			deadline := time.Now().Add(time.Minute).UnixNano() / nsPerMS
+			w.Header().Set(headerAWSRequestID, "dummyid")
+			w.Header().Set(headerDeadlineMS, strconv.FormatInt(deadline, 10))
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{}`))
+		case http.MethodPost:
+			_, err := io.CopyN(io.Discard, r.Body, readBeforeClose)
+			require.NoError(t, err)
+			if status != 0 {
+				w.WriteHeader(status)
+				w.(http.Flusher).Flush()
+				return
+			}
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			_ = conn.Close()
+		}
+	}))
+}
+
+type streamingResponse struct {
+	*io.PipeReader
+	closed chan struct{}
+}
+
+func (r *streamingResponse) Close() error {
+	close(r.closed)
+	return r.PipeReader.Close()
+}
+
+func TestClientDisconnectCancelsContext(t *testing.T) {
+	ts := disconnectingRuntimeAPIServer(t, 1024, statusClientDisconnected)
+	defer ts.Close()
+
+	var posted []handlertrace.ResponsePostedEvent
+	var entries []*CanonicalEntry
+	baseCtx := handlertrace.NewContext(context.Background(), handlertrace.HandlerTrace{
+		ResponsePosted: func(ctx context.Context, event handlertrace.ResponsePostedEvent) {
+			posted = append(posted, event)
+		},
+	})
+
+	causes := make(chan error, 1)
+	response := &streamingResponse{closed: make(chan struct{})}
+	handler := newHandler(func(ctx context.Context) (io.Reader, error) {
+		pr, pw := io.Pipe()
+		response.PipeReader = pr
+		go func() {
+			chunk := []byte(strings.Repeat("x", 256))
+			for {
+				if _, err := pw.Write(chunk); err != nil {
+					break
+				}
+			}
+			<-ctx.Done()
+			causes <- context.Cause(ctx)
+		}()
+		return response, nil
+	}, WithContext(baseCtx), WithCanonicalLog(func(ctx context.Context, entry *CanonicalEntry) {
+		entries = append(entries, entry)
+	}))
+
+	client := newRuntimeAPIClient(strings.Split(ts.URL, "://")[1])
+	invoke, err := client.next(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, handleInvoke(invoke, handler), "a client disconnect should not stop the runtime")
+
+	select {
+	case cause := <-causes:
+		assert.Equal(t, ErrClientDisconnected, cause)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the handler's context was not canceled")
+	}
+	select {
+	case <-response.closed:
+	default:
+		t.Error("the response was not closed")
+	}
+
+	require.Len(t, posted, 1)
+	assert.True(t, posted[0].ClientDisconnected)
+	assert.GreaterOrEqual(t, posted[0].Bytes, int64(1024))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "clientDisconnected", entries[0].Outcome)
+	assert.Equal(t, posted[0].Bytes, entries[0].ResponseBytes)
+}
+
+func TestCompleteResponseDoesNotCancelWithClientDisconnected(t *testing.T) {
+	ts, record := runtimeAPIServer(`{}`, 1)
+	defer ts.Close()
+
+	var cause error
+	var posted []handlertrace.ResponsePostedEvent
+	baseCtx := handlertrace.NewContext(context.Background(), handlertrace.HandlerTrace{
+		ResponsePosted: func(ctx context.Context, event handlertrace.ResponsePostedEvent) {
+			posted = append(posted, event)
+			cause = context.Cause(ctx)
+		},
+	})
+	handler := newHandler(func() (io.Reader, error) {
+		return strings.NewReader("hello"), nil
+	}, WithContext(baseCtx))
+	_ = startRuntimeAPILoop(strings.Split(ts.URL, "://")[1], handler)
+
+	require.Len(t, record.responses, 1)
+	require.Len(t, posted, 1)
+	assert.False(t, posted[0].ClientDisconnected)
+	assert.NotEqual(t, ErrClientDisconnected, cause)
+}
+
+func TestResponseSendFailureStopsTheRuntime(t *testing.T) {
+	ts := disconnectingRuntimeAPIServer(t, 1024, 0)
+	defer ts.Close()
+
+	var posted []handlertrace.ResponsePostedEvent
+	baseCtx := handlertrace.NewContext(context.Background(), handlertrace.HandlerTrace{
+		ResponsePosted: func(ctx context.Context, event handlertrace.ResponsePostedEvent) {
+			posted = append(posted, event)
+		},
+	})
+
+	// the producer stops once its context is canceled, before the response is closed
+	causes := make(chan error, 1)
+	handler := newHandler(func(ctx context.Context) (io.Reader, error) {
+		pr, pw := io.Pipe()
+		go func() {
+			chunk := []byte(strings.Repeat("x", 256))
+			for ctx.Err() == nil {
+				_, _ = pw.Write(chunk)
+			}
+			causes <- context.Cause(ctx)
+			_ = pw.Close()
+		}()
+		return pr, nil
+	}, WithContext(baseCtx))
+
+	client := newRuntimeAPIClient(strings.Split(ts.URL, "://")[1])
+	invoke, err := client.next(context.Background())
+	require.NoError(t, err)
+	err = handleInvoke(invoke, handler)
+	require.Error(t, err, "a failure of the Runtime API should stop the runtime")
+	assert.NotContains(t, err.Error(), ErrClientDisconnected.Error())
+
+	select {
+	case cause := <-causes:
+		assert.Equal(t, errResponseAborted, cause)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the handler's context was not canceled")
+	}
+	assert.False(t, invoke.posted.ClientDisconnected)
+	assert.Empty(t, posted)
+}
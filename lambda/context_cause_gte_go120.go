@@ -0,0 +1,14 @@
+//go:build go1.20
+// +build go1.20
+
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"context"
+)
+
+func withCancelCause(parent context.Context) (context.Context, func(cause error)) {
+	return context.WithCancelCause(parent)
+}
@@ -0,0 +1,16 @@
+//go:build !go1.20
+// +build !go1.20
+
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"context"
+)
+
+// withCancelCause drops the cause, context.Cause is not available before Go 1.20.
+func withCancelCause(parent context.Context) (context.Context, func(cause error)) {
+	ctx, cancel := context.WithCancel(parent)
+	return ctx, func(error) { cancel() }
+}
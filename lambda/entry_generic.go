@@ -16,6 +16,7 @@ type HandlerFunc[TIn, TOut any] interface {
 
 // StartHandlerFunc is the same as StartWithOptions except that it takes a generic input
 // so that the function signature can be validated at compile time.
+// The event is decoded into a TIn, and the response encoded from the TOut, without calling the handler through reflection.
 func StartHandlerFunc[TIn any, TOut any, H HandlerFunc[TIn, TOut]](handler H, options ...Option) {
-	start(newHandler(handler, options...))
+	start(newTypedHandler[TIn, TOut](handler, options...))
 }
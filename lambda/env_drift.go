@@ -0,0 +1,156 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// sensitiveEnvMarkers are substrings that mark an environment variable name as secret-bearing.
+// The values of matching variables are never logged, only a hash of them.
+var sensitiveEnvMarkers = []string{"SECRET", "TOKEN", "PASSWORD", "CREDENTIAL", "KEY"}
+
+func isSensitiveEnv(name string) bool {
+	upper := strings.ToUpper(name)
+	for _, marker := range sensitiveEnvMarkers {
+		if strings.Contains(upper, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// EnvironmentDrift describes a change to a watched environment variable between two invocations.
+// Old and New are nil when the variable is unset, which is distinct from a variable set to an empty value.
+// For secret-bearing variables, Old and New hold a sha256 hash of the value instead of the value itself.
+type EnvironmentDrift struct {
+	Name   string  `json:"name"`
+	Old    *string `json:"old"`
+	New    *string `json:"new"`
+	Hashed bool    `json:"hashed"`
+}
+
+// envSnapshot holds the last observed values of a set of environment variables.
+type envSnapshot struct {
+	mu     sync.Mutex
+	names  []string
+	values map[string]envValue
+}
+
+// envValue is the observed value of an environment variable, and whether it is set at all.
+type envValue struct {
+	value string
+	set   bool
+}
+
+func (v envValue) pointer() *string {
+	if !v.set {
+		return nil
+	}
+	value := v.value
+	return &value
+}
+
+func newEnvSnapshot(names []string) *envSnapshot {
+	s := &envSnapshot{values: make(map[string]envValue, len(names))}
+	for _, name := range names {
+		if _, exists := s.values[name]; exists {
+			continue
+		}
+		s.names = append(s.names, name)
+		s.values[name] = s.read(name)
+	}
+	sort.Strings(s.names)
+	return s
+}
+
+// read returns the current value of the named variable, hashed if the name is secret-bearing.
+func (s *envSnapshot) read(name string) envValue {
+	value, ok := os.LookupEnv(name)
+	if !ok || !isSensitiveEnv(name) {
+		return envValue{value: value, set: ok}
+	}
+	sum := sha256.Sum256([]byte(value))
+	return envValue{value: "sha256:" + hex.EncodeToString(sum[:]), set: true}
+}
+
+// snapshot returns a copy of the last observed values of the variables that are set.
+func (s *envSnapshot) snapshot() map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	values := make(map[string]string, len(s.values))
+	for k, v := range s.values {
+		if v.set {
+			values[k] = v.value
+		}
+	}
+	return values
+}
+
+// detect compares the environment against the snapshot, updates the snapshot, and returns any changes.
+func (s *envSnapshot) detect() []EnvironmentDrift {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var drift []EnvironmentDrift
+	for _, name := range s.names {
+		current := s.read(name)
+		if previous := s.values[name]; previous != current {
+			drift = append(drift, EnvironmentDrift{
+				Name:   name,
+				Old:    previous.pointer(),
+				New:    current.pointer(),
+				Hashed: isSensitiveEnv(name),
+			})
+			s.values[name] = current
+		}
+	}
+	return drift
+}
+
+func (s *envSnapshot) wrap(f handlerFunc) handlerFunc {
+	return func(ctx context.Context, payload []byte) (io.Reader, error) {
+		if drift := s.detect(); len(drift) > 0 {
+			record, _ := json.Marshal(struct {
+				Drift []EnvironmentDrift `json:"environmentDrift"`
+			}{drift})
+			log.Printf("%s", record)
+		}
+		return f(ctx, payload)
+	}
+}
+
+// WithEnvironmentDriftDetection snapshots the named environment variables when the handler is created,
+// and logs a structured record before any invocation that observes one of them changed since the
+// previous invocation. Values of names containing SECRET, TOKEN, PASSWORD, CREDENTIAL, or KEY are
+// reported as hashes rather than in clear text.
+//
+// A variable that becomes unset, or set to an empty value, is reported as a change. The last observed values
+// are returned by EnvironmentSnapshot.
+//
+// This is a diagnostic for libraries that cache environment-derived configuration during init.
+func WithEnvironmentDriftDetection(names ...string) Option {
+	return Option(func(h *handlerOptions) {
+		h.envSnapshot = newEnvSnapshot(names)
+	})
+}
+
+// EnvironmentSnapshot returns the values of the environment variables watched by a handler created by
+// NewHandlerWithOptions with WithEnvironmentDriftDetection, as last observed before an invocation. Secret-bearing
+// values are hashed as in EnvironmentDrift. A variable that is unset has no entry, while a variable set to an empty
+// value has an empty one. ok is false if the handler does not detect environment drift.
+func EnvironmentSnapshot(handler Handler) (values map[string]string, ok bool) {
+	h, isHandlerOptions := handler.(*handlerOptions)
+	if !isHandlerOptions || h.envSnapshot == nil {
+		return nil, false
+	}
+	return h.envSnapshot.snapshot(), true
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+//go:build go1.17
+// +build go1.17
+
+package lambda
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvironmentDriftDetection(t *testing.T) {
+	t.Setenv("AWS_SESSION_TOKEN", "token-1")
+	t.Setenv("DRIFT_TEST_REGION", "us-east-1")
+	t.Setenv("DRIFT_TEST_STABLE", "unchanged")
+	t.Setenv("DRIFT_TEST_EMPTY", "")
+	os.Unsetenv("DRIFT_TEST_UNSET")
+	defer os.Unsetenv("DRIFT_TEST_UNSET")
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	ts, record := runtimeAPIServer(`{}`, 2)
+	defer ts.Close()
+
+	n := 0
+	handler := newHandler(func() error {
+		n++
+		if n == 1 {
+			// simulate the environment changing between the first and second invoke
+			os.Setenv("AWS_SESSION_TOKEN", "token-2")
+			os.Setenv("DRIFT_TEST_REGION", "us-west-2")
+			os.Unsetenv("DRIFT_TEST_EMPTY")
+			os.Setenv("DRIFT_TEST_UNSET", "")
+		}
+		return nil
+	}, WithEnvironmentDriftDetection("AWS_SESSION_TOKEN", "DRIFT_TEST_REGION", "DRIFT_TEST_STABLE", "DRIFT_TEST_EMPTY", "DRIFT_TEST_UNSET"))
+	endpoint := strings.Split(ts.URL, "://")[1]
+	_ = startRuntimeAPILoop(endpoint, handler)
+	assert.Equal(t, 2, record.nPosts)
+
+	var drifts []EnvironmentDrift
+	for _, line := range strings.Split(logs.String(), "\n") {
+		i := strings.Index(line, `{"environmentDrift"`)
+		if i < 0 {
+			continue
+		}
+		var entry struct {
+			Drift []EnvironmentDrift `json:"environmentDrift"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(line[i:]), &entry))
+		drifts = append(drifts, entry.Drift...)
+	}
+
+	hash := func(s string) string {
+		sum := sha256.Sum256([]byte(s))
+		return "sha256:" + hex.EncodeToString(sum[:])
+	}
+	str := func(s string) *string { return &s }
+	assert.Equal(t, []EnvironmentDrift{
+		{Name: "AWS_SESSION_TOKEN", Old: str(hash("token-1")), New: str(hash("token-2")), Hashed: true},
+		{Name: "DRIFT_TEST_EMPTY", Old: str(""), New: nil},
+		{Name: "DRIFT_TEST_REGION", Old: str("us-east-1"), New: str("us-west-2")},
+		{Name: "DRIFT_TEST_UNSET", Old: nil, New: str("")},
+	}, drifts)
+	assert.Contains(t, logs.String(), `{"name":"DRIFT_TEST_EMPTY","old":"","new":null,"hashed":false}`)
+	assert.NotContains(t, logs.String(), "token-1")
+	assert.NotContains(t, logs.String(), "token-2")
+
+	snapshot, ok := EnvironmentSnapshot(handler)
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{
+		"AWS_SESSION_TOKEN": hash("token-2"),
+		"DRIFT_TEST_REGION": "us-west-2",
+		"DRIFT_TEST_STABLE": "unchanged",
+		"DRIFT_TEST_UNSET":  "",
+	}, snapshot)
+}
+
+func TestEnvironmentSnapshotWithoutDriftDetection(t *testing.T) {
+	snapshot, ok := EnvironmentSnapshot(NewHandlerWithOptions(func() error { return nil }))
+	assert.False(t, ok)
+	assert.Nil(t, snapshot)
+}
+
+func TestEnvironmentDriftDetectionNoChanges(t *testing.T) {
+	t.Setenv("DRIFT_TEST_STABLE", "unchanged")
+	snapshot := newEnvSnapshot([]string{"DRIFT_TEST_STABLE", "DRIFT_TEST_UNSET"})
+	assert.Empty(t, snapshot.detect())
+	assert.Empty(t, snapshot.detect())
+}
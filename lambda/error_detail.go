@@ -0,0 +1,113 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"runtime"
+
+	"github.com/aws/aws-lambda-go/lambda/messages"
+)
+
+// maxErrorCauses bounds the cause chain of an error payload, in case an error unwraps to itself.
+const maxErrorCauses = 32
+
+// WithErrorDetail adds the detail of the errors returned by the handler to the function error payload.
+// The stackTrace of the payload is the stack where the handler returned, or the stack of the deepest error in the
+// chain with a StackTrace method returning a slice of program counters, such as the errors of
+// github.com/pkg/errors. The cause of the payload lists the type and message of each error wrapped by the
+// returned error, found with the Unwrap method or else the Cause method.
+// The payload of a handler panic is unchanged.
+func WithErrorDetail() Option {
+	return Option(func(h *handlerOptions) {
+		h.errorDetail = true
+	})
+}
+
+// detailedError is the error returned by a handler, with the stack where the handler returned.
+type detailedError struct {
+	err   error
+	stack []uintptr
+}
+
+func (e *detailedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *detailedError) Unwrap() error {
+	return e.err
+}
+
+func captureErrorDetail(f handlerFunc) handlerFunc {
+	return func(ctx context.Context, payload []byte) (io.Reader, error) {
+		response, err := f(ctx, payload)
+		if err != nil {
+			if _, ok := err.(messages.InvokeResponse_Error); ok {
+				return response, err
+			}
+			s := make([]uintptr, defaultErrorFrameCount)
+			const framesToHide = 1 // runtime.Callers
+			n := runtime.Callers(framesToHide, s)
+			return response, &detailedError{err: err, stack: s[:n]}
+		}
+		return response, nil
+	}
+}
+
+func lambdaDetailedErrorResponse(detailed *detailedError) *messages.InvokeResponse_Error {
+	response := lambdaErrorResponse(detailed.err)
+	stack := detailed.stack
+	for err := unwrapCause(detailed.err); err != nil && len(response.Cause) < maxErrorCauses; err = unwrapCause(err) {
+		response.Cause = append(response.Cause, &messages.InvokeResponse_Error_Cause{
+			Message: err.Error(),
+			Type:    getErrorType(err),
+		})
+	}
+	cause := error(detailed.err)
+	for i := 0; cause != nil && i <= maxErrorCauses; i++ {
+		if errorStack, ok := errorStackTrace(cause); ok {
+			stack = errorStack
+		}
+		cause = unwrapCause(cause)
+	}
+	if len(stack) > 0 {
+		response.StackTrace = convertStack(stack)
+	}
+	return response
+}
+
+// unwrapCause returns the error wrapped by err, as errors.Unwrap does, or else the result of its Cause method.
+func unwrapCause(err error) error {
+	switch err := err.(type) {
+	case interface{ Unwrap() error }:
+		return err.Unwrap()
+	case interface{ Cause() error }:
+		return err.Cause()
+	}
+	return nil
+}
+
+// errorStackTrace returns the program counters of the StackTrace method of err, if it returns a slice of uintptr
+// based values, such as the errors.StackTrace of github.com/pkg/errors.
+func errorStackTrace(err error) ([]uintptr, bool) {
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !method.IsValid() {
+		return nil, false
+	}
+	methodType := method.Type()
+	if methodType.NumIn() != 0 || methodType.NumOut() != 1 {
+		return nil, false
+	}
+	out := methodType.Out(0)
+	if out.Kind() != reflect.Slice || out.Elem().Kind() != reflect.Uintptr {
+		return nil, false
+	}
+	frames := method.Call(nil)[0]
+	stack := make([]uintptr, frames.Len())
+	for i := range stack {
+		stack[i] = uintptr(frames.Index(i).Uint())
+	}
+	return stack, len(stack) > 0
+}
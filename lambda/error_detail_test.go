@@ -0,0 +1,118 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/lambda/messages"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stackTracedError struct {
+	stack []uintptr
+}
+
+func (e *stackTracedError) Error() string         { return "stack traced" }
+func (e *stackTracedError) StackTrace() []uintptr { return e.stack }
+
+func newStackTracedError() error {
+	s := make([]uintptr, 8)
+	n := runtime.Callers(1, s)
+	return &stackTracedError{stack: s[:n]}
+}
+
+type causeError struct {
+	cause error
+}
+
+func (e causeError) Error() string { return "caused: " + e.cause.Error() }
+func (e causeError) Cause() error  { return e.cause }
+
+// chainLeafError is the innermost error of a wrapped error chain.
+type chainLeafError struct{}
+
+func (chainLeafError) Error() string { return "leaf" }
+
+// invokeErrorBody returns the JSON error payload of an invoke of handler, and the payload decoded.
+func invokeErrorBody(t *testing.T, handler interface{}, options ...Option) (string, *messages.InvokeResponse_Error) {
+	t.Helper()
+	h := newHandler(handler, options...)
+	_, invokeErr := callBytesHandlerFunc(context.Background(), []byte(`{}`), h.handlerFunc, h.panicPolicy)
+	require.NotNil(t, invokeErr)
+	body := safeMarshal(invokeErr)
+	var decoded messages.InvokeResponse_Error
+	require.NoError(t, json.Unmarshal(body, &decoded))
+	return string(body), &decoded
+}
+
+func TestErrorDetail(t *testing.T) {
+	t.Run("plain error", func(t *testing.T) {
+		body, decoded := invokeErrorBody(t, func() error { return errors.New("failed") }, WithErrorDetail())
+		assert.Equal(t, "failed", decoded.Message)
+		assert.Equal(t, "errorString", decoded.Type)
+		assert.Nil(t, decoded.Cause)
+		assert.NotContains(t, body, `"cause"`)
+		require.NotEmpty(t, decoded.StackTrace)
+		assert.Equal(t, "captureErrorDetail.func1", decoded.StackTrace[0].Label)
+		assert.True(t, strings.HasSuffix(decoded.StackTrace[0].Path, "error_detail.go"), decoded.StackTrace[0].Path)
+	})
+
+	t.Run("wrapped error chain", func(t *testing.T) {
+		inner := chainLeafError{}
+		_, decoded := invokeErrorBody(t, func() error {
+			return fmt.Errorf("outer: %w", fmt.Errorf("middle: %w", inner))
+		}, WithErrorDetail())
+		assert.Equal(t, "outer: middle: leaf", decoded.Message)
+		assert.Equal(t, "wrapError", decoded.Type)
+		assert.Equal(t, []*messages.InvokeResponse_Error_Cause{
+			{Message: "middle: leaf", Type: "wrapError"},
+			{Message: "leaf", Type: "chainLeafError"},
+		}, decoded.Cause)
+		assert.NotEmpty(t, decoded.StackTrace)
+	})
+
+	t.Run("error stack trace and cause", func(t *testing.T) {
+		_, decoded := invokeErrorBody(t, func() error {
+			return causeError{cause: newStackTracedError()}
+		}, WithErrorDetail())
+		assert.Equal(t, "caused: stack traced", decoded.Message)
+		assert.Equal(t, "causeError", decoded.Type)
+		assert.Equal(t, []*messages.InvokeResponse_Error_Cause{
+			{Message: "stack traced", Type: "stackTracedError"},
+		}, decoded.Cause)
+		require.NotEmpty(t, decoded.StackTrace)
+		assert.Equal(t, "newStackTracedError", decoded.StackTrace[0].Label)
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		body, decoded := invokeErrorBody(t, func() error { panic(errors.New("panicked")) }, WithErrorDetail())
+		assert.Equal(t, "panicked", decoded.Message)
+		assert.Equal(t, "errorString", decoded.Type)
+		assert.NotContains(t, body, `"cause"`)
+		labels := make([]string, 0, len(decoded.StackTrace))
+		for _, frame := range decoded.StackTrace {
+			labels = append(labels, frame.Label)
+		}
+		assert.Contains(t, labels, "lambdaPanicResponse")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		body, _ := invokeErrorBody(t, func() error { return fmt.Errorf("outer: %w", errors.New("inner")) })
+		assert.JSONEq(t, `{"errorMessage": "outer: inner", "errorType": "wrapError"}`, body)
+	})
+}
+
+func TestErrorDetailKeepsReturnedError(t *testing.T) {
+	inner := errors.New("inner")
+	_, err := NewHandlerWithOptions(func() error { return inner }, WithErrorDetail()).Invoke(context.Background(), []byte(`{}`))
+	assert.True(t, errors.Is(err, inner))
+	assert.EqualError(t, err, "inner")
+}
@@ -20,6 +20,9 @@ func lambdaErrorResponse(invokeError error) *messages.InvokeResponse_Error {
 	if ive, ok := invokeError.(messages.InvokeResponse_Error); ok {
 		return &ive
 	}
+	if detailed, ok := invokeError.(*detailedError); ok {
+		return lambdaDetailedErrorResponse(detailed)
+	}
 	var errorName string
 	if errorType := reflect.TypeOf(invokeError); errorType.Kind() == reflect.Ptr {
 		errorName = errorType.Elem().Name()
@@ -0,0 +1,47 @@
+package lambda_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+// recoverPanics is a middleware returning the panics of the handler as an error, instead of a runtime error
+// reporting the panic.
+func recoverPanics(next lambda.InvokeFunc) lambda.InvokeFunc {
+	return func(ctx context.Context, payload []byte) (response io.Reader, err error) {
+		defer func() {
+			if v := recover(); v != nil {
+				response, err = nil, fmt.Errorf("handler panicked: %v", v)
+			}
+		}()
+		return next(ctx, payload)
+	}
+}
+
+// recordDuration is a middleware logging the duration of each invocation.
+func recordDuration(next lambda.InvokeFunc) lambda.InvokeFunc {
+	return func(ctx context.Context, payload []byte) (io.Reader, error) {
+		start := time.Now()
+		response, err := next(ctx, payload)
+		log.Printf("invocation took %s, error: %v", time.Since(start), err)
+		return response, err
+	}
+}
+
+func ExampleWithMiddleware() {
+	lambda.StartWithOptions(
+		func(ctx context.Context, event map[string]string) (string, error) {
+			if event["name"] == "" {
+				panic("no name")
+			}
+			return "Hello " + event["name"], nil
+		},
+		// the duration includes the recovery of a panic
+		lambda.WithMiddleware(recordDuration, recoverPanics),
+	)
+}
@@ -0,0 +1,27 @@
+//go:build go1.18
+// +build go1.18
+
+package lambda_test
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+func ExampleWithPreparedResource() {
+	lambda.StartWithOptions(
+		func(ctx context.Context, event struct{ Name string }) (int, error) {
+			buf, _ := lambda.ResourceFromContext[*bytes.Buffer](ctx)
+			buf.WriteString(event.Name)
+			return buf.Len(), nil
+		},
+		lambda.WithPreparedResource(
+			func(ctx context.Context) (*bytes.Buffer, error) {
+				return bytes.NewBuffer(make([]byte, 0, 64<<20)), nil
+			},
+			nil,
+		),
+	)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-lambda-go/lambdacontext"
 )
 
 func Example() {
@@ -100,3 +101,71 @@ func ExampleWithEnableSIGTERM() {
 		}),
 	)
 }
+
+func ExampleStartLocal() {
+	// invoke with: curl -d '{"name": "gopher"}' http://localhost:9000/2015-03-31/functions/function/invocations
+	err := lambda.StartLocal("localhost:9000", func(event map[string]string) (string, error) {
+		return "hello " + event["name"], nil
+	})
+	log.Fatal(err)
+}
+
+func ExampleWithShutdownHook() {
+	lambda.StartWithOptions(
+		func(event interface{}) (interface{}, error) {
+			return event, nil
+		},
+		lambda.WithShutdownHook(func(ctx context.Context) {
+			log.Print("flushing buffered metrics...")
+		}),
+	)
+}
+
+func ExampleWithEnvironmentDriftDetection() {
+	lambda.StartWithOptions(
+		func(event interface{}) (interface{}, error) {
+			return event, nil
+		},
+		lambda.WithEnvironmentDriftDetection("AWS_REGION", "AWS_SESSION_TOKEN"),
+	)
+}
+
+func ExampleWithContextPropagationFromClientContext() {
+	lambda.StartWithOptions(
+		func(ctx context.Context) (string, error) {
+			tenant, _ := lambda.BaggageValue(ctx, "tenant")
+			return tenant, nil
+		},
+		lambda.WithContextPropagationFromClientContext("tenant", "correlationId"),
+	)
+}
+
+func ExampleWithPanicPolicy() {
+	lambda.StartWithOptions(
+		func(event interface{}) (interface{}, error) {
+			return event, nil
+		},
+		lambda.WithPanicPolicy(lambda.PanicPolicyReportAndExit),
+	)
+}
+
+func ExampleWithCanonicalLog() {
+	lambda.StartWithOptions(
+		func(ctx context.Context, event struct{ OrderID string }) (string, error) {
+			lambdacontext.AddCanonicalField(ctx, "orderId", event.OrderID)
+			return "ok", nil
+		},
+		lambda.WithCanonicalLog(func(ctx context.Context, entry *lambda.CanonicalEntry) {
+			log.Printf("request %s finished with %s in %v: %v", entry.RequestID, entry.Outcome, entry.Duration, entry.Fields)
+		}),
+	)
+}
+
+func ExampleWithAutoMaxProcs() {
+	lambda.StartWithOptions(
+		func(event interface{}) (interface{}, error) {
+			return event, nil
+		},
+		lambda.WithAutoMaxProcs(),
+	)
+}
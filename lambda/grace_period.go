@@ -0,0 +1,29 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"context"
+	"time"
+)
+
+// WithGracePeriod cancels the context of the handler d before the deadline of the invocation, so that a handler
+// which honors the cancellation keeps up to d to flush logs, or to return a partial response, such as the batch item
+// failures of an SQS event, before the function times out. The response or error returned by the handler within
+// the grace period is reported as usual, and a streamed response is read until the deadline of the invocation.
+// A handler which ignores the cancellation still times out at the deadline of the invocation.
+// The grace period applies to functions using the Lambda runtime API, and to StartLocal.
+func WithGracePeriod(d time.Duration) Option {
+	return Option(func(h *handlerOptions) {
+		h.gracePeriod = d
+	})
+}
+
+// withGracePeriod returns a context for the handler which is done gracePeriod before the deadline of ctx.
+func withGracePeriod(ctx context.Context, gracePeriod time.Duration) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok || gracePeriod <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithDeadline(ctx, deadline.Add(-gracePeriod))
+}
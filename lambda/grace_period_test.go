@@ -0,0 +1,77 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambda/messages"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// invokeWithDeadline invokes handler once with the runtime API loop, with the given deadline.
+func invokeWithDeadline(t *testing.T, deadline time.Time, handler interface{}, options ...Option) *requestRecord {
+	metadata := defaultInvokeMetadata()
+	metadata.deadline = strconv.FormatInt(deadline.UnixNano()/nsPerMS, 10)
+	ts, record := runtimeAPIServer(`{}`, 1, metadata)
+	defer ts.Close()
+	endpoint := strings.Split(ts.URL, "://")[1]
+	_ = startRuntimeAPILoop(endpoint, newHandler(handler, options...))
+	require.Len(t, record.responses, 1)
+	return record
+}
+
+func TestGracePeriod(t *testing.T) {
+	const gracePeriod = 300 * time.Millisecond
+
+	t.Run("error within the grace period", func(t *testing.T) {
+		deadline := time.Now().Add(500 * time.Millisecond).Truncate(time.Millisecond)
+		var handlerDeadline time.Time
+		var cancelledAt time.Time
+		record := invokeWithDeadline(t, deadline, func(ctx context.Context) error {
+			handlerDeadline, _ = ctx.Deadline()
+			<-ctx.Done()
+			cancelledAt = time.Now()
+			assert.Equal(t, context.DeadlineExceeded, ctx.Err())
+			// keep working past the cancellation, within the grace period
+			time.Sleep(50 * time.Millisecond)
+			return errors.New("flushed after cancellation")
+		}, WithGracePeriod(gracePeriod))
+
+		assert.Equal(t, deadline.Add(-gracePeriod), handlerDeadline)
+		assert.True(t, cancelledAt.Before(deadline), "cancelled at %v, deadline %v", cancelledAt, deadline)
+		var invokeErr messages.InvokeResponse_Error
+		require.NoError(t, json.Unmarshal(record.responses[0], &invokeErr))
+		assert.Equal(t, "flushed after cancellation", invokeErr.Message)
+		assert.Equal(t, "errorString", invokeErr.Type)
+		assert.Contains(t, record.xrayCauses[0], "flushed after cancellation")
+	})
+
+	t.Run("response within the grace period", func(t *testing.T) {
+		deadline := time.Now().Add(500 * time.Millisecond)
+		record := invokeWithDeadline(t, deadline, func(ctx context.Context) (map[string]int, error) {
+			<-ctx.Done()
+			time.Sleep(50 * time.Millisecond)
+			return map[string]int{"processed": 3}, nil
+		}, WithGracePeriod(gracePeriod))
+		assert.Equal(t, `{"processed":3}`, string(record.responses[0]))
+		assert.Empty(t, record.xrayCauses[0])
+	})
+
+	t.Run("without grace period", func(t *testing.T) {
+		deadline := time.Now().Add(time.Minute).Truncate(time.Millisecond)
+		var handlerDeadline time.Time
+		invokeWithDeadline(t, deadline, func(ctx context.Context) error {
+			handlerDeadline, _ = ctx.Deadline()
+			return nil
+		})
+		assert.Equal(t, deadline, handlerDeadline)
+	})
+}
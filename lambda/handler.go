@@ -13,6 +13,7 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/aws/aws-lambda-go/lambda/handlertrace"
 )
@@ -32,7 +33,24 @@ type handlerOptions struct {
 	jsonResponseIndentValue          string
 	enableSIGTERM                    bool
 	sigtermCallbacks                 []func()
+	shutdownHooks                    []func(context.Context)
 	jsonOutBufferPool                *sync.Pool // contains *jsonOutBuffer
+	envSnapshot                      *envSnapshot
+	baggageKeys                      []string
+	panicPolicy                      PanicPolicy
+	canonicalLogSink                 func(context.Context, *CanonicalEntry)
+	autoMaxProcs                     bool
+	preparedResources                []preparedResource
+	voidResponseBody                 []byte
+	postInvokeGC                     *postInvokeGC
+	strictHandlerTypes               bool
+	strictTypedNilErrors             bool
+	middleware                       []Middleware
+	jsonEncoder                      func(v interface{}) ([]byte, error)
+	jsonDecoder                      func(data []byte, v interface{}) error
+	errorDetail                      bool
+	gracePeriod                      time.Duration
+	invokeObservers                  []InvokeObserver
 }
 
 type Option func(*handlerOptions)
@@ -67,6 +85,22 @@ func WithSetIndent(prefix, indent string) Option {
 	})
 }
 
+// WithJSONEncoder sets the function encoding the responses of the handler, instead of encoding/json, such as a
+// faster JSON library. WithSetEscapeHTML and WithSetIndent do not apply to it.
+func WithJSONEncoder(encode func(v interface{}) ([]byte, error)) Option {
+	return Option(func(h *handlerOptions) {
+		h.jsonEncoder = encode
+	})
+}
+
+// WithJSONDecoder sets the function decoding the events of the handler, instead of encoding/json.
+// WithUseNumber and WithDisallowUnknownFields do not apply to it.
+func WithJSONDecoder(decode func(data []byte, v interface{}) error) Option {
+	return Option(func(h *handlerOptions) {
+		h.jsonDecoder = decode
+	})
+}
+
 // WithUseNumber sets the UseNumber option on the underlying json decoder
 func WithUseNumber(useNumber bool) Option {
 	return Option(func(h *handlerOptions) {
@@ -81,6 +115,15 @@ func WithDisallowUnknownFields(disallowUnknownFields bool) Option {
 	})
 }
 
+// WithVoidResponseBody sets the exact response body posted when the handler has no return value,
+// or returns a nil pointer or interface. By default, null is posted.
+// The body must be valid JSON, or empty to post no payload. Otherwise every invocation of the handler fails.
+func WithVoidResponseBody(body []byte) Option {
+	return Option(func(h *handlerOptions) {
+		h.voidResponseBody = append([]byte{}, body...)
+	})
+}
+
 // WithEnableSIGTERM enables SIGTERM behavior within the Lambda platform on container spindown.
 // SIGKILL will occur ~500ms after SIGTERM.
 // Optionally, an array of callback functions to run on SIGTERM may be provided.
@@ -164,6 +207,35 @@ func newHandler(handlerFunc interface{}, options ...Option) *handlerOptions {
 	if h, ok := handlerFunc.(*handlerOptions); ok {
 		return h
 	}
+	h := newHandlerOptions(options...)
+	h.handlerFunc = h.wrap(reflectHandler(handlerFunc, h))
+	return h
+}
+
+// traceRawPayloads calls the RequestRaw, ResponseRaw and HandlerError callbacks of the handlertrace of the context
+// around f.
+func traceRawPayloads(f handlerFunc) handlerFunc {
+	return func(ctx context.Context, payload []byte) (io.Reader, error) {
+		trace := handlertrace.FromContext(ctx)
+		if trace.RequestRaw != nil {
+			trace.RequestRaw(ctx, payload)
+		}
+		response, err := f(ctx, payload)
+		if err != nil {
+			if trace.HandlerError != nil {
+				trace.HandlerError(ctx, err)
+			}
+			return nil, err
+		}
+		if out, ok := response.(*jsonOutBuffer); ok && trace.ResponseRaw != nil {
+			trace.ResponseRaw(ctx, out.Bytes())
+		}
+		return response, nil
+	}
+}
+
+// newHandlerOptions applies options to the default options of a handler, without its handlerFunc.
+func newHandlerOptions(options ...Option) *handlerOptions {
 	pool := &sync.Pool{}
 	pool.New = func() interface{} {
 		return &jsonOutBuffer{pool, bytes.NewBuffer(nil)}
@@ -183,12 +255,46 @@ func newHandler(handlerFunc interface{}, options ...Option) *handlerOptions {
 		h.baseContext = context.WithValue(h.baseContext, k, v)
 	}
 	if h.enableSIGTERM {
-		enableSIGTERM(h.sigtermCallbacks)
+		callbacks := h.sigtermCallbacks
+		if len(h.shutdownHooks) > 0 {
+			// run before the shutdown hooks instead, as the process exits once they return
+			callbacks = nil
+		}
+		enableSIGTERM(callbacks)
+	}
+	if len(h.shutdownHooks) > 0 {
+		handleShutdown(h.sigtermCallbacks, h.shutdownHooks)
+	}
+	if h.autoMaxProcs {
+		setAutoMaxProcs()
 	}
-	h.handlerFunc = reflectHandler(handlerFunc, h)
 	return h
 }
 
+// wrap wraps the handlerFunc f with the options applying to every invocation.
+func (h *handlerOptions) wrap(f handlerFunc) handlerFunc {
+	f = traceRawPayloads(f)
+	if len(h.middleware) > 0 {
+		f = applyMiddleware(h.middleware, f)
+	}
+	if h.envSnapshot != nil {
+		f = h.envSnapshot.wrap(f)
+	}
+	if len(h.baggageKeys) > 0 {
+		f = propagateBaggage(h.baggageKeys, f)
+	}
+	if len(h.preparedResources) > 0 {
+		f = claimPreparedResources(h.preparedResources, f)
+	}
+	if len(h.invokeObservers) > 0 {
+		f = observeInvokes(h.invokeObservers, f)
+	}
+	if h.errorDetail {
+		f = captureErrorDetail(f)
+	}
+	return f
+}
+
 type handlerFunc func(context.Context, []byte) (io.Reader, error)
 
 // back-compat for the rpc mode
@@ -267,16 +373,17 @@ func reflectHandler(f interface{}, h *handlerOptions) handlerFunc {
 		return errorHandler(err)
 	}
 
-	return func(ctx context.Context, payload []byte) (outFinal io.Reader, _ error) {
-		in := bytes.NewBuffer(payload)
-		decoder := json.NewDecoder(in)
-		if h.jsonRequestUseNumber {
-			decoder.UseNumber()
-		}
-		if h.jsonRequestDisallowUnknownFields {
-			decoder.DisallowUnknownFields()
-		}
+	if err := checkHandlerTypesOrWarn(handlerType, takesContext, h.strictHandlerTypes); err != nil {
+		return errorHandler(err)
+	}
+
+	if len(h.voidResponseBody) > 0 && !json.Valid(h.voidResponseBody) {
+		return errorHandler(fmt.Errorf("void response body is not valid JSON: %q", h.voidResponseBody))
+	}
+
+	typedNilWarning := &typedNilErrorWarning{handler: handler}
 
+	return func(ctx context.Context, payload []byte) (outFinal io.Reader, _ error) {
 		out := h.jsonOutBufferPool.Get().(*jsonOutBuffer)
 		defer func() {
 			// If the final return value is not our buffer, reset and return it to the pool.
@@ -285,9 +392,6 @@ func reflectHandler(f interface{}, h *handlerOptions) handlerFunc {
 				out.Close()
 			}
 		}()
-		encoder := json.NewEncoder(out)
-		encoder.SetEscapeHTML(h.jsonResponseEscapeHTML)
-		encoder.SetIndent(h.jsonResponseIndentPrefix, h.jsonResponseIndentValue)
 
 		trace := handlertrace.FromContext(ctx)
 
@@ -299,7 +403,7 @@ func reflectHandler(f interface{}, h *handlerOptions) handlerFunc {
 		if (handlerType.NumIn() == 1 && !takesContext) || handlerType.NumIn() == 2 {
 			eventType := handlerType.In(handlerType.NumIn() - 1)
 			event := reflect.New(eventType)
-			if err := decoder.Decode(event.Interface()); err != nil {
+			if err := h.decodeEvent(payload, event.Interface()); err != nil {
 				return nil, err
 			}
 			if nil != trace.RequestEvent {
@@ -312,8 +416,12 @@ func reflectHandler(f interface{}, h *handlerOptions) handlerFunc {
 
 		// return the error, if any
 		if len(response) > 0 {
-			if errVal, ok := response[len(response)-1].Interface().(error); ok && errVal != nil {
-				return nil, errVal
+			errRet := response[len(response)-1]
+			if errVal, ok := errRet.Interface().(error); ok && errVal != nil {
+				if h.strictTypedNilErrors || !isTypedNilError(errRet) {
+					return nil, errVal
+				}
+				typedNilWarning.warn(errVal)
 			}
 		}
 		// set the response value, if any
@@ -325,27 +433,89 @@ func reflectHandler(f interface{}, h *handlerOptions) handlerFunc {
 			}
 		}
 
-		// encode to JSON
-		if err := encoder.Encode(val); err != nil {
-			// if response is not JSON serializable, but the response type is a reader, return it as-is
-			if reader, ok := val.(io.Reader); ok {
-				return reader, nil
-			}
-			return nil, err
-		}
+		return h.encodeResponse(out, val, h.voidResponseBody != nil && isVoidResponse(response))
+	}
+}
+
+// decodeEvent decodes the event payload into v, with the decoder set by WithJSONDecoder, or the json decoder
+// configured by the options.
+func (h *handlerOptions) decodeEvent(payload []byte, v interface{}) error {
+	if h.jsonDecoder != nil {
+		return h.jsonDecoder(payload, v)
+	}
+	decoder := json.NewDecoder(bytes.NewBuffer(payload))
+	if h.jsonRequestUseNumber {
+		decoder.UseNumber()
+	}
+	if h.jsonRequestDisallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+	return decoder.Decode(v)
+}
+
+// encodeResponse encodes the response value val of a handler into out, and returns the reader of the response, which
+// is out unless val is an io.Reader to return as-is. void is whether the handler returned no response value.
+func (h *handlerOptions) encodeResponse(out *jsonOutBuffer, val interface{}, void bool) (io.Reader, error) {
+	if void {
+		_, _ = out.Write(h.voidResponseBody)
+		return out, nil
+	}
 
-		// if response value is an io.Reader, return it as-is
+	if err := h.encodeValue(out, val); err != nil {
+		// if response is not JSON serializable, but the response type is a reader, return it as-is
 		if reader, ok := val.(io.Reader); ok {
-			// back-compat, don't return the reader if the value serialized to a non-empty json
-			if strings.HasPrefix(out.String(), "{}") {
-				return reader, nil
-			}
+			return reader, nil
 		}
+		return nil, err
+	}
 
-		// back-compat, strip the encoder's trailing newline unless WithSetIndent was used
-		if h.jsonResponseIndentValue == "" && h.jsonResponseIndentPrefix == "" {
-			out.Truncate(out.Len() - 1)
+	// if response value is an io.Reader, return it as-is
+	if reader, ok := val.(io.Reader); ok {
+		// back-compat, don't return the reader if the value serialized to a non-empty json
+		if strings.HasPrefix(out.String(), "{}") {
+			return reader, nil
 		}
-		return out, nil
 	}
+
+	return out, nil
+}
+
+// encodeValue encodes val into out, with the encoder set by WithJSONEncoder, or the json encoder configured by
+// the options.
+func (h *handlerOptions) encodeValue(out *jsonOutBuffer, val interface{}) error {
+	if h.jsonEncoder != nil {
+		b, err := h.jsonEncoder(val)
+		if err != nil {
+			return err
+		}
+		_, _ = out.Write(b)
+		return nil
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetEscapeHTML(h.jsonResponseEscapeHTML)
+	encoder.SetIndent(h.jsonResponseIndentPrefix, h.jsonResponseIndentValue)
+	if err := encoder.Encode(val); err != nil {
+		return err
+	}
+	// back-compat, strip the encoder's trailing newline unless WithSetIndent was used
+	if h.jsonResponseIndentValue == "" && h.jsonResponseIndentPrefix == "" {
+		out.Truncate(out.Len() - 1)
+	}
+	return nil
+}
+
+// isVoidResponse reports whether the values returned by a handler hold no response, see WithVoidResponseBody.
+func isVoidResponse(response []reflect.Value) bool {
+	return len(response) < 2 || isNilResponse(response[0])
+}
+
+// isNilResponse reports whether the response value of a handler is a nil pointer or interface. An interface
+// holding a nil pointer is not nil, as the value is encoded as its dynamic type.
+func isNilResponse(val reflect.Value) bool {
+	switch val.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return val.IsNil()
+	}
+	return false
 }
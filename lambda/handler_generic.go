@@ -0,0 +1,83 @@
+//go:build go1.18
+// +build go1.18
+
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/aws/aws-lambda-go/lambda/handlertrace"
+)
+
+// NewTypedHandler is the same as NewHandlerWithOptions except that it takes a generic input, so that the function
+// signature is validated at compile time, and the handler is called without reflection.
+func NewTypedHandler[TIn any, TOut any, H HandlerFunc[TIn, TOut]](handler H, options ...Option) Handler {
+	return newTypedHandler[TIn, TOut](handler, options...)
+}
+
+func newTypedHandler[TIn any, TOut any, H HandlerFunc[TIn, TOut]](handler H, options ...Option) *handlerOptions {
+	h := newHandlerOptions(options...)
+	h.handlerFunc = h.wrap(typedHandler[TIn, TOut](handler, h))
+	return h
+}
+
+// typedHandler decodes the event and encodes the response as reflectHandler does for a handler with the same signature.
+func typedHandler[TIn any, TOut any, H HandlerFunc[TIn, TOut]](f H, h *handlerOptions) handlerFunc {
+	handler := (func(context.Context, TIn) (TOut, error))(f)
+	if handler == nil {
+		return errorHandler(errors.New("handler is nil"))
+	}
+
+	if err := checkHandlerTypesOrWarn(reflect.TypeOf(handler), true, h.strictHandlerTypes); err != nil {
+		return errorHandler(err)
+	}
+
+	if len(h.voidResponseBody) > 0 && !json.Valid(h.voidResponseBody) {
+		return errorHandler(fmt.Errorf("void response body is not valid JSON: %q", h.voidResponseBody))
+	}
+
+	typedNilWarning := &typedNilErrorWarning{handler: reflect.ValueOf(handler)}
+
+	return func(ctx context.Context, payload []byte) (outFinal io.Reader, _ error) {
+		out := h.jsonOutBufferPool.Get().(*jsonOutBuffer)
+		defer func() {
+			// If the final return value is not our buffer, reset and return it to the pool.
+			// The caller of the handlerFunc does this otherwise.
+			if outFinal != out {
+				out.Close()
+			}
+		}()
+
+		trace := handlertrace.FromContext(ctx)
+
+		var event TIn
+		if err := h.decodeEvent(payload, &event); err != nil {
+			return nil, err
+		}
+		if nil != trace.RequestEvent {
+			trace.RequestEvent(ctx, event)
+		}
+
+		response, err := handler(ctx, event)
+		if err != nil {
+			if h.strictTypedNilErrors || !isTypedNilError(reflect.ValueOf(&err).Elem()) {
+				return nil, err
+			}
+			typedNilWarning.warn(err)
+		}
+
+		var val interface{} = response
+		if nil != trace.ResponseEvent {
+			trace.ResponseEvent(ctx, val)
+		}
+
+		return h.encodeResponse(out, val, h.voidResponseBody != nil && isNilResponse(reflect.ValueOf(&response).Elem()))
+	}
+}
@@ -0,0 +1,187 @@
+//go:build go1.18
+// +build go1.18
+
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil" //nolint: staticcheck
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda/handlertrace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type typedNilErr struct{}
+
+func (*typedNilErr) Error() string { return "typed nil" }
+
+// assertSameAsReflect asserts the typed handler of f responds to payload as the reflection based handler does.
+func assertSameAsReflect[TIn, TOut any](t *testing.T, f func(context.Context, TIn) (TOut, error), payload string, options ...Option) []byte {
+	t.Helper()
+	expected, expectedErr := NewHandlerWithOptions(f, options...).Invoke(context.Background(), []byte(payload))
+	actual, actualErr := NewTypedHandler(f, options...).Invoke(context.Background(), []byte(payload))
+	assert.Equal(t, expectedErr, actualErr)
+	assert.Equal(t, string(expected), string(actual))
+	return actual
+}
+
+func TestTypedHandlerMatchesReflectHandler(t *testing.T) {
+	type event struct {
+		Name  string      `json:"name"`
+		Count json.Number `json:"count,omitempty"`
+		Any   interface{} `json:"any,omitempty"`
+	}
+	greet := func(_ context.Context, e event) (map[string]string, error) {
+		return map[string]string{"greeting": "<hello " + e.Name + ">"}, nil
+	}
+
+	t.Run("struct event", func(t *testing.T) {
+		out := assertSameAsReflect(t, greet, `{"name": "gopher"}`)
+		assert.Equal(t, `{"greeting":"<hello gopher>"}`, string(out))
+	})
+	t.Run("malformed event", func(t *testing.T) {
+		assertSameAsReflect(t, greet, `{"name": `)
+	})
+	t.Run("options", func(t *testing.T) {
+		assertSameAsReflect(t, greet, `{"name": "gopher"}`, WithSetEscapeHTML(true), WithSetIndent(">", "  "))
+		assertSameAsReflect(t, greet, `{"name": "gopher", "other": 1}`, WithDisallowUnknownFields(true))
+		assertSameAsReflect(t, func(_ context.Context, e event) (event, error) { return e, nil }, `{"name": "a", "any": 12345678901234567890}`, WithUseNumber(true))
+	})
+	t.Run("raw message event", func(t *testing.T) {
+		out := assertSameAsReflect(t, func(_ context.Context, e json.RawMessage) (json.RawMessage, error) { return e, nil }, ` {"a": [1, 2]} `)
+		assert.Equal(t, `{"a":[1,2]}`, string(out))
+	})
+	t.Run("bytes event", func(t *testing.T) {
+		// like encoding/json, a []byte is decoded from a base64 string
+		out := assertSameAsReflect(t, func(_ context.Context, e []byte) (string, error) { return string(e), nil }, `"aGVsbG8="`)
+		assert.Equal(t, `"hello"`, string(out))
+		assertSameAsReflect(t, func(_ context.Context, e []byte) (string, error) { return string(e), nil }, `{"a": 1}`)
+	})
+	t.Run("interface event", func(t *testing.T) {
+		assertSameAsReflect(t, func(_ context.Context, e interface{}) (interface{}, error) { return e, nil }, `{"a": [1, "b", null]}`)
+	})
+	t.Run("errors", func(t *testing.T) {
+		assertSameAsReflect(t, func(context.Context, event) (*event, error) { return nil, errors.New("failed") }, `{}`)
+		assertSameAsReflect(t, func(context.Context, event) (*event, error) { return &event{}, (*typedNilErr)(nil) }, `{}`)
+		assertSameAsReflect(t, func(context.Context, event) (*event, error) { return &event{}, (*typedNilErr)(nil) }, `{}`, WithStrictTypedNilErrors())
+	})
+	t.Run("void responses", func(t *testing.T) {
+		assertSameAsReflect(t, func(context.Context, event) (*event, error) { return nil, nil }, `{}`)
+		assertSameAsReflect(t, func(context.Context, event) (*event, error) { return nil, nil }, `{}`, WithVoidResponseBody([]byte(`{}`)))
+		assertSameAsReflect(t, func(context.Context, event) (interface{}, error) { return nil, nil }, `{}`, WithVoidResponseBody(nil))
+		assertSameAsReflect(t, func(context.Context, event) (*event, error) { return nil, nil }, `{}`, WithVoidResponseBody([]byte(`{`)))
+		assertSameAsReflect(t, func(context.Context, event) (interface{}, error) { return (*event)(nil), nil }, `{}`, WithVoidResponseBody([]byte(`{}`)))
+	})
+	t.Run("reader responses", func(t *testing.T) {
+		out := assertSameAsReflect(t, func(context.Context, event) (io.Reader, error) { return strings.NewReader("raw"), nil }, `{}`)
+		assert.Equal(t, "raw", string(out))
+		out = assertSameAsReflect(t, func(context.Context, event) (*bytes.Buffer, error) { return bytes.NewBufferString("buffered"), nil }, `{}`)
+		assert.Equal(t, "buffered", string(out))
+	})
+	t.Run("json encoder and decoder", func(t *testing.T) {
+		encodes, decodes := 0, 0
+		encoder := WithJSONEncoder(func(v interface{}) ([]byte, error) { encodes++; return json.Marshal(v) })
+		decoder := WithJSONDecoder(func(data []byte, v interface{}) error { decodes++; return json.Unmarshal(data, v) })
+		out := assertSameAsReflect(t, greet, `{"name": "gopher"}`, encoder, decoder)
+		assert.Equal(t, `{"greeting":"\u003chello gopher\u003e"}`, string(out))
+		assert.Equal(t, 2, encodes)
+		assert.Equal(t, 2, decodes)
+	})
+	t.Run("handler types", func(t *testing.T) {
+		type hidden struct{ name string } //nolint:unused
+		assertSameAsReflect(t, func(context.Context, hidden) (string, error) { return "", nil }, `{}`, WithStrictHandlerTypes())
+	})
+}
+
+func TestTypedHandlerTrace(t *testing.T) {
+	var traced []interface{}
+	ctx := handlertrace.NewContext(context.Background(), handlertrace.HandlerTrace{
+		RequestEvent:  func(_ context.Context, event interface{}) { traced = append(traced, event) },
+		ResponseEvent: func(_ context.Context, response interface{}) { traced = append(traced, response) },
+	})
+	handler := NewTypedHandler(func(_ context.Context, e events.SQSEvent) (events.SQSEventResponse, error) {
+		return events.SQSEventResponse{BatchItemFailures: []events.SQSBatchItemFailure{{ItemIdentifier: e.Records[0].MessageId}}}, nil
+	})
+	_, err := handler.Invoke(ctx, []byte(`{"Records": [{"messageId": "m-1"}]}`))
+	require.NoError(t, err)
+	require.Len(t, traced, 2)
+	assert.Equal(t, events.SQSEvent{Records: []events.SQSMessage{{MessageId: "m-1"}}}, traced[0])
+	assert.Equal(t, events.SQSEventResponse{BatchItemFailures: []events.SQSBatchItemFailure{{ItemIdentifier: "m-1"}}}, traced[1])
+}
+
+func TestTypedHandlerNil(t *testing.T) {
+	var f func(context.Context, string) (string, error)
+	_, err := NewTypedHandler(f).Invoke(context.Background(), []byte(`""`))
+	assert.EqualError(t, err, "handler is nil")
+}
+
+func TestTypedHandlerContextValues(t *testing.T) {
+	type key struct{}
+	ts, record := runtimeAPIServer(`"tacos"`, 1)
+	defer ts.Close()
+
+	handler := NewTypedHandler(func(ctx context.Context, food string) (string, error) {
+		return fmt.Sprintf("%v %s", ctx.Value(key{}), food), nil
+	}, WithContextValue(key{}, "craving"))
+	endpoint := strings.Split(ts.URL, "://")[1]
+	_ = startRuntimeAPILoop(endpoint, handler)
+	assert.Equal(t, `"craving tacos"`, string(record.responses[0]))
+}
+
+// s3EventPayload returns an S3 event of 20 records.
+func s3EventPayload(b *testing.B) []byte {
+	fixture, err := ioutil.ReadFile("../events/testdata/s3-event.json")
+	require.NoError(b, err)
+	var event events.S3Event
+	require.NoError(b, json.Unmarshal(fixture, &event))
+	for len(event.Records) < 20 {
+		event.Records = append(event.Records, event.Records[0])
+	}
+	payload, err := json.Marshal(event)
+	require.NoError(b, err)
+	return payload
+}
+
+func BenchmarkS3EventHandler(b *testing.B) {
+	payload := s3EventPayload(b)
+	type response struct {
+		Keys []string `json:"keys"`
+	}
+	f := func(_ context.Context, event events.S3Event) (response, error) {
+		var r response
+		for _, record := range event.Records {
+			r.Keys = append(r.Keys, record.S3.Object.Key)
+		}
+		return r, nil
+	}
+	for _, bm := range []struct {
+		name    string
+		handler Handler
+	}{
+		{"reflect", NewHandler(f)},
+		{"typed", NewTypedHandler(f)},
+	} {
+		handler := bm.handler
+		b.Run(bm.name, func(b *testing.B) {
+			ctx := context.Background()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := handler.Invoke(ctx, payload); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -519,3 +519,100 @@ func TestHandlerTrace(t *testing.T) {
 		t.Error("response callbacks not called as expected", responseHistory)
 	}
 }
+
+func TestHandlerTraceRawPayloadsAndErrors(t *testing.T) {
+	var calls []string
+	ctx := handlertrace.NewContext(context.Background(), handlertrace.HandlerTrace{
+		RequestRaw: func(_ context.Context, payload []byte) { calls = append(calls, "request raw "+string(payload)) },
+		RequestEvent: func(_ context.Context, event interface{}) {
+			calls = append(calls, fmt.Sprintf("request event %v", event))
+		},
+		ResponseEvent: func(_ context.Context, response interface{}) {
+			calls = append(calls, fmt.Sprintf("response event %v", response))
+		},
+		ResponseRaw:  func(_ context.Context, payload []byte) { calls = append(calls, "response raw "+string(payload)) },
+		HandlerError: func(_ context.Context, err error) { calls = append(calls, "error "+err.Error()) },
+	})
+	handler := NewHandler(func(x int) (int, error) {
+		if x < 0 {
+			return 0, errors.New("negative")
+		}
+		return x * 2, nil
+	})
+
+	t.Run("success", func(t *testing.T) {
+		calls = nil
+		_, err := handler.Invoke(ctx, []byte(`21`))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"request raw 21", "request event 21", "response event 42", "response raw 42"}, calls)
+	})
+	t.Run("handler error", func(t *testing.T) {
+		calls = nil
+		_, err := handler.Invoke(ctx, []byte(`-1`))
+		assert.EqualError(t, err, "negative")
+		assert.Equal(t, []string{"request raw -1", "request event -1", "error negative"}, calls)
+	})
+	t.Run("unmarshal error", func(t *testing.T) {
+		calls = nil
+		_, err := handler.Invoke(ctx, []byte(`"21"`))
+		require.Error(t, err)
+		assert.Equal(t, []string{`request raw "21"`, "error " + err.Error()}, calls)
+	})
+	t.Run("reader response", func(t *testing.T) {
+		calls = nil
+		_, err := NewHandler(func() (io.Reader, error) { return strings.NewReader("raw"), nil }).Invoke(ctx, []byte(`{}`))
+		require.NoError(t, err)
+		// the reader is not read by the handler, so there is no raw response
+		require.Len(t, calls, 2)
+		assert.Equal(t, "request raw {}", calls[0])
+		assert.True(t, strings.HasPrefix(calls[1], "response event "))
+	})
+}
+
+func TestVoidResponseBody(t *testing.T) {
+	type result struct {
+		Value string `json:"value"`
+	}
+	noReturn := func() error { return nil }
+	nilPointer := func() (*result, error) { return nil, nil }
+	nilInterface := func() (interface{}, error) { return nil, nil }
+	value := func() (*result, error) { return &result{"ok"}, nil }
+	emptyMap := func() (map[string]string, error) { return nil, nil }
+
+	testCases := []struct {
+		name     string
+		handler  interface{}
+		options  []Option
+		expected string
+	}{
+		{"no return value", noReturn, nil, `null`},
+		{"nil pointer", nilPointer, nil, `null`},
+		{"nil interface", nilInterface, nil, `null`},
+		{"no return value with override", noReturn, []Option{WithVoidResponseBody([]byte(`{}`))}, `{}`},
+		{"nil pointer with override", nilPointer, []Option{WithVoidResponseBody([]byte(`{}`))}, `{}`},
+		{"nil interface with override", nilInterface, []Option{WithVoidResponseBody([]byte(`{}`))}, `{}`},
+		{"empty override", noReturn, []Option{WithVoidResponseBody([]byte{})}, ``},
+		{"override is posted as is", nilPointer, []Option{WithVoidResponseBody([]byte(`{ "status": "none" }`)), WithSetIndent("", "  ")}, `{ "status": "none" }`},
+		{"values are not affected", value, []Option{WithVoidResponseBody([]byte(`{}`))}, `{"value":"ok"}`},
+		{"nil maps are not void", emptyMap, []Option{WithVoidResponseBody([]byte(`{}`))}, `null`},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts, record := runtimeAPIServer(``, 1)
+			defer ts.Close()
+
+			handler := newHandler(testCase.handler, testCase.options...)
+			endpoint := strings.Split(ts.URL, "://")[1]
+			_ = startRuntimeAPILoop(endpoint, handler)
+
+			require.Len(t, record.responses, 1)
+			assert.Equal(t, testCase.expected, string(record.responses[0]))
+		})
+	}
+}
+
+func TestVoidResponseBodyInvalidJSON(t *testing.T) {
+	handler := NewHandlerWithOptions(func() error { return nil }, WithVoidResponseBody([]byte(`{`)))
+	_, err := handler.Invoke(context.Background(), []byte(`{}`))
+	assert.EqualError(t, err, `void response body is not valid JSON: "{"`)
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"reflect"
+)
+
+var (
+	jsonMarshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	readerType          = reflect.TypeOf((*io.Reader)(nil)).Elem()
+)
+
+// WithStrictHandlerTypes makes the handler fail every invocation, instead of logging a warning when it is created,
+// when its event type is a struct with fields but none that encoding/json can decode into, or its response type is one
+// that always marshals to {}. This is usually the mistake of a struct with only unexported fields.
+func WithStrictHandlerTypes() Option {
+	return Option(func(h *handlerOptions) {
+		h.strictHandlerTypes = true
+	})
+}
+
+// checkHandlerTypes returns an error if the event or response type of handler has fields, but none encoding/json uses.
+func checkHandlerTypes(handler reflect.Type, takesContext bool) error {
+	if (handler.NumIn() == 1 && !takesContext) || handler.NumIn() == 2 {
+		eventType := handler.In(handler.NumIn() - 1)
+		if !hasJSONFields(eventType, jsonUnmarshalerType, textUnmarshalerType) {
+			return fmt.Errorf("handler event type %s has no exported fields to decode the event into, every event decodes to its zero value", eventType)
+		}
+	}
+	if handler.NumOut() == 2 {
+		responseType := handler.Out(0)
+		if responseType.Implements(readerType) {
+			return nil
+		}
+		if !hasJSONFields(responseType, jsonMarshalerType, textMarshalerType) {
+			return fmt.Errorf("handler response type %s has no exported fields to encode, every response marshals to {}", responseType)
+		}
+	}
+	return nil
+}
+
+func checkHandlerTypesOrWarn(handler reflect.Type, takesContext bool, strict bool) error {
+	err := checkHandlerTypes(handler, takesContext)
+	if err != nil && !strict {
+		log.Printf("lambda: warning: %v", err)
+		return nil
+	}
+	return err
+}
+
+// hasJSONFields reports whether t, which is not a struct or is an empty struct, or has a field encoding/json uses,
+// can hold a value from JSON. Types implementing one of the custom interfaces are assumed to handle their JSON.
+func hasJSONFields(t reflect.Type, custom ...reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	for _, c := range custom {
+		if t.Implements(c) || reflect.PtrTo(t).Implements(c) {
+			return true
+		}
+	}
+	if t.Kind() != reflect.Struct || t.NumField() == 0 {
+		return true
+	}
+	return hasExportedJSONField(t)
+}
+
+func hasExportedJSONField(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Tag.Get("json") == "-" {
+			continue
+		}
+		if field.Anonymous {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			// encoding/json promotes the fields of embedded structs, even of unexported types
+			if embedded.Kind() == reflect.Struct && field.Tag.Get("json") == "" {
+				if hasExportedJSONField(embedded) {
+					return true
+				}
+				continue
+			}
+		}
+		if field.PkgPath == "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type unexportedEvent struct {
+	name  string //nolint:unused
+	count int    //nolint:unused
+}
+
+type taggedEvent struct {
+	Name string `json:"name"`
+	skip string //nolint:unused
+}
+
+type ignoredFieldsEvent struct {
+	Name string `json:"-"`
+	name string //nolint:unused
+}
+
+type embeddingEvent struct {
+	taggedEvent
+	count int //nolint:unused
+}
+
+func TestCheckHandlerTypes(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		handler interface{}
+		err     string
+	}{
+		{"unexported event", func(unexportedEvent) error { return nil },
+			"handler event type lambda.unexportedEvent has no exported fields to decode the event into, every event decodes to its zero value"},
+		{"unexported event pointer", func(context.Context, *unexportedEvent) error { return nil },
+			"handler event type *lambda.unexportedEvent has no exported fields to decode the event into, every event decodes to its zero value"},
+		{"ignored fields", func(ignoredFieldsEvent) error { return nil },
+			"handler event type lambda.ignoredFieldsEvent has no exported fields to decode the event into, every event decodes to its zero value"},
+		{"unexported response", func() (unexportedEvent, error) { return unexportedEvent{}, nil },
+			"handler response type lambda.unexportedEvent has no exported fields to encode, every response marshals to {}"},
+		{"tagged event", func(taggedEvent) (*taggedEvent, error) { return nil, nil }, ""},
+		{"embedded struct", func(embeddingEvent) error { return nil }, ""},
+		{"map", func(map[string]interface{}) (map[string]interface{}, error) { return nil, nil }, ""},
+		{"interface", func(context.Context, interface{}) (interface{}, error) { return nil, nil }, ""},
+		{"empty struct", func(struct{}) (struct{}, error) { return struct{}{}, nil }, ""},
+		{"custom unmarshaler", func(time.Time) (time.Time, error) { return time.Time{}, nil }, ""},
+		{"reader response", func() (*bytes.Buffer, error) { return nil, nil }, ""},
+		{"no event", func(context.Context) error { return nil }, ""},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			handlerType := reflect.TypeOf(test.handler)
+			takesContext := handlerType.NumIn() > 0 && handlerType.In(0) == reflect.TypeOf((*context.Context)(nil)).Elem()
+			err := checkHandlerTypes(handlerType, takesContext)
+			if test.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestHandlerTypesWarning(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	handler := NewHandler(func(e unexportedEvent) (string, error) { return "ok", nil })
+	assert.Contains(t, logs.String(), "lambda: warning: handler event type lambda.unexportedEvent has no exported fields")
+
+	response, err := handler.Invoke(context.Background(), []byte(`{"name": "ignored"}`))
+	require.NoError(t, err)
+	assert.Equal(t, `"ok"`, string(response))
+
+	logs.Reset()
+	NewHandler(func(e taggedEvent) error { return nil })
+	assert.Empty(t, logs.String())
+}
+
+func TestStrictHandlerTypes(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	handler := NewHandlerWithOptions(func(e unexportedEvent) error { return nil }, WithStrictHandlerTypes())
+	assert.Empty(t, logs.String())
+	_, err := handler.Invoke(context.Background(), []byte(`{}`))
+	assert.EqualError(t, err, "handler event type lambda.unexportedEvent has no exported fields to decode the event into, every event decodes to its zero value")
+
+	handler = NewHandlerWithOptions(func(e taggedEvent) error { return nil }, WithStrictHandlerTypes())
+	_, err = handler.Invoke(context.Background(), []byte(`{"name": "test"}`))
+	assert.NoError(t, err)
+}
@@ -11,6 +11,38 @@ import (
 type HandlerTrace struct {
 	RequestEvent  func(context.Context, interface{})
 	ResponseEvent func(context.Context, interface{})
+
+	// RequestRaw is called with the payload of the invoke, before it is decoded into the event of the handler.
+	RequestRaw func(context.Context, []byte)
+
+	// ResponseRaw is called with the JSON encoded response of the handler. It is not called for responses returned
+	// as an io.Reader, which are streamed as they are read.
+	ResponseRaw func(context.Context, []byte)
+
+	// HandlerError is called with the error of the handler, or the error decoding the payload or encoding the response.
+	HandlerError func(context.Context, error)
+
+	// ResponsePosted is called after a successful response has been posted to the Lambda Runtime API, or written
+	// to the HTTP client of a local invocation, or after the client disconnected while the response was streamed.
+	// It is only called for handlers started with lambda.Start or lambda.StartLocal, and only sees a trace added to
+	// the base context, for example with lambda.WithContext.
+	// When reading a streamed response fails partway, the error is reported in the trailers of the response, and
+	// ResponsePosted receives the SHA256 and Bytes of the part written before the failure, with ClientDisconnected
+	// false. For StartLocal, a stream failing before its first byte is written as an error response, and
+	// ResponsePosted receives an event with 0 Bytes.
+	ResponsePosted func(context.Context, ResponsePostedEvent)
+}
+
+// ResponsePostedEvent describes the response payload posted to the Lambda Runtime API, or written to the client of
+// lambda.StartLocal.
+// It allows checking that what the handler produced is byte-identical to what the runtime received.
+type ResponsePostedEvent struct {
+	SHA256 string // hex encoded SHA-256 of the posted payload
+	Bytes  int64
+
+	// ClientDisconnected is set when the Runtime API reported that the client of the invocation went away before
+	// the response was complete. SHA256 and Bytes then describe the part of the payload read until then.
+	ClientDisconnected bool
 }
 
 func callbackCompose(f1, f2 func(context.Context, interface{})) func(context.Context, interface{}) {
@@ -24,16 +56,60 @@ func callbackCompose(f1, f2 func(context.Context, interface{})) func(context.Con
 	}
 }
 
+func responsePostedCompose(f1, f2 func(context.Context, ResponsePostedEvent)) func(context.Context, ResponsePostedEvent) {
+	if f1 == nil {
+		return f2
+	}
+	if f2 == nil {
+		return f1
+	}
+	return func(ctx context.Context, event ResponsePostedEvent) {
+		f1(ctx, event)
+		f2(ctx, event)
+	}
+}
+
+func rawCompose(f1, f2 func(context.Context, []byte)) func(context.Context, []byte) {
+	if f1 == nil {
+		return f2
+	}
+	if f2 == nil {
+		return f1
+	}
+	return func(ctx context.Context, payload []byte) {
+		f1(ctx, payload)
+		f2(ctx, payload)
+	}
+}
+
+func errorCompose(f1, f2 func(context.Context, error)) func(context.Context, error) {
+	if f1 == nil {
+		return f2
+	}
+	if f2 == nil {
+		return f1
+	}
+	return func(ctx context.Context, err error) {
+		f1(ctx, err)
+		f2(ctx, err)
+	}
+}
+
 type handlerTraceKey struct{}
 
 // NewContext adds callbacks to the provided context which allows handlers which
 // wrap the return value of lambda.NewHandler to access to the request and
-// response events.
+// response events. The callbacks of a trace already in the context are kept,
+// and called before the ones of trace.
 func NewContext(ctx context.Context, trace HandlerTrace) context.Context {
 	existing := FromContext(ctx)
 	return context.WithValue(ctx, handlerTraceKey{}, HandlerTrace{
-		RequestEvent:  callbackCompose(existing.RequestEvent, trace.RequestEvent),
-		ResponseEvent: callbackCompose(existing.ResponseEvent, trace.ResponseEvent),
+		RequestEvent:   callbackCompose(existing.RequestEvent, trace.RequestEvent),
+		ResponseEvent:  callbackCompose(existing.ResponseEvent, trace.ResponseEvent),
+		RequestRaw:     rawCompose(existing.RequestRaw, trace.RequestRaw),
+		ResponseRaw:    rawCompose(existing.ResponseRaw, trace.ResponseRaw),
+		HandlerError:   errorCompose(existing.HandlerError, trace.HandlerError),
+		ResponsePosted: responsePostedCompose(existing.ResponsePosted, trace.ResponsePosted),
 	})
 }
 
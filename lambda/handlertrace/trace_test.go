@@ -2,6 +2,7 @@ package handlertrace
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -42,3 +43,51 @@ func TestTrace(t *testing.T) {
 	fmt.Println(responseCall)
 	assert.Equal(t, responseCall, 2)
 }
+
+func TestTraceResponsePosted(t *testing.T) {
+	var calls []string
+	ctx := NewContext(context.Background(), HandlerTrace{
+		ResponsePosted: func(ctx context.Context, event ResponsePostedEvent) {
+			calls = append(calls, "first:"+event.SHA256)
+		},
+	})
+	ctx = NewContext(ctx, HandlerTrace{})
+	ctx = NewContext(ctx, HandlerTrace{
+		ResponsePosted: func(ctx context.Context, event ResponsePostedEvent) {
+			calls = append(calls, "second:"+event.SHA256)
+		},
+	})
+
+	FromContext(ctx).ResponsePosted(ctx, ResponsePostedEvent{SHA256: "abc", Bytes: 3})
+	assert.Equal(t, []string{"first:abc", "second:abc"}, calls)
+	assert.Nil(t, FromContext(context.Background()).ResponsePosted)
+}
+
+func TestTraceRawPayloadsAndErrors(t *testing.T) {
+	var calls []string
+	ctx := NewContext(context.Background(), HandlerTrace{
+		RequestRaw:   func(_ context.Context, payload []byte) { calls = append(calls, "first request:"+string(payload)) },
+		HandlerError: func(_ context.Context, err error) { calls = append(calls, "first error:"+err.Error()) },
+	})
+	ctx = NewContext(ctx, HandlerTrace{})
+	ctx = NewContext(ctx, HandlerTrace{
+		RequestRaw:   func(_ context.Context, payload []byte) { calls = append(calls, "second request:"+string(payload)) },
+		ResponseRaw:  func(_ context.Context, payload []byte) { calls = append(calls, "second response:"+string(payload)) },
+		HandlerError: func(_ context.Context, err error) { calls = append(calls, "second error:"+err.Error()) },
+	})
+
+	trace := FromContext(ctx)
+	trace.RequestRaw(ctx, []byte("{}"))
+	trace.ResponseRaw(ctx, []byte("null"))
+	trace.HandlerError(ctx, errors.New("failed"))
+	assert.Equal(t, []string{
+		"first request:{}", "second request:{}",
+		"second response:null",
+		"first error:failed", "second error:failed",
+	}, calls)
+
+	empty := FromContext(NewContext(context.Background(), HandlerTrace{}))
+	assert.Nil(t, empty.RequestRaw)
+	assert.Nil(t, empty.ResponseRaw)
+	assert.Nil(t, empty.HandlerError)
+}
@@ -0,0 +1,153 @@
+//go:build go1.21
+// +build go1.21
+
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/events/generate"
+	"github.com/aws/aws-lambda-go/lambda/handlertrace"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// middlewareFunc adapts a function to a Handler, as middleware wrapping the Handler returned by NewHandler is written.
+type middlewareFunc func(ctx context.Context, payload []byte) ([]byte, error)
+
+func (f middlewareFunc) Invoke(ctx context.Context, payload []byte) ([]byte, error) {
+	return f(ctx, payload)
+}
+
+// withInvocationLogger is a middleware binding the Lambda fields of each invocation to the logger of its context.
+func withInvocationLogger(logger *slog.Logger, next Handler) Handler {
+	return middlewareFunc(func(ctx context.Context, payload []byte) ([]byte, error) {
+		ctx = lambdacontext.NewContextWithInvocationLogger(ctx, logger)
+		lambdacontext.LoggerFromContext(ctx).Info("invoke started")
+		return next.Invoke(ctx, payload)
+	})
+}
+
+// withTrace is a middleware recording the request and response events of each invocation.
+func withTrace(record func(string), next Handler) Handler {
+	return middlewareFunc(func(ctx context.Context, payload []byte) ([]byte, error) {
+		lc, _ := lambdacontext.FromContext(ctx)
+		ctx = handlertrace.NewContext(ctx, handlertrace.HandlerTrace{
+			RequestEvent: func(ctx context.Context, event interface{}) {
+				record(fmt.Sprintf("request %s %d", lc.AwsRequestID, len(event.(events.SQSEvent).Records)))
+			},
+			ResponseEvent: func(ctx context.Context, response interface{}) {
+				record(fmt.Sprintf("response %s %d", lc.AwsRequestID, len(response.(events.SQSEventResponse).BatchItemFailures)))
+			},
+		})
+		return next.Invoke(ctx, payload)
+	})
+}
+
+// TestIntegrationLoggerMiddlewareBatch runs an SQS batch handler, wrapped in middleware installing the lambdacontext
+// logger and handler traces, with StartWithOptions against the runtime API, for three sequential invokes.
+func TestIntegrationLoggerMiddlewareBatch(t *testing.T) {
+	event := generate.NewSQSEvent("ok", "fail", "ok")
+	payload, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	requestIDs := []string{"request-1", "request-2", "request-3"}
+	deadline := strconv.FormatInt(time.Now().Add(time.Minute).UnixNano()/int64(time.Millisecond), 10)
+	var metadata []eventMetadata
+	for _, id := range requestIDs {
+		m := defaultInvokeMetadata()
+		m.requestID = id
+		m.deadline = deadline
+		metadata = append(metadata, m)
+	}
+	ts, record := runtimeAPIServer(string(payload), len(requestIDs), metadata...)
+	defer ts.Close()
+
+	var logs bytes.Buffer
+	logger := slog.New(lambdacontext.WrapLogHandler(slog.NewJSONHandler(&logs, &slog.HandlerOptions{ReplaceAttr: lambdacontext.ReplaceAttr})))
+
+	var traceLock sync.Mutex
+	var traces []string
+	recordTrace := func(trace string) {
+		traceLock.Lock()
+		defer traceLock.Unlock()
+		traces = append(traces, trace)
+	}
+
+	batchHandler := func(ctx context.Context, event events.SQSEvent) (events.SQSEventResponse, error) {
+		logger := lambdacontext.LoggerFromContext(ctx)
+		return event.BatchResponse(func(message events.SQSMessage) error {
+			logger.Info("processing", "messageId", message.MessageId)
+			if message.Body == "fail" {
+				return errors.New("failed")
+			}
+			return nil
+		}), nil
+	}
+	handler := withInvocationLogger(logger, withTrace(recordTrace, NewHandler(batchHandler)))
+
+	baseContext := handlertrace.NewContext(context.Background(), handlertrace.HandlerTrace{
+		ResponsePosted: func(ctx context.Context, posted handlertrace.ResponsePostedEvent) {
+			lc, _ := lambdacontext.FromContext(ctx)
+			recordTrace("posted " + lc.AwsRequestID)
+		},
+	})
+
+	os.Setenv("AWS_LAMBDA_RUNTIME_API", strings.Split(ts.URL, "://")[1])
+	defer os.Unsetenv("AWS_LAMBDA_RUNTIME_API")
+	logFatalf = func(format string, v ...interface{}) {}
+	defer func() { logFatalf = log.Fatalf }()
+
+	StartWithOptions(handler, WithContext(baseContext))
+
+	// batch failures map to the message of the failed record, in each response
+	require.Len(t, record.responses, len(requestIDs))
+	for _, response := range record.responses {
+		assert.JSONEq(t, `{"batchItemFailures": [{"itemIdentifier": "`+event.Records[1].MessageId+`"}]}`, string(response))
+	}
+
+	// the callbacks fire in order, once per invoke
+	var expectedTraces []string
+	for _, id := range requestIDs {
+		expectedTraces = append(expectedTraces, "request "+id+" 3", "response "+id+" 1", "posted "+id)
+	}
+	assert.Equal(t, expectedTraces, traces)
+
+	// each log record carries the request ID of its invoke
+	type logRecord struct {
+		Message   string `json:"message"`
+		RequestID string `json:"requestId"`
+		MessageID string `json:"messageId"`
+	}
+	var records []logRecord
+	decoder := json.NewDecoder(&logs)
+	for decoder.More() {
+		var r logRecord
+		require.NoError(t, decoder.Decode(&r))
+		records = append(records, r)
+	}
+	var expectedRecords []logRecord
+	for _, id := range requestIDs {
+		expectedRecords = append(expectedRecords, logRecord{Message: "invoke started", RequestID: id})
+		for _, message := range event.Records {
+			expectedRecords = append(expectedRecords, logRecord{Message: "processing", RequestID: id, MessageID: message.MessageId})
+		}
+	}
+	assert.Equal(t, expectedRecords, records)
+}
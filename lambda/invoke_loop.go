@@ -6,13 +6,16 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/aws/aws-lambda-go/lambda/handlertrace"
 	"github.com/aws/aws-lambda-go/lambda/messages"
 	"github.com/aws/aws-lambda-go/lambdacontext"
 )
@@ -22,6 +25,11 @@ const (
 	nsPerMS = int64(time.Millisecond / time.Nanosecond)
 )
 
+// ErrClientDisconnected is the cause of the cancellation of the handler's context when the client disconnects
+// while the response is streamed, for example from a Function URL. It is retrieved with context.Cause, from Go 1.20.
+// The reader returned by the handler is then no longer read, and is closed if it is an io.Closer.
+var ErrClientDisconnected = errors.New("lambda: client disconnected before the response was complete")
+
 // TODO: replace with time.UnixMillis after dropping version <1.17 from CI workflows
 func unixMS(ms int64) time.Time {
 	return time.Unix(ms/msPerS, (ms%msPerS)*nsPerMS)
@@ -48,6 +56,8 @@ func handleInvoke(invoke *invoke, handler *handlerOptions) error {
 	}
 	ctx, cancel := context.WithDeadline(handler.baseContext, deadline)
 	defer cancel()
+	ctx, cancelCause := withCancelCause(ctx)
+	defer cancelCause(nil)
 
 	// set the invoke metadata values
 	lc := lambdacontext.LambdaContext{
@@ -63,21 +73,60 @@ func handleInvoke(invoke *invoke, handler *handlerOptions) error {
 	}
 	ctx = lambdacontext.NewContext(ctx, &lc)
 
+	return runInvoke(ctx, cancelCause, handler, invoke.payload.Bytes(), invoke.headers.Get(headerTraceID), runtimeAPIResponder{invoke})
+}
+
+// invokeResponder sends the outcome of an invocation, to the Runtime API, or to the client of StartLocal.
+type invokeResponder interface {
+	// success sends the response read from body. abort is called with the cause as soon as sending it stops before
+	// its end. The returned event describes what was sent, including when an error is returned.
+	success(body io.Reader, contentType string, abort func(cause error)) (handlertrace.ResponsePostedEvent, error)
+	// failure sends the error of the invocation.
+	failure(invokeErr *messages.InvokeResponse_Error) error
+}
+
+type runtimeAPIResponder struct {
+	invoke *invoke
+}
+
+func (r runtimeAPIResponder) success(body io.Reader, contentType string, abort func(cause error)) (handlertrace.ResponsePostedEvent, error) {
+	r.invoke.abort = abort
+	err := r.invoke.success(body, contentType)
+	return r.invoke.posted, err
+}
+
+func (r runtimeAPIResponder) failure(invokeErr *messages.InvokeResponse_Error) error {
+	return reportFailure(r.invoke, invokeErr)
+}
+
+// runInvoke calls the handler with the context of an invocation, and sends its outcome with responder.
+// It returns an error if the function panics, or some other non-recoverable error occurred.
+func runInvoke(ctx context.Context, cancelCause func(error), handler *handlerOptions, payload []byte, traceID string, responder invokeResponder) error {
 	// set the trace id
-	traceID := invoke.headers.Get(headerTraceID)
 	if lambdacontext.MaxConcurrency() == 1 {
 		os.Setenv("_X_AMZN_TRACE_ID", traceID)
 	}
 	// nolint:staticcheck
 	ctx = context.WithValue(ctx, "x-amzn-trace-id", traceID)
+	ctx, emitCanonicalLog := handler.startCanonicalLog(ctx, traceID)
+
+	// prepare resources for the next invoke once this one's response is posted
+	defer handler.prepareNextResources()
+	defer handler.postInvokeGC.collect()
 
 	// call the handler, marshal any returned error
-	response, invokeErr := callBytesHandlerFunc(ctx, invoke.payload.Bytes(), handler.handlerFunc)
+	handlerCtx, cancelHandler := withGracePeriod(ctx, handler.gracePeriod)
+	defer cancelHandler()
+	response, invokeErr := callBytesHandlerFunc(handlerCtx, payload, handler.handlerFunc, handler.panicPolicy)
 	if invokeErr != nil {
-		if err := reportFailure(invoke, invokeErr); err != nil {
+		if err := responder.failure(invokeErr); err != nil {
 			return err
 		}
+		emitCanonicalLog(invokeErr, handlertrace.ResponsePostedEvent{})
 		if invokeErr.ShouldExit {
+			if handler.panicPolicy == PanicPolicyReportAndExit {
+				exitAfterPanic(handler)
+			}
 			return fmt.Errorf("calling the handler function resulted in a panic, the process should exit")
 		}
 		return nil
@@ -88,15 +137,20 @@ func handleInvoke(invoke *invoke, handler *handlerOptions) error {
 	}
 
 	// if the response defines a content-type, plumb it through
-	contentType := contentTypeBytes
-	type ContentType interface{ ContentType() string }
-	if response, ok := response.(ContentType); ok {
-		contentType = response.ContentType()
-	}
+	contentType := responseContentType(response)
 
-	if err := invoke.success(response, contentType); err != nil {
-		return fmt.Errorf("unexpected error occurred when sending the function functionResponse to the API: %v", err)
+	// stop the handler as soon as its response can no longer be sent
+	posted, err := responder.success(streamResponse(ctx, response), contentType, cancelCause)
+	if err != nil {
+		if !posted.ClientDisconnected {
+			return fmt.Errorf("unexpected error occurred when sending the function functionResponse to the API: %v", err)
+		}
+		log.Printf("client disconnected after %d bytes of the response: %v", posted.Bytes, err)
+	}
+	if trace := handlertrace.FromContext(ctx); trace.ResponsePosted != nil {
+		trace.ResponsePosted(ctx, posted)
 	}
+	emitCanonicalLog(nil, posted)
 
 	return nil
 }
@@ -116,8 +170,11 @@ func reportFailure(invoke *invoke, invokeErr *messages.InvokeResponse_Error) err
 	return nil
 }
 
-func callBytesHandlerFunc(ctx context.Context, payload []byte, handler handlerFunc) (response io.Reader, invokeErr *messages.InvokeResponse_Error) {
+func callBytesHandlerFunc(ctx context.Context, payload []byte, handler handlerFunc, panicPolicy PanicPolicy) (response io.Reader, invokeErr *messages.InvokeResponse_Error) {
 	defer func() {
+		if panicPolicy == PanicPolicyPropagate {
+			return
+		}
 		if err := recover(); err != nil {
 			invokeErr = lambdaPanicResponse(err)
 		}
@@ -149,6 +206,15 @@ func parseCognitoIdentity(invoke *invoke, out *lambdacontext.CognitoIdentity) er
 
 func parseClientContext(invoke *invoke, out *lambdacontext.ClientContext) error {
 	clientContextJSON := invoke.headers.Get(headerClientContext)
+	if clientContextJSON != "" && !strings.HasPrefix(strings.TrimSpace(clientContextJSON), "{") {
+		// the base64 encoded form of the Invoke API
+		clientContext, err := lambdacontext.ParseClientContext(clientContextJSON)
+		if err != nil {
+			return err
+		}
+		*out = clientContext
+		return nil
+	}
 	if clientContextJSON != "" {
 		if err := json.Unmarshal([]byte(clientContextJSON), out); err != nil {
 			return fmt.Errorf("failed to unmarshal client context json: %v", err)
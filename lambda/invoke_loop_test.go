@@ -5,6 +5,9 @@ package lambda
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -17,6 +20,8 @@ import (
 	"testing"
 	"unicode/utf8"
 
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda/handlertrace"
 	"github.com/aws/aws-lambda-go/lambda/messages"
 	"github.com/aws/aws-lambda-go/lambdacontext"
 	"github.com/stretchr/testify/assert"
@@ -295,6 +300,52 @@ func TestReadPayload(t *testing.T) {
 	assert.Equal(t, contentTypeJSON, record.contentTypes[0])
 }
 
+func TestResponsePostedTrace(t *testing.T) {
+	ts, record := runtimeAPIServer(`{"message": "I am craving tacos"}`, 1)
+	defer ts.Close()
+
+	var posted []handlertrace.ResponsePostedEvent
+	ctx := handlertrace.NewContext(context.Background(), handlertrace.HandlerTrace{
+		ResponsePosted: func(ctx context.Context, event handlertrace.ResponsePostedEvent) {
+			posted = append(posted, event)
+		},
+	})
+	handler := newHandler(func(event struct{ Message string }) (string, error) {
+		return event.Message, nil
+	}, WithContext(ctx))
+	endpoint := strings.Split(ts.URL, "://")[1]
+	_ = startRuntimeAPILoop(endpoint, handler)
+
+	require.Len(t, record.responses, 1)
+	sum := sha256.Sum256(record.responses[0])
+	require.Len(t, posted, 1)
+	assert.Equal(t, hex.EncodeToString(sum[:]), posted[0].SHA256)
+	assert.Equal(t, int64(len(record.responses[0])), posted[0].Bytes)
+	assert.Equal(t, posted[0].SHA256, record.responseSHA256s[0])
+}
+
+func TestResponsePostedTraceNotCalledOnError(t *testing.T) {
+	ts, record := runtimeAPIServer(``, 1)
+	defer ts.Close()
+
+	called := false
+	ctx := handlertrace.NewContext(context.Background(), handlertrace.HandlerTrace{
+		ResponsePosted: func(context.Context, handlertrace.ResponsePostedEvent) {
+			called = true
+		},
+	})
+	handler := newHandler(func() error {
+		return errors.New("boom")
+	}, WithContext(ctx))
+	endpoint := strings.Split(ts.URL, "://")[1]
+	_ = startRuntimeAPILoop(endpoint, handler)
+
+	require.Len(t, record.responses, 1)
+	assert.False(t, called)
+	sum := sha256.Sum256(record.responses[0])
+	assert.Equal(t, hex.EncodeToString(sum[:]), record.responseSHA256s[0])
+}
+
 type readCloser struct {
 	closed bool
 	reader *strings.Reader
@@ -327,6 +378,23 @@ func TestBinaryResponseDefaultContentType(t *testing.T) {
 	assert.Equal(t, contentTypeBytes, record.contentTypes[0])
 }
 
+func TestFunctionURLStreamingResponse(t *testing.T) {
+	ts, record := runtimeAPIServer(`{"rawPath": "/tacos"}`, 1)
+	defer ts.Close()
+
+	handler := NewHandler(func(request events.LambdaFunctionURLRequest) (*events.LambdaFunctionURLStreamingResponse, error) {
+		return &events.LambdaFunctionURLStreamingResponse{
+			StatusCode: http.StatusAccepted,
+			Headers:    map[string]string{"Content-Type": "text/plain"},
+			Body:       strings.NewReader("streaming " + request.RawPath),
+		}, nil
+	})
+	endpoint := strings.Split(ts.URL, "://")[1]
+	_ = startRuntimeAPILoop(endpoint, handler)
+	assert.Equal(t, `{"statusCode":202,"headers":{"Content-Type":"text/plain"}}`+"\x00\x00\x00\x00\x00\x00\x00\x00streaming /tacos", string(record.responses[0]))
+	assert.Equal(t, "application/vnd.awslambda.http-integration-response", record.contentTypes[0])
+}
+
 func TestBinaryResponseDoesNotLeakResources(t *testing.T) {
 	numResponses := 3
 	responses := make([]*readCloser, numResponses)
@@ -352,6 +420,29 @@ func TestBinaryResponseDoesNotLeakResources(t *testing.T) {
 	}
 }
 
+func TestBase64ClientContext(t *testing.T) {
+	metadata := defaultInvokeMetadata()
+	metadata.clientContext = base64.StdEncoding.EncodeToString([]byte(`{"client": {"app_title": "Tacos"}, "custom": {"customerId": "customer-abc"}}`))
+	badMetadata := defaultInvokeMetadata()
+	badMetadata.clientContext = "not base64!"
+	ts, record := runtimeAPIServer(`{}`, 2, metadata, badMetadata)
+	defer ts.Close()
+
+	handler := NewHandler(func(ctx context.Context) (string, error) {
+		lc, _ := lambdacontext.FromContext(ctx)
+		customerID, _ := lc.ClientContextCustomValue("customerId")
+		return lc.ClientContext.Client.AppTitle + " " + customerID, nil
+	})
+	endpoint := strings.Split(ts.URL, "://")[1]
+	_ = startRuntimeAPILoop(endpoint, handler)
+
+	assert.Equal(t, `"Tacos customer-abc"`, string(record.responses[0]))
+	assert.JSONEq(t, `{
+	    "errorMessage":"failed to decode base64 client context: illegal base64 data at input byte 3",
+	    "errorType":"errorString"
+	}`, string(record.responses[1]))
+}
+
 func TestContextDeserializationErrors(t *testing.T) {
 	badClientContext := defaultInvokeMetadata()
 	badClientContext.clientContext = `{ not json }`
@@ -402,12 +493,15 @@ func TestSafeMarshal_SerializationError(t *testing.T) {
 }
 
 type requestRecord struct {
-	lock         sync.Mutex
-	nGets        int
-	nPosts       int
-	responses    [][]byte
-	contentTypes []string
-	xrayCauses   []string
+	lock            sync.Mutex
+	nGets           int
+	nPosts          int
+	responses       [][]byte
+	contentTypes    []string
+	xrayCauses      []string
+	responseSHA256s []string
+	errorTypes      []string
+	errorBodies     []string
 }
 
 type eventMetadata struct {
@@ -489,6 +583,9 @@ func runtimeAPIServer(eventPayload string, failAfter int, overrides ...eventMeta
 			record.responses = append(record.responses, response.Bytes())
 			record.contentTypes = append(record.contentTypes, r.Header.Get("Content-Type"))
 			record.xrayCauses = append(record.xrayCauses, r.Header.Get(headerXRayErrorCause))
+			record.responseSHA256s = append(record.responseSHA256s, r.Trailer.Get(trailerResponseSHA256))
+			record.errorTypes = append(record.errorTypes, r.Trailer.Get(trailerLambdaErrorType))
+			record.errorBodies = append(record.errorBodies, r.Trailer.Get(trailerLambdaErrorBody))
 			record.lock.Unlock()
 			if done {
 				// all handlers are done, cancel the context to let the GET handler exit.
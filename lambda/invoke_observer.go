@@ -0,0 +1,91 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambdacontext"
+)
+
+// InvokeObserver observes the invocations of a handler, for example to emit metrics in the CloudWatch embedded
+// metric format. See WithInvokeObserver.
+type InvokeObserver interface {
+	// InvokeStart is called before the event of an invocation is decoded.
+	InvokeStart(ctx context.Context, info InvokeStartInfo)
+	// InvokeEnd is called once the handler returns and its response is encoded.
+	InvokeEnd(ctx context.Context, info InvokeEndInfo)
+}
+
+// InvokeStartInfo describes an invocation about to be handled.
+type InvokeStartInfo struct {
+	// RequestID is the AWS request ID of the invocation, or empty when the context has no LambdaContext.
+	RequestID string
+	// ColdStart is true for the first invocation of the handler, which is the first of the process when the
+	// handler is started with Start.
+	ColdStart bool
+	// PayloadSize is the size in bytes of the event.
+	PayloadSize int
+}
+
+// InvokeEndInfo describes the outcome of an invocation.
+type InvokeEndInfo struct {
+	// Duration is the time spent decoding the event, calling the handler, and encoding its response.
+	Duration time.Duration
+	// ResponseSize is the size in bytes of the response, or -1 when the response is streamed from an io.Reader
+	// of unknown length. It is 0 when the invocation failed.
+	ResponseSize int64
+	// Err is the error returned by the handler, including the errors decoding the event and encoding the response,
+	// or nil on success.
+	Err error
+}
+
+// WithInvokeObserver calls obs at the start and the end of each invocation of the handler. The end is observed
+// whether the invocation succeeds or fails, except when the handler panics.
+// Multiple observers are called in the order they were added at the start, and in the reverse order at the end.
+func WithInvokeObserver(obs InvokeObserver) Option {
+	return Option(func(h *handlerOptions) {
+		h.invokeObservers = append(h.invokeObservers, obs)
+	})
+}
+
+func observeInvokes(observers []InvokeObserver, f handlerFunc) handlerFunc {
+	var invoked int32
+	return func(ctx context.Context, payload []byte) (io.Reader, error) {
+		startInfo := InvokeStartInfo{
+			ColdStart:   atomic.CompareAndSwapInt32(&invoked, 0, 1),
+			PayloadSize: len(payload),
+		}
+		if lc, ok := lambdacontext.FromContext(ctx); ok {
+			startInfo.RequestID = lc.AwsRequestID
+		}
+		for _, obs := range observers {
+			obs.InvokeStart(ctx, startInfo)
+		}
+
+		start := time.Now()
+		response, err := f(ctx, payload)
+		endInfo := InvokeEndInfo{
+			Duration: time.Since(start),
+			Err:      err,
+		}
+		if err == nil {
+			endInfo.ResponseSize = responseSize(response)
+		}
+		for i := len(observers) - 1; i >= 0; i-- {
+			observers[i].InvokeEnd(ctx, endInfo)
+		}
+		return response, err
+	}
+}
+
+// responseSize returns the length of a buffered response, or -1.
+func responseSize(response io.Reader) int64 {
+	if response, ok := response.(interface{ Len() int }); ok {
+		return int64(response.Len())
+	}
+	return -1
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingObserver struct {
+	name   string
+	calls  *[]string
+	starts []InvokeStartInfo
+	ends   []InvokeEndInfo
+}
+
+func (o *recordingObserver) InvokeStart(_ context.Context, info InvokeStartInfo) {
+	*o.calls = append(*o.calls, o.name+" start")
+	o.starts = append(o.starts, info)
+}
+
+func (o *recordingObserver) InvokeEnd(_ context.Context, info InvokeEndInfo) {
+	*o.calls = append(*o.calls, o.name+" end")
+	o.ends = append(o.ends, info)
+}
+
+func TestInvokeObserver(t *testing.T) {
+	ts, record := runtimeAPIServer(`{"name": "gopher"}`, 2)
+	defer ts.Close()
+
+	var calls []string
+	first := &recordingObserver{name: "first", calls: &calls}
+	second := &recordingObserver{name: "second", calls: &calls}
+	invokes := 0
+	handler := newHandler(func(_ context.Context, e struct{ Name string }) (string, error) {
+		invokes++
+		if invokes > 1 {
+			return "", fmt.Errorf("invoke %d failed", invokes)
+		}
+		return "hello " + e.Name, nil
+	}, WithInvokeObserver(first), WithInvokeObserver(second))
+	endpoint := strings.Split(ts.URL, "://")[1]
+	_ = startRuntimeAPILoop(endpoint, handler)
+	require.Len(t, record.responses, 2)
+
+	assert.Equal(t, []string{
+		"first start", "second start", "second end", "first end",
+		"first start", "second start", "second end", "first end",
+	}, calls)
+	assert.Equal(t, first.starts, second.starts)
+	assert.Equal(t, first.ends, second.ends)
+
+	require.Len(t, first.starts, 2)
+	assert.Equal(t, InvokeStartInfo{RequestID: "dummyid", ColdStart: true, PayloadSize: 18}, first.starts[0])
+	assert.Equal(t, InvokeStartInfo{RequestID: "dummyid", ColdStart: false, PayloadSize: 18}, first.starts[1])
+
+	require.Len(t, first.ends, 2)
+	assert.NoError(t, first.ends[0].Err)
+	assert.Equal(t, int64(len(`"hello gopher"`)), first.ends[0].ResponseSize)
+	assert.True(t, first.ends[0].Duration > 0)
+	assert.EqualError(t, first.ends[1].Err, "invoke 2 failed")
+	assert.Equal(t, int64(0), first.ends[1].ResponseSize)
+}
+
+func TestInvokeObserverCodecErrors(t *testing.T) {
+	var calls []string
+	obs := &recordingObserver{name: "obs", calls: &calls}
+	handler := NewHandlerWithOptions(func(_ context.Context, event map[string]string) (interface{}, error) {
+		if event["respond"] == "func" {
+			return func() {}, nil
+		}
+		return io.MultiReader(strings.NewReader("streamed")), nil
+	}, WithInvokeObserver(obs))
+
+	_, err := handler.Invoke(context.Background(), []byte(`{"respond": `))
+	require.Error(t, err)
+	_, err = handler.Invoke(context.Background(), []byte(`{"respond": "func"}`))
+	require.Error(t, err)
+	_, err = handler.Invoke(context.Background(), []byte(`{}`))
+	require.NoError(t, err)
+
+	require.Len(t, obs.ends, 3)
+	assert.EqualError(t, obs.ends[0].Err, "unexpected EOF")
+	assert.Contains(t, obs.ends[1].Err.Error(), "unsupported type")
+	assert.NoError(t, obs.ends[2].Err)
+	assert.Equal(t, int64(-1), obs.ends[2].ResponseSize)
+	assert.Equal(t, []bool{true, false, false}, []bool{obs.starts[0].ColdStart, obs.starts[1].ColdStart, obs.starts[2].ColdStart})
+	assert.Equal(t, "", obs.starts[0].RequestID)
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+// Package invokeclient helps functions that invoke other Lambda functions. It does not depend on an
+// AWS SDK: it produces the values to set on an Invoke request, and decodes the values of its response.
+package invokeclient
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambda/messages"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+)
+
+// maxClientContextLength is the longest ClientContext accepted by Invoke, once base64 encoded.
+const maxClientContextLength = 3583
+
+// clientContext is the encoding of lambdacontext.ClientContext expected by Invoke.
+type clientContext struct {
+	Client lambdacontext.ClientApplication `json:"client"`
+	Custom map[string]string               `json:"custom,omitempty"`
+	Env    map[string]string               `json:"env,omitempty"`
+}
+
+// BuildClientContext returns the base64 encoded ClientContext to set on an Invoke request,
+// which the invoked function reads from lambdacontext.LambdaContext.ClientContext.
+// It returns an error when the encoded context is longer than the 3583 bytes Invoke accepts.
+func BuildClientContext(custom map[string]string, client lambdacontext.ClientApplication) (string, error) {
+	b, err := json.Marshal(clientContext{Client: client, Custom: custom})
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.StdEncoding.EncodeToString(b)
+	if len(encoded) > maxClientContextLength {
+		return "", fmt.Errorf("client context is %d bytes once encoded, more than the %d allowed", len(encoded), maxClientContextLength)
+	}
+	return encoded, nil
+}
+
+// InvokeError is an error reported by an invoked function.
+// The embedded InvokeResponse_Error can be returned by a handler to report the same error to its own caller.
+type InvokeError struct {
+	// FunctionError is the FunctionError of the Invoke response, Unhandled for errors reported by the runtime.
+	FunctionError string
+	messages.InvokeResponse_Error
+}
+
+func (e *InvokeError) Error() string {
+	if e.Type == "" {
+		return e.Message
+	}
+	return e.Type + ": " + e.Message
+}
+
+func (e *InvokeError) Unwrap() error {
+	return e.InvokeResponse_Error
+}
+
+// DecodeInvokeError returns the error reported by an invoked function, or nil when functionError,
+// the FunctionError of the Invoke response, is empty. The payload of the response is decoded as the
+// error envelope of the Lambda runtimes, or used as the message when it is not one.
+func DecodeInvokeError(payload []byte, functionError string) error {
+	if functionError == "" {
+		return nil
+	}
+	invokeErr := &InvokeError{FunctionError: functionError}
+	if err := json.Unmarshal(payload, &invokeErr.InvokeResponse_Error); err != nil || invokeErr.Message == "" && invokeErr.Type == "" {
+		invokeErr.InvokeResponse_Error = messages.InvokeResponse_Error{Message: string(payload)}
+	}
+	return invokeErr
+}
+
+// BudgetedContext returns a context whose deadline is fraction of the time remaining until the deadline
+// of ctx, to pass a sub-deadline to a downstream invocation and keep time to handle its outcome.
+// A fraction outside of (0, 1] is treated as 1. When ctx has no deadline, the context only adds a cancel function.
+func BudgetedContext(ctx context.Context, fraction float64) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+	if fraction <= 0 || fraction > 1 {
+		fraction = 1
+	}
+	budget := time.Duration(float64(time.Until(deadline)) * fraction)
+	return context.WithDeadline(ctx, time.Now().Add(budget))
+}
@@ -0,0 +1,163 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package invokeclient
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil" //nolint: staticcheck
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambda/messages"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildClientContext(t *testing.T) {
+	client := lambdacontext.ClientApplication{
+		InstallationID: "install-1",
+		AppTitle:       "orders",
+		AppVersionCode: "1.2.3",
+		AppPackageName: "com.example.orders",
+	}
+	custom := map[string]string{"tenant": "acme", "correlationId": "corr-123"}
+
+	encoded, err := BuildClientContext(custom, client)
+	require.NoError(t, err)
+
+	// the invoked function's runtime receives the decoded JSON, and parses it into a lambdacontext.ClientContext
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	require.NoError(t, err)
+	var parsed lambdacontext.ClientContext
+	require.NoError(t, json.Unmarshal(decoded, &parsed))
+	assert.Equal(t, lambdacontext.ClientContext{Client: client, Custom: custom}, parsed)
+	assert.JSONEq(t, `{
+		"client": {"installation_id": "install-1", "app_title": "orders", "app_version_code": "1.2.3", "app_package_name": "com.example.orders"},
+		"custom": {"tenant": "acme", "correlationId": "corr-123"}
+	}`, string(decoded))
+}
+
+func TestBuildClientContextTooLong(t *testing.T) {
+	_, err := BuildClientContext(map[string]string{"blob": strings.Repeat("x", 3000)}, lambdacontext.ClientApplication{})
+	assert.EqualError(t, err, "client context is 4152 bytes once encoded, more than the 3583 allowed")
+}
+
+func TestDecodeInvokeError(t *testing.T) {
+	for name, test := range map[string]struct {
+		file          string
+		payload       string
+		functionError string
+		expected      *InvokeError
+		expectedError string
+	}{
+		"go error": {
+			file:          "go-error.json",
+			functionError: "Unhandled",
+			expected: &InvokeError{FunctionError: "Unhandled", InvokeResponse_Error: messages.InvokeResponse_Error{
+				Message: "order 42 not found",
+				Type:    "errorString",
+			}},
+			expectedError: "errorString: order 42 not found",
+		},
+		"go panic": {
+			file:          "go-panic.json",
+			functionError: "Unhandled",
+			expected: &InvokeError{FunctionError: "Unhandled", InvokeResponse_Error: messages.InvokeResponse_Error{
+				Message: "runtime error: index out of range [3] with length 3",
+				Type:    "boundsError",
+				StackTrace: []*messages.InvokeResponse_Error_StackFrame{
+					{Path: "github.com/aws/aws-lambda-go/lambda/errors.go", Line: 39, Label: "lambdaPanicResponse"},
+					{Path: "github.com/example/orders/main.go", Line: 27, Label: "handle"},
+				},
+			}},
+			expectedError: "boundsError: runtime error: index out of range [3] with length 3",
+		},
+		"timeout": {
+			file:          "timeout.json",
+			functionError: "Unhandled",
+			expected: &InvokeError{FunctionError: "Unhandled", InvokeResponse_Error: messages.InvokeResponse_Error{
+				Message: "2026-10-16T11:36:00.000Z 2d7b1c3e-5f4a-4b8e-9c1d-0e2f3a4b5c6d Task timed out after 3.00 seconds",
+			}},
+			expectedError: "2026-10-16T11:36:00.000Z 2d7b1c3e-5f4a-4b8e-9c1d-0e2f3a4b5c6d Task timed out after 3.00 seconds",
+		},
+		"runtime exit": {
+			file:          "runtime-exit.json",
+			functionError: "Unhandled",
+			expected: &InvokeError{FunctionError: "Unhandled", InvokeResponse_Error: messages.InvokeResponse_Error{
+				Message: "RequestId: 2d7b1c3e-5f4a-4b8e-9c1d-0e2f3a4b5c6d Error: Runtime exited with error: exit status 2",
+				Type:    "Runtime.ExitError",
+			}},
+			expectedError: "Runtime.ExitError: RequestId: 2d7b1c3e-5f4a-4b8e-9c1d-0e2f3a4b5c6d Error: Runtime exited with error: exit status 2",
+		},
+		"not an error envelope": {
+			payload:       `upstream connect error`,
+			functionError: "Handled",
+			expected: &InvokeError{FunctionError: "Handled", InvokeResponse_Error: messages.InvokeResponse_Error{
+				Message: "upstream connect error",
+			}},
+			expectedError: "upstream connect error",
+		},
+		"json that is not an error envelope": {
+			payload:       `{"status":"failed"}`,
+			functionError: "Unhandled",
+			expected: &InvokeError{FunctionError: "Unhandled", InvokeResponse_Error: messages.InvokeResponse_Error{
+				Message: `{"status":"failed"}`,
+			}},
+			expectedError: `{"status":"failed"}`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			payload := []byte(test.payload)
+			if test.file != "" {
+				var err error
+				payload, err = ioutil.ReadFile("testdata/" + test.file)
+				require.NoError(t, err)
+			}
+
+			err := DecodeInvokeError(payload, test.functionError)
+			var invokeErr *InvokeError
+			require.True(t, errors.As(err, &invokeErr))
+			assert.Equal(t, test.expected, invokeErr)
+			assert.EqualError(t, err, test.expectedError)
+
+			var responseErr messages.InvokeResponse_Error
+			require.True(t, errors.As(err, &responseErr))
+			assert.Equal(t, test.expected.InvokeResponse_Error, responseErr)
+		})
+	}
+}
+
+func TestDecodeInvokeErrorSuccess(t *testing.T) {
+	assert.NoError(t, DecodeInvokeError([]byte(`{"errorMessage":"not an error"}`), ""))
+}
+
+func TestBudgetedContext(t *testing.T) {
+	parent, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	parentDeadline, _ := parent.Deadline()
+
+	ctx, cancel := BudgetedContext(parent, 0.5)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(5*time.Second), deadline, time.Second)
+
+	for _, fraction := range []float64{0, -1, 2} {
+		ctx, cancel := BudgetedContext(parent, fraction)
+		deadline, ok := ctx.Deadline()
+		cancel()
+		require.True(t, ok)
+		assert.WithinDuration(t, parentDeadline, deadline, time.Millisecond)
+	}
+
+	ctx, cancel = BudgetedContext(context.Background(), 0.5)
+	_, ok = ctx.Deadline()
+	assert.False(t, ok)
+	cancel()
+	assert.Error(t, ctx.Err())
+}
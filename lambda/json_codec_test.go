@@ -0,0 +1,95 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/lambda/handlertrace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTMLEscaping(t *testing.T) {
+	echo := func(event string) (string, error) { return event, nil }
+	payload := []byte(`"<b>tacos & burritos</b>"`)
+	for _, tc := range []struct {
+		name     string
+		options  []Option
+		expected string
+	}{
+		{"default", nil, `"<b>tacos & burritos</b>"`},
+		{"with HTML escaping", []Option{WithSetEscapeHTML(true)}, `"\u003cb\u003etacos \u0026 burritos\u003c/b\u003e"`},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			response, err := NewHandlerWithOptions(echo, tc.options...).Invoke(context.Background(), payload)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, string(response))
+		})
+	}
+}
+
+func TestJSONEncoderAndDecoder(t *testing.T) {
+	type event struct {
+		Name string `json:"name"`
+	}
+	var encoded, decoded []interface{}
+	encoder := func(v interface{}) ([]byte, error) {
+		encoded = append(encoded, v)
+		b, err := json.Marshal(v)
+		return append(b, '\n'), err
+	}
+	decoder := func(data []byte, v interface{}) error {
+		decoded = append(decoded, string(data))
+		return json.Unmarshal(data, v)
+	}
+	var traced []interface{}
+	ctx := handlertrace.NewContext(context.Background(), handlertrace.HandlerTrace{
+		RequestEvent:  func(_ context.Context, event interface{}) { traced = append(traced, event) },
+		ResponseEvent: func(_ context.Context, response interface{}) { traced = append(traced, response) },
+	})
+
+	handler := NewHandlerWithOptions(func(e event) (map[string]string, error) {
+		return map[string]string{"greeting": "<hello " + e.Name + ">"}, nil
+	},
+		WithJSONEncoder(encoder),
+		WithJSONDecoder(decoder),
+		// do not apply to the encoder and decoder
+		WithSetIndent("", "  "),
+		WithDisallowUnknownFields(true),
+	)
+	response, err := handler.Invoke(ctx, []byte(`{"name": "gopher", "other": 1}`))
+	require.NoError(t, err)
+
+	// the output of the encoder is posted as is
+	assert.Equal(t, "{\"greeting\":\"\\u003chello gopher\\u003e\"}\n", string(response))
+	assert.Equal(t, []interface{}{`{"name": "gopher", "other": 1}`}, decoded)
+	assert.Equal(t, []interface{}{map[string]string{"greeting": "<hello gopher>"}}, encoded)
+	assert.Equal(t, []interface{}{event{Name: "gopher"}, map[string]string{"greeting": "<hello gopher>"}}, traced)
+}
+
+func TestJSONEncoderAndDecoderErrors(t *testing.T) {
+	errEncode := errors.New("cannot encode")
+	errDecode := errors.New("cannot decode")
+	failEncode := WithJSONEncoder(func(interface{}) ([]byte, error) { return nil, errEncode })
+	failDecode := WithJSONDecoder(func([]byte, interface{}) error { return errDecode })
+
+	called := false
+	_, err := NewHandlerWithOptions(func(string) error { called = true; return nil }, failDecode).Invoke(context.Background(), []byte(`""`))
+	assert.Equal(t, errDecode, err)
+	assert.False(t, called)
+
+	_, err = NewHandlerWithOptions(func() (string, error) { return "", nil }, failEncode).Invoke(context.Background(), []byte(`{}`))
+	assert.Equal(t, errEncode, err)
+
+	// a reader the encoder cannot encode is returned as is
+	response, err := NewHandlerWithOptions(func() (io.Reader, error) { return strings.NewReader("raw"), nil }, failEncode).Invoke(context.Background(), []byte(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, "raw", string(response))
+}
@@ -0,0 +1,203 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil" // nolint:staticcheck
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambda/handlertrace"
+	"github.com/aws/aws-lambda-go/lambda/messages"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+)
+
+// LocalInvokePath is the path of the invocations served by StartLocal, which is the path of the Lambda Runtime
+// Interface Emulator.
+const LocalInvokePath = "/2015-03-31/functions/function/invocations"
+
+const (
+	headerFunctionError       = "X-Amz-Function-Error"
+	headerClientContextInvoke = "X-Amz-Client-Context"
+	headerTraceIDInvoke       = "X-Amzn-Trace-Id"
+	defaultLocalTimeout       = 300 * time.Second
+	localFunctionAccount      = "012345678912"
+)
+
+// StartLocal serves the invocations of handler over HTTP on addr, to test it without deploying it or running the
+// Lambda Runtime Interface Emulator. The body of a POST request to LocalInvokePath is the event of an invocation.
+// Like Start, the handler must satisfy the rules documented by Start, and is wrapped with the options. Each
+// invocation runs through the same steps as with the Lambda runtime API: the canonical log line, the handlertrace
+// callbacks, the resources prepared for the next invocation, and the _X_AMZN_TRACE_ID of the X-Amzn-Trace-Id header.
+//
+// The context of each invocation has a new request ID, and a deadline after the timeout in seconds of the
+// AWS_LAMBDA_FUNCTION_TIMEOUT environment variable, 300 by default. Its function ARN is built from the
+// AWS_REGION and AWS_LAMBDA_FUNCTION_NAME environment variables. Its client context is decoded from the
+// X-Amz-Client-Context header of the request, as with the Invoke API.
+//
+// The response of the handler is the body of the HTTP response. A response returned as an io.Reader is streamed with
+// chunked transfer encoding. A function error is the JSON body of the response, with the X-Amz-Function-Error header,
+// or is reported in the trailers of a response that already started streaming, as by the Lambda runtime API.
+// StartLocal blocks, and returns the error of the HTTP server.
+func StartLocal(addr string, handler interface{}, options ...Option) error {
+	log.Printf("serving local invocations on http://%s%s", addr, LocalInvokePath)
+	return http.ListenAndServe(addr, newLocalServer(newHandler(handler, options...)))
+}
+
+func newLocalServer(handler *handlerOptions) *localServer {
+	timeout := defaultLocalTimeout
+	if seconds, err := strconv.Atoi(os.Getenv("AWS_LAMBDA_FUNCTION_TIMEOUT")); err == nil && seconds > 0 {
+		timeout = time.Duration(seconds) * time.Second
+	}
+	return &localServer{
+		handler:     handler,
+		timeout:     timeout,
+		functionARN: localFunctionARN(),
+	}
+}
+
+func localFunctionARN() string {
+	region := os.Getenv("AWS_REGION")
+	if region == "" {
+		region = "us-east-1"
+	}
+	name := os.Getenv("AWS_LAMBDA_FUNCTION_NAME")
+	if name == "" {
+		name = "function"
+	}
+	return fmt.Sprintf("arn:aws:lambda:%s:%s:function:%s", region, localFunctionAccount, name)
+}
+
+type localServer struct {
+	handler     *handlerOptions
+	timeout     time.Duration
+	functionARN string
+}
+
+func (s *localServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != LocalInvokePath {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	payload, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to read the event: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	clientContext, err := lambdacontext.ParseClientContext(r.Header.Get(headerClientContextInvoke))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(s.handler.baseContext, s.timeout)
+	defer cancel()
+	ctx, cancelCause := withCancelCause(ctx)
+	defer cancelCause(nil)
+	lc := lambdacontext.LambdaContext{
+		AwsRequestID:       newLocalRequestID(),
+		InvokedFunctionArn: s.functionARN,
+		ClientContext:      clientContext,
+	}
+	ctx = lambdacontext.NewContext(ctx, &lc)
+
+	traceID := r.Header.Get(headerTraceIDInvoke)
+	if err := runInvoke(ctx, cancelCause, s.handler, payload, traceID, localResponder{w}); err != nil {
+		log.Printf("local invocation %s: %v", lc.AwsRequestID, err)
+	}
+}
+
+// localResponder writes the outcome of a local invocation as the HTTP response.
+type localResponder struct {
+	w http.ResponseWriter
+}
+
+func (l localResponder) success(body io.Reader, contentType string, abort func(cause error)) (handlertrace.ResponsePostedEvent, error) {
+	l.w.Header().Set("Content-Type", contentType)
+	l.w.Header().Set("Trailer", trailerLambdaErrorType+", "+trailerLambdaErrorBody)
+	reader := &readErrorReader{reader: body}
+	hash := sha256.New()
+	written, err := io.Copy(flushWriter{l.w}, io.TeeReader(reader, hash))
+	posted := handlertrace.ResponsePostedEvent{SHA256: hex.EncodeToString(hash.Sum(nil)), Bytes: written}
+	switch {
+	case reader.err != nil && written == 0:
+		writeLocalFailure(l.w, lambdaErrorResponse(reader.err))
+	case reader.err != nil:
+		// the status is already sent, report the error in the trailers of the truncated response
+		lambdaErr := lambdaErrorResponse(reader.err)
+		log.Printf("%s", safeMarshal(lambdaErr))
+		l.w.Header().Set(trailerLambdaErrorType, lambdaErr.Type)
+		l.w.Header().Set(trailerLambdaErrorBody, base64.StdEncoding.EncodeToString(safeMarshal(lambdaErr)))
+	case err != nil:
+		// the client of the local invocation went away
+		abort(ErrClientDisconnected)
+		posted.ClientDisconnected = true
+		return posted, err
+	}
+	return posted, nil
+}
+
+func (l localResponder) failure(invokeErr *messages.InvokeResponse_Error) error {
+	writeLocalFailure(l.w, invokeErr)
+	return nil
+}
+
+// readErrorReader keeps the error reading the response, to tell it from an error writing it.
+type readErrorReader struct {
+	reader io.Reader
+	err    error
+}
+
+func (r *readErrorReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if err != nil && err != io.EOF {
+		r.err = err
+	}
+	return n, err
+}
+
+func writeLocalFailure(w http.ResponseWriter, invokeErr *messages.InvokeResponse_Error) {
+	errorPayload := safeMarshal(invokeErr)
+	log.Printf("%s", errorPayload)
+	w.Header().Set("Content-Type", contentTypeJSON)
+	w.Header().Set(headerFunctionError, "Unhandled")
+	_, _ = w.Write(errorPayload)
+}
+
+// flushWriter flushes each write, so that the response is streamed with chunked transfer encoding.
+type flushWriter struct {
+	w http.ResponseWriter
+}
+
+func (f flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	if flusher, ok := f.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return n, err
+}
+
+// newLocalRequestID returns a random version 4 UUID.
+func newLocalRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,255 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil" //nolint: staticcheck
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda/handlertrace"
+	"github.com/aws/aws-lambda-go/lambda/messages"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// localInvoke starts a local server of handler on a random port, and invokes it with payload.
+func localInvoke(t *testing.T, payload []byte, handler interface{}, options ...Option) (*http.Response, string) {
+	ts := httptest.NewServer(newLocalServer(newHandler(handler, options...)))
+	defer ts.Close()
+	resp, err := http.Post(ts.URL+LocalInvokePath, "application/json", bytes.NewReader(payload))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, string(body)
+}
+
+func setenv(t *testing.T, key, value string) func() {
+	previous, ok := os.LookupEnv(key)
+	require.NoError(t, os.Setenv(key, value))
+	return func() {
+		if ok {
+			os.Setenv(key, previous)
+		} else {
+			os.Unsetenv(key)
+		}
+	}
+}
+
+func TestStartLocalS3Event(t *testing.T) {
+	defer setenv(t, "AWS_REGION", "eu-west-1")()
+	defer setenv(t, "AWS_LAMBDA_FUNCTION_NAME", "thumbnails")()
+	defer setenv(t, "AWS_LAMBDA_FUNCTION_TIMEOUT", "10")()
+	payload, err := ioutil.ReadFile("../events/testdata/s3-event.json")
+	require.NoError(t, err)
+
+	var traced []interface{}
+	var middleware []string
+	handler := func(ctx context.Context, event events.S3Event) ([]string, error) {
+		lc, ok := lambdacontext.FromContext(ctx)
+		require.True(t, ok)
+		assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), lc.AwsRequestID)
+		assert.Equal(t, "arn:aws:lambda:eu-west-1:012345678912:function:thumbnails", lc.InvokedFunctionArn)
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		assert.WithinDuration(t, time.Now().Add(10*time.Second), deadline, time.Second)
+
+		var keys []string
+		for _, record := range event.Records {
+			keys = append(keys, record.S3.Object.Key)
+		}
+		return keys, nil
+	}
+	resp, body := localInvoke(t, payload, handler,
+		WithContext(handlertrace.NewContext(context.Background(), handlertrace.HandlerTrace{
+			RequestEvent:  func(_ context.Context, event interface{}) { traced = append(traced, event) },
+			ResponseEvent: func(_ context.Context, response interface{}) { traced = append(traced, response) },
+		})),
+		WithMiddleware(func(next InvokeFunc) InvokeFunc {
+			return func(ctx context.Context, payload []byte) (io.Reader, error) {
+				middleware = append(middleware, "called")
+				return next(ctx, payload)
+			}
+		}),
+	)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get(headerFunctionError))
+	assert.Equal(t, `["Happy%20Face.jpg"]`, body)
+	assert.Equal(t, []string{"called"}, middleware)
+	require.Len(t, traced, 2)
+	assert.Equal(t, []string{"Happy%20Face.jpg"}, traced[1])
+}
+
+type localTestError struct{}
+
+func (localTestError) Error() string { return "the bucket is gone" }
+
+func TestStartLocalError(t *testing.T) {
+	for name, handler := range map[string]interface{}{
+		"error": func() error { return localTestError{} },
+		"panic": func() error { panic(localTestError{}) },
+		"reader": func() (io.Reader, error) {
+			return failingReader{localTestError{}}, nil
+		},
+	} {
+		handler := handler
+		t.Run(name, func(t *testing.T) {
+			resp, body := localInvoke(t, []byte(`{}`), handler)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, "Unhandled", resp.Header.Get(headerFunctionError))
+			assert.Equal(t, contentTypeJSON, resp.Header.Get("Content-Type"))
+			var invokeErr messages.InvokeResponse_Error
+			require.NoError(t, json.Unmarshal([]byte(body), &invokeErr))
+			assert.Equal(t, "the bucket is gone", invokeErr.Message)
+			assert.Equal(t, "localTestError", invokeErr.Type)
+		})
+	}
+}
+
+type failingReader struct{ err error }
+
+func (r failingReader) Read([]byte) (int, error) { return 0, r.err }
+
+func TestStartLocalStreaming(t *testing.T) {
+	chunks := []string{"<html>", "<body>streamed</body>", "</html>"}
+	handler := func() (io.Reader, error) {
+		r, w := io.Pipe()
+		go func() {
+			for _, chunk := range chunks {
+				_, _ = w.Write([]byte(chunk))
+			}
+			_ = w.Close()
+		}()
+		return r, nil
+	}
+	resp, body := localInvoke(t, []byte(`{}`), handler)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"chunked"}, resp.TransferEncoding)
+	assert.Equal(t, contentTypeBytes, resp.Header.Get("Content-Type"))
+	assert.Equal(t, strings.Join(chunks, ""), body)
+
+	resp, body = localInvoke(t, []byte(`{}`), func() (*events.LambdaFunctionURLStreamingResponse, error) {
+		return &events.LambdaFunctionURLStreamingResponse{StatusCode: 201, Body: strings.NewReader("created")}, nil
+	})
+	assert.Equal(t, "application/vnd.awslambda.http-integration-response", resp.Header.Get("Content-Type"))
+	assert.True(t, strings.HasSuffix(body, "created"))
+}
+
+func TestStartLocalRequests(t *testing.T) {
+	ts := httptest.NewServer(newLocalServer(newHandler(func() error { return nil })))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + LocalInvokePath)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	resp, err = http.Post(ts.URL+"/invoke", "application/json", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestStartLocalListenError(t *testing.T) {
+	err := StartLocal("not an address", func() error { return errors.New("unreachable") })
+	assert.Error(t, err)
+}
+
+func TestStartLocalStreamingError(t *testing.T) {
+	ts := httptest.NewServer(newLocalServer(newHandler(func() (StreamingResponse, error) {
+		return StreamingResponse{ContentType: "text/csv", Body: io.MultiReader(strings.NewReader("id\n1\n"), failingReader{localTestError{}})}, nil
+	})))
+	defer ts.Close()
+	resp, err := http.Post(ts.URL+LocalInvokePath, "application/json", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, "text/csv", resp.Header.Get("Content-Type"))
+	assert.Empty(t, resp.Header.Get(headerFunctionError))
+	assert.Equal(t, "id\n1\n", string(body))
+	assert.Equal(t, "localTestError", resp.Trailer.Get(trailerLambdaErrorType))
+	assert.NotEmpty(t, resp.Trailer.Get(trailerLambdaErrorBody))
+}
+
+func TestStartLocalClientContext(t *testing.T) {
+	ts := httptest.NewServer(newLocalServer(newHandler(func(ctx context.Context) (string, error) {
+		lc, _ := lambdacontext.FromContext(ctx)
+		value, _ := lc.ClientContextCustomValue("customerId")
+		return value, nil
+	})))
+	defer ts.Close()
+
+	for _, tc := range []struct {
+		header         string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{base64.StdEncoding.EncodeToString([]byte(`{"custom": {"customerId": "customer-abc"}}`)), http.StatusOK, `"customer-abc"`},
+		{"", http.StatusOK, `""`},
+		{"not base64!", http.StatusBadRequest, "failed to decode base64 client context: illegal base64 data at input byte 3\n"},
+	} {
+		req, err := http.NewRequest(http.MethodPost, ts.URL+LocalInvokePath, strings.NewReader(`{}`))
+		require.NoError(t, err)
+		req.Header.Set("X-Amz-Client-Context", tc.header)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		require.NoError(t, err)
+		assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.expectedBody, string(body))
+	}
+}
+
+func TestStartLocalInvokePipeline(t *testing.T) {
+	var entries []*CanonicalEntry
+	var posted []handlertrace.ResponsePostedEvent
+	var traceIDs []interface{}
+	ts := httptest.NewServer(newLocalServer(newHandler(func(ctx context.Context) (string, error) {
+		traceIDs = append(traceIDs, ctx.Value("x-amzn-trace-id"))
+		lambdacontext.AddCanonicalField(ctx, "handled", true)
+		return "ok", nil
+	},
+		WithCanonicalLog(func(_ context.Context, entry *CanonicalEntry) { entries = append(entries, entry) }),
+		WithContext(handlertrace.NewContext(context.Background(), handlertrace.HandlerTrace{
+			ResponsePosted: func(_ context.Context, event handlertrace.ResponsePostedEvent) { posted = append(posted, event) },
+		})),
+	)))
+	req, err := http.NewRequest(http.MethodPost, ts.URL+LocalInvokePath, strings.NewReader(`{}`))
+	require.NoError(t, err)
+	req.Header.Set("X-Amzn-Trace-Id", "Root=1-5759e988-bd862e3fe1be46a994272793")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	ts.Close()
+
+	assert.Equal(t, `"ok"`, string(body))
+	assert.Equal(t, []interface{}{"Root=1-5759e988-bd862e3fe1be46a994272793"}, traceIDs)
+	require.Len(t, posted, 1)
+	assert.Equal(t, int64(len(`"ok"`)), posted[0].Bytes)
+	assert.NotEmpty(t, posted[0].SHA256)
+	require.Len(t, entries, 1)
+	assert.Equal(t, canonicalOutcomeSuccess, entries[0].Outcome)
+	assert.Equal(t, "Root=1-5759e988-bd862e3fe1be46a994272793", entries[0].TraceID)
+	assert.Equal(t, int64(len(`"ok"`)), entries[0].ResponseBytes)
+	assert.Equal(t, true, entries[0].Fields["handled"])
+}
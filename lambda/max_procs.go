@@ -0,0 +1,95 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"fmt"
+	"io/ioutil" //nolint: staticcheck
+	"log"
+	"math"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// memoryPerVCPU is the function memory, in MB, at which Lambda allocates one full vCPU.
+// CPU is allocated in proportion to memory, up to 6 vCPUs at 10240 MB.
+const memoryPerVCPU = 1769
+
+// maxProcsCurve maps the function memory size in MB to a GOMAXPROCS value.
+// This allows tests to substitute their own curve.
+var maxProcsCurve = func(memoryMB int) int {
+	procs := int(math.Ceil(float64(memoryMB) / memoryPerVCPU))
+	if procs < 1 {
+		return 1
+	}
+	if procs > 6 {
+		return 6
+	}
+	return procs
+}
+
+// cgroupCPUMaxPath is the cgroup v2 CPU quota file, read in preference to the memory size when present.
+// This allows tests to point at a temp file.
+var cgroupCPUMaxPath = "/sys/fs/cgroup/cpu.max"
+
+// WithAutoMaxProcs sets GOMAXPROCS during init to match the vCPUs allocated to the function, rather than
+// the core count of the host, which reduces scheduler contention for functions with less than one full vCPU.
+// The value is taken from the cgroup CPU quota when available, and otherwise derived from AWS_LAMBDA_FUNCTION_MEMORY_SIZE.
+// The decision is logged. This option does nothing when the GOMAXPROCS environment variable is set.
+func WithAutoMaxProcs() Option {
+	return Option(func(h *handlerOptions) {
+		h.autoMaxProcs = true
+	})
+}
+
+func setAutoMaxProcs() {
+	if v, ok := os.LookupEnv("GOMAXPROCS"); ok {
+		log.Printf("lambda: GOMAXPROCS=%s is set in the environment, leaving it unchanged", v)
+		return
+	}
+	procs, source, ok := autoMaxProcs()
+	if !ok {
+		return
+	}
+	if cpus := runtime.NumCPU(); procs > cpus {
+		procs = cpus
+	}
+	previous := runtime.GOMAXPROCS(procs)
+	log.Printf("lambda: set GOMAXPROCS to %d from %s, was %d", procs, source, previous)
+}
+
+// autoMaxProcs returns the GOMAXPROCS value for the function, and a description of where it came from.
+func autoMaxProcs() (int, string, bool) {
+	if procs, ok := cgroupMaxProcs(cgroupCPUMaxPath); ok {
+		return procs, "cgroup " + cgroupCPUMaxPath, true
+	}
+	memoryMB, err := strconv.Atoi(os.Getenv("AWS_LAMBDA_FUNCTION_MEMORY_SIZE"))
+	if err != nil || memoryMB <= 0 {
+		return 0, "", false
+	}
+	return maxProcsCurve(memoryMB), fmt.Sprintf("AWS_LAMBDA_FUNCTION_MEMORY_SIZE=%d", memoryMB), true
+}
+
+// cgroupMaxProcs reads a cgroup v2 cpu.max file, formatted as "<quota> <period>" or "max <period>",
+// and returns the quota in whole CPUs, rounded up.
+func cgroupMaxProcs(path string) (int, bool) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+	fields := strings.Fields(string(b))
+	if len(fields) != 2 || fields[0] == "max" {
+		return 0, false
+	}
+	quota, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil || quota <= 0 {
+		return 0, false
+	}
+	period, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil || period <= 0 {
+		return 0, false
+	}
+	return int(math.Ceil(quota / period)), true
+}
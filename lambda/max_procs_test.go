@@ -0,0 +1,114 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+//go:build go1.17
+// +build go1.17
+
+package lambda
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxProcsCurve(t *testing.T) {
+	for memoryMB, expected := range map[int]int{
+		128:   1,
+		1024:  1,
+		1769:  1,
+		1770:  2,
+		3008:  2,
+		5308:  4,
+		10240: 6,
+		20000: 6,
+	} {
+		assert.Equal(t, expected, maxProcsCurve(memoryMB), "memory size %d", memoryMB)
+	}
+}
+
+func TestAutoMaxProcsFromMemory(t *testing.T) {
+	cgroupCPUMaxPath = filepath.Join(t.TempDir(), "missing")
+	defer func() { cgroupCPUMaxPath = "/sys/fs/cgroup/cpu.max" }()
+
+	t.Setenv("AWS_LAMBDA_FUNCTION_MEMORY_SIZE", "3008")
+	procs, source, ok := autoMaxProcs()
+	assert.True(t, ok)
+	assert.Equal(t, 2, procs)
+	assert.Equal(t, "AWS_LAMBDA_FUNCTION_MEMORY_SIZE=3008", source)
+
+	t.Setenv("AWS_LAMBDA_FUNCTION_MEMORY_SIZE", "")
+	_, _, ok = autoMaxProcs()
+	assert.False(t, ok)
+}
+
+func TestAutoMaxProcsCurveOverride(t *testing.T) {
+	cgroupCPUMaxPath = filepath.Join(t.TempDir(), "missing")
+	defaultCurve := maxProcsCurve
+	defer func() {
+		cgroupCPUMaxPath = "/sys/fs/cgroup/cpu.max"
+		maxProcsCurve = defaultCurve
+	}()
+	maxProcsCurve = func(memoryMB int) int { return memoryMB / 100 }
+
+	t.Setenv("AWS_LAMBDA_FUNCTION_MEMORY_SIZE", "512")
+	procs, _, ok := autoMaxProcs()
+	assert.True(t, ok)
+	assert.Equal(t, 5, procs)
+}
+
+func TestAutoMaxProcsFromCgroup(t *testing.T) {
+	dir := t.TempDir()
+	defer func() { cgroupCPUMaxPath = "/sys/fs/cgroup/cpu.max" }()
+	t.Setenv("AWS_LAMBDA_FUNCTION_MEMORY_SIZE", "10240")
+
+	for content, expected := range map[string]int{
+		"150000 100000\n": 2,
+		"100000 100000\n": 1,
+		"50000 100000\n":  1,
+		"max 100000\n":    6, // no quota, falls back to the memory size
+		"garbage\n":       6,
+	} {
+		cgroupCPUMaxPath = filepath.Join(dir, "cpu.max")
+		require.NoError(t, os.WriteFile(cgroupCPUMaxPath, []byte(content), 0600))
+		procs, _, ok := autoMaxProcs()
+		assert.True(t, ok)
+		assert.Equal(t, expected, procs, "cpu.max %q", content)
+	}
+}
+
+func TestWithAutoMaxProcs(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(0))
+	cgroupCPUMaxPath = filepath.Join(t.TempDir(), "missing")
+	defer func() { cgroupCPUMaxPath = "/sys/fs/cgroup/cpu.max" }()
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	t.Setenv("AWS_LAMBDA_FUNCTION_MEMORY_SIZE", "128")
+	runtime.GOMAXPROCS(4)
+	newHandler(func() {}, WithAutoMaxProcs())
+	assert.Equal(t, 1, runtime.GOMAXPROCS(0))
+	assert.Contains(t, logs.String(), "set GOMAXPROCS to 1 from AWS_LAMBDA_FUNCTION_MEMORY_SIZE=128")
+}
+
+func TestWithAutoMaxProcsExplicitEnv(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(0))
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	t.Setenv("GOMAXPROCS", "3")
+	t.Setenv("AWS_LAMBDA_FUNCTION_MEMORY_SIZE", "128")
+	runtime.GOMAXPROCS(3)
+	newHandler(func() {}, WithAutoMaxProcs())
+	assert.Equal(t, 3, runtime.GOMAXPROCS(0))
+	assert.Contains(t, logs.String(), "GOMAXPROCS=3 is set in the environment")
+}
@@ -38,6 +38,7 @@ type InvokeResponse_Error struct {
 	Message    string                             `json:"errorMessage"`
 	Type       string                             `json:"errorType"`
 	StackTrace []*InvokeResponse_Error_StackFrame `json:"stackTrace,omitempty"`
+	Cause      []*InvokeResponse_Error_Cause      `json:"cause,omitempty"`
 	ShouldExit bool                               `json:"-"`
 }
 
@@ -51,3 +52,9 @@ type InvokeResponse_Error_StackFrame struct {
 	Line  int32  `json:"line"`
 	Label string `json:"label"`
 }
+
+//nolint:staticcheck
+type InvokeResponse_Error_Cause struct {
+	Message string `json:"errorMessage"`
+	Type    string `json:"errorType"`
+}
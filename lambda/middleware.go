@@ -0,0 +1,38 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"context"
+	"io"
+)
+
+// InvokeFunc is an invocation of a handler, with the raw event payload. The response is the raw response payload,
+// which is streamed when it is not buffered already. Its ContentType and Close methods, if any, are used after the
+// invocation.
+type InvokeFunc func(ctx context.Context, payload []byte) (io.Reader, error)
+
+// Middleware wraps every invocation of a handler, such as to record metrics or log requests.
+// A middleware calls next to continue the invocation, and can change the context, the payload, the response or the error.
+type Middleware func(next InvokeFunc) InvokeFunc
+
+// WithMiddleware wraps the invocations of the handler with middleware, the first of which is the outermost.
+// The middleware runs after the context of the invocation is set up, and the handlertrace hooks of the context
+// are called by the handler once the middleware calls next.
+//
+// A middleware reading the response, rather than passing it on, must close it when it is an io.Closer, and loses
+// the streaming of the response.
+func WithMiddleware(middleware ...Middleware) Option {
+	return Option(func(h *handlerOptions) {
+		h.middleware = append(h.middleware, middleware...)
+	})
+}
+
+// applyMiddleware wraps f with the middleware, in reverse so that the first one is the outermost.
+func applyMiddleware(middleware []Middleware, f handlerFunc) handlerFunc {
+	next := InvokeFunc(f)
+	for i := len(middleware) - 1; i >= 0; i-- {
+		next = middleware[i](next)
+	}
+	return handlerFunc(next)
+}
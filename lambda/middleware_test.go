@@ -0,0 +1,167 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil" //nolint: staticcheck
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/lambda/handlertrace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingMiddleware appends name to calls before and after the rest of the invocation.
+func recordingMiddleware(name string, calls *[]string) Middleware {
+	return func(next InvokeFunc) InvokeFunc {
+		return func(ctx context.Context, payload []byte) (io.Reader, error) {
+			*calls = append(*calls, name+" before")
+			response, err := next(ctx, payload)
+			*calls = append(*calls, name+" after")
+			return response, err
+		}
+	}
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	var calls []string
+	handler := NewHandlerWithOptions(func(event string) (string, error) {
+		calls = append(calls, "handler "+event)
+		return strings.ToUpper(event), nil
+	},
+		WithMiddleware(recordingMiddleware("first", &calls), recordingMiddleware("second", &calls)),
+		WithMiddleware(recordingMiddleware("third", &calls)),
+	)
+
+	response, err := handler.Invoke(context.Background(), []byte(`"tacos"`))
+	require.NoError(t, err)
+	assert.Equal(t, `"TACOS"`, string(response))
+	assert.Equal(t, []string{
+		"first before", "second before", "third before",
+		"handler tacos",
+		"third after", "second after", "first after",
+	}, calls)
+}
+
+func TestMiddlewareErrors(t *testing.T) {
+	errHandler := errors.New("handler failed")
+	var seen error
+	observe := func(next InvokeFunc) InvokeFunc {
+		return func(ctx context.Context, payload []byte) (io.Reader, error) {
+			response, err := next(ctx, payload)
+			seen = err
+			return response, err
+		}
+	}
+	handler := NewHandlerWithOptions(func() error { return errHandler }, WithMiddleware(observe))
+	_, err := handler.Invoke(context.Background(), []byte(`{}`))
+	assert.Equal(t, errHandler, err)
+	assert.Equal(t, errHandler, seen)
+
+	// a middleware can fail the invocation without calling the handler
+	called := false
+	reject := func(next InvokeFunc) InvokeFunc {
+		return func(ctx context.Context, payload []byte) (io.Reader, error) {
+			if !bytes.Contains(payload, []byte("token")) {
+				return nil, errors.New("unauthorized")
+			}
+			return next(ctx, payload)
+		}
+	}
+	handler = NewHandlerWithOptions(func() error { called = true; return nil }, WithMiddleware(observe, reject))
+	_, err = handler.Invoke(context.Background(), []byte(`{}`))
+	assert.EqualError(t, err, "unauthorized")
+	assert.EqualError(t, seen, "unauthorized")
+	assert.False(t, called)
+
+	// and recover the panics of the handler
+	recoverPanics := func(next InvokeFunc) InvokeFunc {
+		return func(ctx context.Context, payload []byte) (response io.Reader, err error) {
+			defer func() {
+				if v := recover(); v != nil {
+					response, err = nil, errors.New("recovered")
+				}
+			}()
+			return next(ctx, payload)
+		}
+	}
+	handler = NewHandlerWithOptions(func() error { panic("boom") }, WithMiddleware(observe, recoverPanics))
+	_, err = handler.Invoke(context.Background(), []byte(`{}`))
+	assert.EqualError(t, err, "recovered")
+	assert.EqualError(t, seen, "recovered")
+}
+
+func TestMiddlewareHandlerTrace(t *testing.T) {
+	var calls []string
+	// a middleware installing the trace hooks sees the events of the handler
+	trace := func(next InvokeFunc) InvokeFunc {
+		return func(ctx context.Context, payload []byte) (io.Reader, error) {
+			ctx = handlertrace.NewContext(ctx, handlertrace.HandlerTrace{
+				RequestEvent:  func(_ context.Context, event interface{}) { calls = append(calls, "request "+event.(string)) },
+				ResponseEvent: func(_ context.Context, response interface{}) { calls = append(calls, "response "+response.(string)) },
+			})
+			return next(ctx, payload)
+		}
+	}
+	handler := NewHandlerWithOptions(func(event string) (string, error) {
+		calls = append(calls, "handler")
+		return event + "!", nil
+	}, WithMiddleware(recordingMiddleware("outer", &calls), trace))
+
+	_, err := handler.Invoke(context.Background(), []byte(`"tacos"`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"outer before", "request tacos", "handler", "response tacos!", "outer after"}, calls)
+}
+
+func TestMiddlewareStreamingResponse(t *testing.T) {
+	ts, record := runtimeAPIServer(`{}`, 1)
+	defer ts.Close()
+
+	body := &readCloser{reader: strings.NewReader("streamed")}
+	var passed io.Reader
+	handler := NewHandlerWithOptions(func() (io.Reader, error) {
+		return body, nil
+	}, WithMiddleware(func(next InvokeFunc) InvokeFunc {
+		return func(ctx context.Context, payload []byte) (io.Reader, error) {
+			response, err := next(ctx, payload)
+			passed = response
+			return response, err
+		}
+	}))
+	endpoint := strings.Split(ts.URL, "://")[1]
+	_ = startRuntimeAPILoop(endpoint, handler)
+
+	// the response is passed on as returned, and closed after it is posted
+	assert.Equal(t, body, passed)
+	assert.Equal(t, "streamed", string(record.responses[0]))
+	assert.Equal(t, contentTypeBytes, record.contentTypes[0])
+	assert.True(t, body.closed)
+}
+
+func TestMiddlewareChangesPayloadAndResponse(t *testing.T) {
+	upper := func(next InvokeFunc) InvokeFunc {
+		return func(ctx context.Context, payload []byte) (io.Reader, error) {
+			response, err := next(ctx, bytes.ToUpper(payload))
+			if err != nil {
+				return nil, err
+			}
+			if closer, ok := response.(io.Closer); ok {
+				defer closer.Close()
+			}
+			b, err := ioutil.ReadAll(response)
+			if err != nil {
+				return nil, err
+			}
+			return bytes.NewReader(append(b, '\n')), nil
+		}
+	}
+	handler := NewHandlerWithOptions(func(event string) (string, error) { return event, nil }, WithMiddleware(upper))
+	response, err := handler.Invoke(context.Background(), []byte(`"tacos"`))
+	require.NoError(t, err)
+	assert.Equal(t, "\"TACOS\"\n", string(response))
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"context"
+	"log"
+	"os"
+)
+
+// PanicPolicy controls what the runtime API loop does when the handler panics.
+type PanicPolicy int
+
+const (
+	// PanicPolicyRecover recovers the panic, reports it as the function error, and then stops the runtime loop,
+	// which causes Start to log the failure and exit. This is the default.
+	PanicPolicyRecover PanicPolicy = iota
+
+	// PanicPolicyReportAndExit recovers the panic and reports it as the function error,
+	// then runs any callbacks registered with WithEnableSIGTERM and hooks registered with WithShutdownHook,
+	// and exits the process with status 1.
+	PanicPolicyReportAndExit
+
+	// PanicPolicyPropagate does not recover the panic, crashing the process without reporting a function error.
+	// The Lambda service reports the invoke as a Runtime.ExitError. This matches the behavior of a plain Go program.
+	PanicPolicyPropagate
+)
+
+// This allows testing the PanicPolicyReportAndExit policy, by tests overwriting this function to keep the program alive
+var osExit = os.Exit
+
+// WithPanicPolicy sets the behavior of the runtime loop when the handler panics, see PanicPolicy.
+// The policy applies to functions using the Lambda runtime API, such as the provided.al2023 runtime.
+func WithPanicPolicy(policy PanicPolicy) Option {
+	return Option(func(h *handlerOptions) {
+		h.panicPolicy = policy
+	})
+}
+
+// exitAfterPanic runs the shutdown callbacks, then exits the process.
+func exitAfterPanic(handler *handlerOptions) {
+	log.Print("handler panicked, running shutdown callbacks and exiting")
+	for _, f := range handler.sigtermCallbacks {
+		f()
+	}
+	if len(handler.shutdownHooks) > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		runShutdownHooks(ctx, handler.shutdownHooks)
+	}
+	osExit(1)
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/lambda/messages"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPanicPolicy(t *testing.T) {
+	defer func() { osExit = os.Exit }()
+
+	for name, tt := range map[string]struct {
+		policy         PanicPolicy
+		expectPanic    bool
+		expectReported bool
+		expectExit     bool
+	}{
+		"Recover": {
+			policy:         PanicPolicyRecover,
+			expectReported: true,
+		},
+		"ReportAndExit": {
+			policy:         PanicPolicyReportAndExit,
+			expectReported: true,
+			expectExit:     true,
+		},
+		"Propagate": {
+			policy:      PanicPolicyPropagate,
+			expectPanic: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var events []string
+			exitCode := -1
+			osExit = func(code int) {
+				events = append(events, "exit")
+				exitCode = code
+			}
+
+			ts, record := runtimeAPIServer(``, 1)
+			defer ts.Close()
+
+			handler := newHandler(func() error {
+				panic("a fatal error")
+			}, WithPanicPolicy(tt.policy))
+			handler.sigtermCallbacks = []func(){func() { events = append(events, "shutdown hook") }}
+
+			endpoint := strings.Split(ts.URL, "://")[1]
+			if tt.expectPanic {
+				assert.PanicsWithValue(t, "a fatal error", func() { _ = startRuntimeAPILoop(endpoint, handler) })
+			} else {
+				assert.EqualError(t, startRuntimeAPILoop(endpoint, handler), "calling the handler function resulted in a panic, the process should exit")
+			}
+
+			if tt.expectReported {
+				require.Len(t, record.responses, 1)
+				var invokeErr messages.InvokeResponse_Error
+				require.NoError(t, json.Unmarshal(record.responses[0], &invokeErr))
+				assert.Equal(t, "a fatal error", invokeErr.Message)
+				assert.NotEmpty(t, invokeErr.StackTrace)
+			} else {
+				assert.Empty(t, record.responses)
+			}
+
+			if tt.expectExit {
+				assert.Equal(t, []string{"shutdown hook", "exit"}, events)
+				assert.Equal(t, 1, exitCode)
+			} else {
+				assert.Empty(t, events)
+			}
+		})
+	}
+}
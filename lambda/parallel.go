@@ -0,0 +1,150 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// tasksPerProc is the number of tasks DefaultParallelism runs per GOMAXPROCS, as the tasks of a handler
+// mostly wait on calls to other services.
+const tasksPerProc = 8
+
+// fullParallelismDeadline is the remaining time from which DefaultParallelism stops reducing the parallelism.
+const fullParallelismDeadline = 10 * time.Second
+
+// DefaultParallelism returns the number of tasks Parallel and WorkerPool run at once when not given a limit.
+// It is 8 tasks per GOMAXPROCS, reduced in proportion when less than 10 seconds remain before the deadline of ctx,
+// so that fewer tasks are started that could not complete, down to a single one.
+func DefaultParallelism(ctx context.Context) int {
+	n := tasksPerProc * runtime.GOMAXPROCS(0)
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline); remaining < fullParallelismDeadline {
+			n = int(float64(n) * float64(remaining) / float64(fullParallelismDeadline))
+		}
+	}
+	if n < 1 {
+		return 1
+	}
+	return n
+}
+
+// TaskPanicError is the error of a task of Parallel or WorkerPool that panicked.
+type TaskPanicError struct {
+	Value interface{} // passed to panic
+	Stack []byte      // of the goroutine of the task, when it panicked
+}
+
+func (e *TaskPanicError) Error() string {
+	return fmt.Sprintf("task panicked: %v", e.Value)
+}
+
+// Parallel calls each of fns in its own goroutine, with at most n running at once, or DefaultParallelism(ctx) if n
+// is 0 or less. The context given to the functions is canceled once one of them fails, and the functions not started
+// yet are skipped. Parallel returns the first error, including a *TaskPanicError for a function that panicked.
+func Parallel(ctx context.Context, n int, fns ...func(context.Context) error) error {
+	pool := NewWorkerPool(ctx, n, true)
+	for _, fn := range fns {
+		pool.Go(fn)
+	}
+	return pool.Wait()
+}
+
+// WorkerPool runs tasks in goroutines with bounded concurrency, such as one task per record of a batch.
+//
+//	pool := lambda.NewWorkerPool(ctx, 0, false)
+//	for _, record := range event.Records {
+//		record := record
+//		pool.Go(func(ctx context.Context) error {
+//			return process(ctx, record)
+//		})
+//	}
+//	err := pool.Wait()
+type WorkerPool struct {
+	ctx           context.Context
+	cancel        context.CancelFunc
+	cancelOnError bool
+	slots         chan struct{}
+	wg            sync.WaitGroup
+
+	lock sync.Mutex
+	err  error
+}
+
+// NewWorkerPool returns a pool running at most limit tasks at once, or DefaultParallelism(ctx) if limit is 0 or less.
+// With cancelOnError, the context given to the tasks is canceled once one of them fails, and the tasks not started
+// yet are skipped.
+func NewWorkerPool(ctx context.Context, limit int, cancelOnError bool) *WorkerPool {
+	if limit <= 0 {
+		limit = DefaultParallelism(ctx)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return &WorkerPool{
+		ctx:           ctx,
+		cancel:        cancel,
+		cancelOnError: cancelOnError,
+		slots:         make(chan struct{}, limit),
+	}
+}
+
+// Go runs fn in a new goroutine, waiting first for one of the running tasks to complete when the pool is at its
+// limit. fn is skipped if the context of the pool is done before it can start.
+func (p *WorkerPool) Go(fn func(context.Context) error) {
+	select {
+	case p.slots <- struct{}{}:
+		// checked again as the select picks either case when a slot frees up once the context is done
+		if err := p.ctx.Err(); err != nil {
+			<-p.slots
+			p.fail(err)
+			return
+		}
+	case <-p.ctx.Done():
+		p.fail(p.ctx.Err())
+		return
+	}
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer func() { <-p.slots }()
+		if err := p.run(fn); err != nil {
+			p.fail(err)
+		}
+	}()
+}
+
+func (p *WorkerPool) run(fn func(context.Context) error) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = &TaskPanicError{Value: v, Stack: debug.Stack()}
+		}
+	}()
+	return fn(p.ctx)
+}
+
+// fail records the first error of the pool.
+func (p *WorkerPool) fail(err error) {
+	p.lock.Lock()
+	if p.err == nil {
+		p.err = err
+	}
+	p.lock.Unlock()
+	if p.cancelOnError {
+		p.cancel()
+	}
+}
+
+// Wait waits for the tasks started with Go to complete, and returns the first error of the pool: the error returned
+// by a task, a *TaskPanicError for a task that panicked, or the error of the context if a task was skipped.
+func (p *WorkerPool) Wait() error {
+	p.wg.Wait()
+	p.cancel()
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.err
+}
@@ -0,0 +1,151 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// concurrencyProbe returns a task recording the maximum number of tasks running at once.
+func concurrencyProbe(running, max *int32) func(context.Context) error {
+	return func(context.Context) error {
+		n := atomic.AddInt32(running, 1)
+		defer atomic.AddInt32(running, -1)
+		for {
+			m := atomic.LoadInt32(max)
+			if n <= m || atomic.CompareAndSwapInt32(max, m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		return nil
+	}
+}
+
+func TestParallelBounds(t *testing.T) {
+	for _, limit := range []int{1, 3, 10} {
+		var running, max, calls int32
+		probe := concurrencyProbe(&running, &max)
+		fns := make([]func(context.Context) error, 20)
+		for i := range fns {
+			fns[i] = func(ctx context.Context) error {
+				atomic.AddInt32(&calls, 1)
+				return probe(ctx)
+			}
+		}
+
+		require.NoError(t, Parallel(context.Background(), limit, fns...))
+		assert.Equal(t, int32(20), calls)
+		assert.LessOrEqual(t, max, int32(limit))
+		if limit > 1 {
+			assert.Greater(t, max, int32(1), "tasks should run concurrently")
+		}
+	}
+}
+
+func TestParallelCancelsOnFirstError(t *testing.T) {
+	errTask := errors.New("task failed")
+	var started, canceled int32
+	fns := []func(context.Context) error{
+		func(ctx context.Context) error {
+			atomic.AddInt32(&started, 1)
+			<-ctx.Done()
+			atomic.AddInt32(&canceled, 1)
+			return ctx.Err()
+		},
+		func(ctx context.Context) error {
+			atomic.AddInt32(&started, 1)
+			return errTask
+		},
+	}
+	for i := 0; i < 10; i++ {
+		fns = append(fns, func(ctx context.Context) error {
+			atomic.AddInt32(&started, 1)
+			return nil
+		})
+	}
+
+	err := Parallel(context.Background(), 2, fns...)
+	assert.Equal(t, errTask, err)
+	assert.Equal(t, int32(1), canceled)
+	assert.Equal(t, int32(2), started, "the tasks not started before the error should be skipped")
+}
+
+func TestWorkerPoolWithoutCancelOnError(t *testing.T) {
+	errTask := errors.New("task failed")
+	pool := NewWorkerPool(context.Background(), 2, false)
+	var completed int32
+	pool.Go(func(ctx context.Context) error {
+		return errTask
+	})
+	for i := 0; i < 5; i++ {
+		pool.Go(func(ctx context.Context) error {
+			time.Sleep(time.Millisecond)
+			if ctx.Err() == nil {
+				atomic.AddInt32(&completed, 1)
+			}
+			return nil
+		})
+	}
+	assert.Equal(t, errTask, pool.Wait())
+	assert.Equal(t, int32(5), completed)
+}
+
+func TestWorkerPoolSkipsTasksAfterContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := NewWorkerPool(ctx, 1, false)
+	cancel()
+	called := false
+	pool.Go(func(context.Context) error {
+		called = true
+		return nil
+	})
+	assert.Equal(t, context.Canceled, pool.Wait())
+	assert.False(t, called)
+}
+
+func TestParallelCapturesPanics(t *testing.T) {
+	err := Parallel(context.Background(), 0,
+		func(context.Context) error { return nil },
+		func(context.Context) error { panic("boom") },
+	)
+	var panicErr *TaskPanicError
+	require.True(t, errors.As(err, &panicErr))
+	assert.Equal(t, "boom", panicErr.Value)
+	assert.Equal(t, "task panicked: boom", panicErr.Error())
+	assert.Contains(t, string(panicErr.Stack), "TestParallelCapturesPanics")
+}
+
+func TestDefaultParallelism(t *testing.T) {
+	full := 8 * runtime.GOMAXPROCS(0)
+	assert.Equal(t, full, DefaultParallelism(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	assert.Equal(t, full, DefaultParallelism(ctx))
+
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	half := DefaultParallelism(ctx)
+	assert.LessOrEqual(t, half, full/2)
+	assert.GreaterOrEqual(t, half, 1)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, 1, DefaultParallelism(ctx))
+
+	ctx, cancel = context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	assert.Equal(t, 1, DefaultParallelism(ctx))
+
+	// the pool uses the default when not given a limit
+	assert.Equal(t, full, cap(NewWorkerPool(context.Background(), 0, false).slots))
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"runtime"
+	"runtime/debug"
+	"sync"
+)
+
+// GCMode is what the runtime loop does after posting the response of an invocation, see WithPostInvokeGC.
+type GCMode int
+
+const (
+	// GCModeOff leaves garbage collection to the Go runtime. This is the default.
+	GCModeOff GCMode = iota
+
+	// GCModeGC runs a garbage collection with runtime.GC.
+	GCModeGC
+
+	// GCModeFreeOSMemory runs a garbage collection and returns as much memory as possible to the operating system,
+	// with debug.FreeOSMemory.
+	GCModeFreeOSMemory
+)
+
+// These allow tests to observe the collections.
+var (
+	runtimeGC     = runtime.GC
+	freeOSMemory  = debug.FreeOSMemory
+	readHeapAlloc = readHeapAllocMemStats
+)
+
+func readHeapAllocMemStats() uint64 {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.HeapAlloc
+}
+
+// WithPostInvokeGC runs a garbage collection after the response of each invocation is posted, while the execution
+// environment is about to idle, so that the pause is less likely to land in the middle of the next invocation.
+// This helps functions with large heaps, at the cost of the collection being billed. See WithPostInvokeGCEvery and
+// WithPostInvokeGCHeapGrowth to collect less often.
+// The option applies to functions using the Lambda runtime API, such as the provided.al2023 runtime.
+func WithPostInvokeGC(mode GCMode) Option {
+	return Option(func(h *handlerOptions) {
+		h.postInvokeGCPolicy().mode = mode
+	})
+}
+
+// WithPostInvokeGCEvery limits the collections of WithPostInvokeGC to every n invocations.
+func WithPostInvokeGCEvery(n int) Option {
+	return Option(func(h *handlerOptions) {
+		h.postInvokeGCPolicy().every = n
+	})
+}
+
+// WithPostInvokeGCHeapGrowth limits the collections of WithPostInvokeGC to when the allocated heap grew by more than
+// bytes since the previous one, as reported by runtime.MemStats. When WithPostInvokeGCEvery is also set, a collection
+// runs when either is due.
+func WithPostInvokeGCHeapGrowth(bytes uint64) Option {
+	return Option(func(h *handlerOptions) {
+		h.postInvokeGCPolicy().heapGrowth = bytes
+	})
+}
+
+func (h *handlerOptions) postInvokeGCPolicy() *postInvokeGC {
+	if h.postInvokeGC == nil {
+		h.postInvokeGC = &postInvokeGC{}
+	}
+	return h.postInvokeGC
+}
+
+type postInvokeGC struct {
+	mode       GCMode
+	every      int
+	heapGrowth uint64
+
+	lock     sync.Mutex
+	invokes  int    // since the previous collection
+	lastHeap uint64 // allocated after the previous collection
+}
+
+// collect runs a collection if one is due, it is called after each response is posted.
+func (p *postInvokeGC) collect() {
+	if p == nil || p.mode == GCModeOff {
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.invokes++
+	if !p.due() {
+		return
+	}
+	p.invokes = 0
+	if p.mode == GCModeFreeOSMemory {
+		freeOSMemory()
+	} else {
+		runtimeGC()
+	}
+	if p.heapGrowth > 0 {
+		p.lastHeap = readHeapAlloc()
+	}
+}
+
+func (p *postInvokeGC) due() bool {
+	if p.every <= 1 && p.heapGrowth == 0 {
+		return true
+	}
+	if p.every > 1 && p.invokes >= p.every {
+		return true
+	}
+	return p.heapGrowth > 0 && readHeapAlloc() > p.lastHeap+p.heapGrowth
+}
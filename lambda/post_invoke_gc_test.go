@@ -0,0 +1,142 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordCollections replaces the collection functions with ones recording the number of responses posted
+// to the fake runtime API when they are called, until the returned function is called.
+func recordCollections(record *requestRecord, gcs, frees *[]int) (restore func()) {
+	postsSoFar := func() int {
+		record.lock.Lock()
+		defer record.lock.Unlock()
+		return record.nPosts
+	}
+	runtimeGC = func() { *gcs = append(*gcs, postsSoFar()) }
+	freeOSMemory = func() { *frees = append(*frees, postsSoFar()) }
+	return func() {
+		runtimeGC = runtime.GC
+		freeOSMemory = debug.FreeOSMemory
+	}
+}
+
+func TestPostInvokeGC(t *testing.T) {
+	for name, test := range map[string]struct {
+		options       []Option
+		invokes       int
+		expectedGCs   []int
+		expectedFrees []int
+	}{
+		"off by default": {
+			invokes: 3,
+		},
+		"off": {
+			options: []Option{WithPostInvokeGC(GCModeOff), WithPostInvokeGCEvery(1)},
+			invokes: 3,
+		},
+		"after each response": {
+			options:     []Option{WithPostInvokeGC(GCModeGC)},
+			invokes:     3,
+			expectedGCs: []int{1, 2, 3},
+		},
+		"free OS memory": {
+			options:       []Option{WithPostInvokeGC(GCModeFreeOSMemory)},
+			invokes:       3,
+			expectedFrees: []int{1, 2, 3},
+		},
+		"every 3 invokes": {
+			options:     []Option{WithPostInvokeGC(GCModeGC), WithPostInvokeGCEvery(3)},
+			invokes:     7,
+			expectedGCs: []int{3, 6},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ts, record := runtimeAPIServer(`{}`, test.invokes)
+			defer ts.Close()
+			var gcs, frees []int
+			defer recordCollections(record, &gcs, &frees)()
+
+			handler := newHandler(func() error { return nil }, test.options...)
+			_ = startRuntimeAPILoop(strings.Split(ts.URL, "://")[1], handler)
+
+			assert.Equal(t, test.invokes, record.nPosts)
+			// each collection ran once the response of its invoke was posted
+			assert.Equal(t, test.expectedGCs, gcs)
+			assert.Equal(t, test.expectedFrees, frees)
+		})
+	}
+}
+
+func TestPostInvokeGCHeapGrowth(t *testing.T) {
+	ts, _ := runtimeAPIServer(`{}`, 7)
+	defer ts.Close()
+
+	// each invoke grows the heap by 40 bytes, and each collection shrinks it to 10 bytes
+	heap := uint64(0)
+	readHeapAlloc = func() uint64 { return heap }
+	defer func() { readHeapAlloc = readHeapAllocMemStats }()
+	invokes := 0
+	var gcs []int
+	runtimeGC = func() {
+		gcs = append(gcs, invokes)
+		heap = 10
+	}
+	defer func() { runtimeGC = runtime.GC }()
+
+	handler := newHandler(func() error {
+		invokes++
+		heap += 40
+		return nil
+	}, WithPostInvokeGC(GCModeGC), WithPostInvokeGCHeapGrowth(100))
+	_ = startRuntimeAPILoop(strings.Split(ts.URL, "://")[1], handler)
+
+	// 120 bytes after the third invoke, 130 bytes after the sixth
+	assert.Equal(t, []int{3, 6}, gcs)
+}
+
+func TestPostInvokeGCEveryOrHeapGrowth(t *testing.T) {
+	heap := uint64(0)
+	readHeapAlloc = func() uint64 { return heap }
+	defer func() { readHeapAlloc = readHeapAllocMemStats }()
+	collections := 0
+	runtimeGC = func() {
+		collections++
+		heap = 0
+	}
+	defer func() { runtimeGC = runtime.GC }()
+
+	p := &postInvokeGC{mode: GCModeGC, every: 4, heapGrowth: 100}
+	for _, growth := range []uint64{10, 10, 10, 10, 500, 10} {
+		heap += growth
+		p.collect()
+	}
+	// on the fourth invoke, and when the heap grew on the fifth
+	assert.Equal(t, 2, collections)
+}
+
+func BenchmarkPostInvokeGC(b *testing.B) {
+	retained := make([][]byte, 0, 1024)
+	for i := 0; i < cap(retained); i++ {
+		retained = append(retained, make([]byte, 16*1024))
+	}
+	for _, mode := range []struct {
+		name string
+		mode GCMode
+	}{{"Off", GCModeOff}, {"GC", GCModeGC}, {"FreeOSMemory", GCModeFreeOSMemory}} {
+		b.Run(mode.name, func(b *testing.B) {
+			p := &postInvokeGC{mode: mode.mode}
+			for i := 0; i < b.N; i++ {
+				_ = make([]byte, 1024*1024) // garbage of the invoke
+				p.collect()
+			}
+		})
+	}
+	runtime.KeepAlive(retained)
+}
@@ -0,0 +1,40 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"context"
+	"io"
+)
+
+// preparedResource is a per-invocation resource registered with WithPreparedResource.
+type preparedResource interface {
+	// prepareNext starts preparing the resource for the next invocation in the background.
+	prepareNext()
+	// claim adds a prepared resource to ctx, preparing one synchronously if none is ready.
+	// release must be called once the invocation no longer uses the resource.
+	claim(ctx context.Context) (_ context.Context, release func(), _ error)
+}
+
+func claimPreparedResources(resources []preparedResource, f handlerFunc) handlerFunc {
+	return func(ctx context.Context, payload []byte) (io.Reader, error) {
+		for _, r := range resources {
+			var release func()
+			var err error
+			ctx, release, err = r.claim(ctx)
+			if err != nil {
+				return nil, err
+			}
+			defer release()
+		}
+		return f(ctx, payload)
+	}
+}
+
+// prepareNextResources starts preparing the resources for the next invocation.
+// It is called after the response of an invocation is posted, so preparation overlaps with the idle time between invokes.
+func (handler *handlerOptions) prepareNextResources() {
+	for _, r := range handler.preparedResources {
+		r.prepareNext()
+	}
+}
@@ -0,0 +1,100 @@
+//go:build go1.18
+// +build go1.18
+
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"context"
+	"sync"
+)
+
+type preparedResourceKey[T any] struct{}
+
+type preparedResult[T any] struct {
+	value T
+	err   error
+}
+
+type preparedResourceSlot[T any] struct {
+	prepare func(context.Context) (T, error)
+	dispose func(T)
+
+	mu   sync.Mutex
+	next chan preparedResult[T] // the background preparation for the next invocation, if any
+}
+
+// WithPreparedResource registers a per-invocation resource, such as a pooled database transaction or a large buffer.
+// Each invocation claims one, available to the handler through ResourceFromContext, and disposes of it when the handler returns.
+// After the response of an invocation is posted, prepare is started in the background, so that the next invocation can
+// claim a resource without waiting. If the background preparation has not completed when the next invocation starts,
+// for example because the execution environment was frozen mid-prepare, or if it failed, the resource is prepared
+// synchronously instead, and a late background result is disposed of. An error from the synchronous prepare is
+// returned as the function error.
+//
+// prepare is called with the invocation context when preparing synchronously, and with context.Background() otherwise.
+// dispose may be nil. Background preparation applies to functions using the Lambda runtime API, such as the provided.al2023 runtime.
+func WithPreparedResource[T any](prepare func(ctx context.Context) (T, error), dispose func(T)) Option {
+	return Option(func(h *handlerOptions) {
+		h.preparedResources = append(h.preparedResources, &preparedResourceSlot[T]{prepare: prepare, dispose: dispose})
+	})
+}
+
+// ResourceFromContext returns the resource of type T claimed for the invocation by WithPreparedResource.
+func ResourceFromContext[T any](ctx context.Context) (T, bool) {
+	value, ok := ctx.Value(preparedResourceKey[T]{}).(T)
+	return value, ok
+}
+
+func (s *preparedResourceSlot[T]) prepareNext() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.next != nil {
+		return
+	}
+	next := make(chan preparedResult[T], 1)
+	s.next = next
+	go func() {
+		value, err := s.prepare(context.Background())
+		next <- preparedResult[T]{value, err}
+	}()
+}
+
+func (s *preparedResourceSlot[T]) claim(ctx context.Context) (context.Context, func(), error) {
+	s.mu.Lock()
+	next := s.next
+	s.next = nil
+	s.mu.Unlock()
+
+	if next != nil {
+		select {
+		case result := <-next:
+			if result.err == nil {
+				return s.attach(ctx, result.value)
+			}
+		default:
+			go func() {
+				if result := <-next; result.err == nil {
+					s.release(result.value)
+				}
+			}()
+		}
+	}
+
+	value, err := s.prepare(ctx)
+	if err != nil {
+		return ctx, nil, err
+	}
+	return s.attach(ctx, value)
+}
+
+func (s *preparedResourceSlot[T]) attach(ctx context.Context, value T) (context.Context, func(), error) {
+	return context.WithValue(ctx, preparedResourceKey[T]{}, value), func() { s.release(value) }, nil
+}
+
+func (s *preparedResourceSlot[T]) release(value T) {
+	if s.dispose != nil {
+		s.dispose(value)
+	}
+}
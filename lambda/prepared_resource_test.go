@@ -0,0 +1,173 @@
+//go:build go1.18
+// +build go1.18
+
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testResource struct {
+	id         int
+	background bool
+}
+
+// instrumentedPrepare records the calls to prepare and dispose.
+type instrumentedPrepare struct {
+	mu       sync.Mutex
+	prepared int
+	disposed []int
+	done     chan int      // receives the id of each completed background prepare
+	block    chan struct{} // if set, background prepares wait on it
+}
+
+func (p *instrumentedPrepare) prepare(ctx context.Context) (*testResource, error) {
+	p.mu.Lock()
+	p.prepared++
+	r := &testResource{id: p.prepared, background: ctx == context.Background()}
+	block := p.block
+	p.mu.Unlock()
+	if r.background {
+		if block != nil {
+			<-block
+		}
+		defer func() { p.done <- r.id }()
+	}
+	return r, nil
+}
+
+func (p *instrumentedPrepare) dispose(r *testResource) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.disposed = append(p.disposed, r.id)
+}
+
+func (p *instrumentedPrepare) disposedIDs() []int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]int{}, p.disposed...)
+}
+
+func TestPreparedResourceClaimsBackgroundPrepare(t *testing.T) {
+	p := &instrumentedPrepare{done: make(chan int, 1)}
+	slot := &preparedResourceSlot[*testResource]{prepare: p.prepare, dispose: p.dispose}
+
+	// first invocation, nothing prepared yet
+	ctx, release, err := slot.claim(context.TODO())
+	require.NoError(t, err)
+	r, ok := ResourceFromContext[*testResource](ctx)
+	require.True(t, ok)
+	assert.Equal(t, &testResource{id: 1}, r)
+	release()
+
+	// the next resource is prepared while idle, and claimed without preparing again
+	slot.prepareNext()
+	assert.Equal(t, 2, <-p.done)
+	ctx, release, err = slot.claim(context.TODO())
+	require.NoError(t, err)
+	r, _ = ResourceFromContext[*testResource](ctx)
+	assert.Equal(t, &testResource{id: 2, background: true}, r)
+	release()
+
+	assert.Equal(t, []int{1, 2}, p.disposedIDs())
+}
+
+func TestPreparedResourceFallbackWhenPrepareIncomplete(t *testing.T) {
+	p := &instrumentedPrepare{done: make(chan int, 1), block: make(chan struct{})}
+	slot := &preparedResourceSlot[*testResource]{prepare: p.prepare, dispose: p.dispose}
+
+	// simulate a freeze mid-prepare, the background prepare does not complete before the next invocation
+	slot.prepareNext()
+	ctx, release, err := slot.claim(context.TODO())
+	require.NoError(t, err)
+	r, _ := ResourceFromContext[*testResource](ctx)
+	assert.False(t, r.background, "expected a synchronously prepared resource")
+	release()
+
+	// the late background result is disposed of
+	close(p.block)
+	<-p.done
+	assert.Eventually(t, func() bool { return len(p.disposedIDs()) == 2 }, time.Second, time.Millisecond)
+	assert.ElementsMatch(t, []int{1, 2}, p.disposedIDs())
+}
+
+func TestPreparedResourceFallbackWhenPrepareFails(t *testing.T) {
+	calls := 0
+	slot := &preparedResourceSlot[int]{prepare: func(ctx context.Context) (int, error) {
+		calls++
+		if ctx == context.Background() {
+			return 0, errors.New("background prepare failed")
+		}
+		return calls, nil
+	}}
+
+	slot.prepareNext()
+	slot.mu.Lock()
+	next := slot.next
+	slot.mu.Unlock()
+	for len(next) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, release, err := slot.claim(context.TODO())
+	require.NoError(t, err)
+	value, _ := ResourceFromContext[int](ctx)
+	assert.Equal(t, 2, value)
+	release() // no dispose function
+}
+
+func TestWithPreparedResource(t *testing.T) {
+	ts, record := runtimeAPIServer(`{}`, 3)
+	defer ts.Close()
+
+	p := &instrumentedPrepare{done: make(chan int, 3)}
+	invokes := 0
+	handler := NewHandlerWithOptions(func(ctx context.Context) (int, error) {
+		invokes++
+		r, ok := ResourceFromContext[*testResource](ctx)
+		if !ok {
+			return 0, errors.New("no resource")
+		}
+		if invokes == 3 {
+			return 0, errors.New("failing invoke")
+		}
+		return r.id, nil
+	}, WithPreparedResource(p.prepare, p.dispose))
+	endpoint := strings.Split(ts.URL, "://")[1]
+	_ = startRuntimeAPILoop(endpoint, handler)
+
+	assert.Equal(t, 3, record.nPosts)
+	require.Len(t, record.responses, 3)
+	assert.Equal(t, "1", string(record.responses[0]), "the first invoke prepares synchronously")
+	assert.Contains(t, string(record.responses[2]), "failing invoke")
+	// every claimed resource is disposed of, whether the invoke succeeded or failed
+	assert.Contains(t, p.disposedIDs(), 1)
+	assert.GreaterOrEqual(t, len(p.disposedIDs()), 3)
+}
+
+func TestWithPreparedResourceError(t *testing.T) {
+	ts, record := runtimeAPIServer(`{}`, 1)
+	defer ts.Close()
+
+	handler := NewHandlerWithOptions(func(ctx context.Context) error {
+		t.Error("the handler should not be called")
+		return nil
+	}, WithPreparedResource(func(context.Context) (string, error) {
+		return "", errors.New("no connection")
+	}, nil))
+	endpoint := strings.Split(ts.URL, "://")[1]
+	_ = startRuntimeAPILoop(endpoint, handler)
+
+	require.Len(t, record.responses, 1)
+	assert.Contains(t, string(record.responses[0]), "no connection")
+}
@@ -7,14 +7,20 @@ package lambda
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"io/ioutil" //nolint: staticcheck
 	"log"
 	"net/http"
 	"runtime"
 	"sync"
+
+	"github.com/aws/aws-lambda-go/lambda/handlertrace"
 )
 
 const (
@@ -28,12 +34,21 @@ const (
 	headerXRayErrorCause     = "Lambda-Runtime-Function-Xray-Error-Cause"
 	trailerLambdaErrorType   = "Lambda-Runtime-Function-Error-Type"
 	trailerLambdaErrorBody   = "Lambda-Runtime-Function-Error-Body"
+	trailerResponseSHA256    = "X-Amz-Lambda-Go-Response-Sha256"
 	contentTypeJSON          = "application/json"
 	contentTypeBytes         = "application/octet-stream"
 	apiVersion               = "2018-06-01"
 	xrayErrorCauseMaxSize    = 1024 * 1024
+
+	// statusClientDisconnected is the status of the early response of the Runtime API to a streamed response,
+	// when the client of the invocation went away before the whole response was sent.
+	statusClientDisconnected = http.StatusGone
 )
 
+// errResponseAborted is the cause of the cancellation of the handler's context when sending its response to the
+// Runtime API failed before the whole response was read.
+var errResponseAborted = errors.New("lambda: sending the response to the Runtime API failed")
+
 type runtimeAPIClient struct {
 	baseURL    string
 	userAgent  string
@@ -60,6 +75,10 @@ type invoke struct {
 	payload *bytes.Buffer
 	headers http.Header
 	client  *runtimeAPIClient
+	posted  handlertrace.ResponsePostedEvent // set by success
+	// abort, if set, is called with the cause as soon as sending the response stops before its end,
+	// so that the producer of a streamed response stops without waiting for success to return
+	abort func(cause error)
 }
 
 // success sends the response payload for an in-progress invocation.
@@ -70,7 +89,12 @@ func (i *invoke) success(body io.Reader, contentType string) error {
 	defer i.payload.Reset()
 
 	url := i.client.baseURL + i.id + "/response"
-	return i.client.post(url, body, contentType, nil)
+	b := newErrorCapturingReader(body)
+	b.abort = i.abort
+	err := i.client.post(url, b, contentType, nil)
+	i.posted = b.posted()
+	i.posted.ClientDisconnected = errors.Is(err, ErrClientDisconnected)
+	return err
 }
 
 // failure sends the payload to the Runtime API. This marks the function's invoke as a failure.
@@ -83,7 +107,7 @@ func (i *invoke) failure(body io.Reader, contentType string, causeForXRay []byte
 	defer i.payload.Reset()
 
 	url := i.client.baseURL + i.id + "/error"
-	return i.client.post(url, body, contentType, causeForXRay)
+	return i.client.post(url, newErrorCapturingReader(body), contentType, causeForXRay)
 }
 
 // next connects to the Runtime API and waits for a new invoke Request to be available.
@@ -126,8 +150,7 @@ func (c *runtimeAPIClient) next(ctx context.Context) (*invoke, error) {
 	}, nil
 }
 
-func (c *runtimeAPIClient) post(url string, body io.Reader, contentType string, xrayErrorCause []byte) error {
-	b := newErrorCapturingReader(body)
+func (c *runtimeAPIClient) post(url string, b *errorCapturingReader, contentType string, xrayErrorCause []byte) error {
 	req, err := http.NewRequest(http.MethodPost, url, b)
 	if err != nil {
 		return fmt.Errorf("failed to construct POST request to %s: %v", url, err)
@@ -142,13 +165,17 @@ func (c *runtimeAPIClient) post(url string, body io.Reader, contentType string,
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to POST to %s: %v", url, err)
+		return fmt.Errorf("failed to POST to %s: %w", url, err)
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
 			log.Printf("runtime API client failed to close %s response body: %v", url, err)
 		}
 	}()
+	if resp.StatusCode == statusClientDisconnected && b.stop(ErrClientDisconnected) {
+		// the Runtime API answered before the end of a streamed response, stop sending the rest
+		return fmt.Errorf("failed to POST to %s after %d bytes: %w", url, b.posted().Bytes, ErrClientDisconnected)
+	}
 	if resp.StatusCode != http.StatusAccepted {
 		return fmt.Errorf("failed to POST to %s: got unexpected status code: %d", url, resp.StatusCode)
 	}
@@ -165,17 +192,52 @@ func newErrorCapturingReader(r io.Reader) *errorCapturingReader {
 	trailer := http.Header{
 		trailerLambdaErrorType: nil,
 		trailerLambdaErrorBody: nil,
+		trailerResponseSHA256:  nil,
 	}
-	return &errorCapturingReader{r, trailer}
+	return &errorCapturingReader{reader: r, Trailer: trailer, hash: sha256.New()}
 }
 
+// errorCapturingReader reports errors reading the body in the trailer, and the SHA-256 of a completely read body.
+// The HTTP client may still read it after the response to the request is received, so its state is locked.
 type errorCapturingReader struct {
 	reader  io.Reader
 	Trailer http.Header
+	abort   func(cause error) // called when the body is stopped or closed before its end
+	mu      sync.Mutex
+	hash    hash.Hash
+	n       int64
+	done    bool // the end of the body, or an error reading it, was reached
+	stopped bool
+}
+
+// stop makes the next reads fail, and aborts the body with cause. It reports whether the end of the body was not
+// reached yet.
+func (r *errorCapturingReader) stop(cause error) bool {
+	r.mu.Lock()
+	stopped := !r.done && !r.stopped
+	r.stopped = true
+	r.mu.Unlock()
+	if stopped && r.abort != nil {
+		r.abort(cause)
+	}
+	return stopped
+}
+
+// Close is called by the HTTP client once it is done sending the body, including when sending it failed.
+func (r *errorCapturingReader) Close() error {
+	r.stop(errResponseAborted)
+	return nil
 }
 
 func (r *errorCapturingReader) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stopped {
+		return 0, errResponseAborted
+	}
 	if r.reader == nil {
+		r.Trailer.Set(trailerResponseSHA256, hex.EncodeToString(r.hash.Sum(nil)))
+		r.done = true
 		return 0, io.EOF
 	}
 	n, err := r.reader.Read(p)
@@ -183,7 +245,21 @@ func (r *errorCapturingReader) Read(p []byte) (int, error) {
 		lambdaErr := lambdaErrorResponse(err)
 		r.Trailer.Set(trailerLambdaErrorType, lambdaErr.Type)
 		r.Trailer.Set(trailerLambdaErrorBody, base64.StdEncoding.EncodeToString(safeMarshal(lambdaErr)))
+		r.done = true
 		return 0, io.EOF
 	}
+	r.hash.Write(p[:n])
+	r.n += int64(n)
+	if err == io.EOF {
+		r.Trailer.Set(trailerResponseSHA256, hex.EncodeToString(r.hash.Sum(nil)))
+		r.done = true
+	}
 	return n, err
 }
+
+// posted returns the hash and size of the body read so far.
+func (r *errorCapturingReader) posted() handlertrace.ResponsePostedEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return handlertrace.ResponsePostedEvent{SHA256: hex.EncodeToString(r.hash.Sum(nil)), Bytes: r.n}
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// shutdownTimeout bounds the shutdown hooks. Lambda sends SIGKILL ~500ms after SIGTERM, this leaves time to exit.
+const shutdownTimeout = 450 * time.Millisecond
+
+// WithShutdownHook adds a hook run when the execution environment shuts down, to flush buffers or close connections.
+// Hooks run in the order they are added, with a context that is done when the shutdown window is about to end,
+// then the process exits. A hook that panics does not prevent the next ones from running.
+//
+// Lambda only sends SIGTERM before shutting down an execution environment when an extension is registered. Functions
+// without an external extension also need WithEnableSIGTERM, whose callbacks then run before the hooks.
+// The hooks also run when the process exits after a panic, see PanicPolicyReportAndExit.
+func WithShutdownHook(hook func(ctx context.Context)) Option {
+	return Option(func(h *handlerOptions) {
+		h.shutdownHooks = append(h.shutdownHooks, hook)
+	})
+}
+
+// handleShutdown runs the callbacks and the hooks when the process receives SIGTERM, then exits the process.
+// The returned function stops handling the signal.
+func handleShutdown(callbacks []func(), hooks []func(context.Context)) (stop func()) {
+	signaled := make(chan os.Signal, 1)
+	stopped := make(chan struct{})
+	signal.Notify(signaled, syscall.SIGTERM)
+	go func() {
+		select {
+		case <-signaled:
+		case <-stopped:
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		for _, f := range callbacks {
+			f()
+		}
+		runShutdownHooks(ctx, hooks)
+		osExit(0)
+	}()
+	return func() {
+		signal.Stop(signaled)
+		close(stopped)
+	}
+}
+
+// runShutdownHooks runs the hooks in order, and returns once they all returned or ctx is done.
+func runShutdownHooks(ctx context.Context, hooks []func(context.Context)) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, hook := range hooks {
+			runShutdownHook(ctx, hook)
+		}
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		log.Print("WARNING! Shutdown hooks did not return before the end of the shutdown window")
+	}
+}
+
+func runShutdownHook(ctx context.Context, hook func(context.Context)) {
+	defer func() {
+		if err := recover(); err != nil {
+			log.Printf("shutdown hook panicked: %v", err)
+		}
+	}()
+	hook(ctx)
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunShutdownHooks(t *testing.T) {
+	var events []string
+	hook := func(name string) func(context.Context) {
+		return func(ctx context.Context) {
+			_, hasDeadline := ctx.Deadline()
+			assert.True(t, hasDeadline)
+			events = append(events, name)
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	runShutdownHooks(ctx, []func(context.Context){
+		hook("first"),
+		func(context.Context) { panic("flush failed") },
+		hook("second"),
+	})
+	assert.Equal(t, []string{"first", "second"}, events)
+}
+
+func TestRunShutdownHooksTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	block := make(chan struct{})
+	defer close(block)
+
+	start := time.Now()
+	runShutdownHooks(ctx, []func(context.Context){func(context.Context) { <-block }})
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+}
+
+func TestShutdownHooksOnSIGTERM(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("SIGTERM cannot be sent on windows")
+	}
+	defer func() { osExit = os.Exit }()
+	exited := make(chan int, 1)
+	osExit = func(code int) { exited <- code }
+
+	var events []string
+	stop := handleShutdown(
+		[]func(){func() { events = append(events, "callback") }},
+		[]func(context.Context){
+			func(context.Context) { events = append(events, "first") },
+			func(ctx context.Context) {
+				deadline, ok := ctx.Deadline()
+				require.True(t, ok)
+				assert.WithinDuration(t, time.Now().Add(shutdownTimeout), deadline, shutdownTimeout)
+				events = append(events, "second")
+			},
+		},
+	)
+	defer stop()
+
+	process, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	require.NoError(t, process.Signal(syscall.SIGTERM))
+
+	select {
+	case code := <-exited:
+		assert.Equal(t, 0, code)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the process did not exit after SIGTERM")
+	}
+	assert.Equal(t, []string{"callback", "first", "second"}, events)
+}
+
+func TestShutdownHooksNotRunAfterInvoke(t *testing.T) {
+	// the signal is handled from the handler construction, stop it for the next tests
+	defer signal.Reset(syscall.SIGTERM)
+	defer func() { osExit = os.Exit }()
+	exited := false
+	osExit = func(int) { exited = true }
+
+	ts, record := runtimeAPIServer(`{}`, 2)
+	defer ts.Close()
+
+	ran := false
+	handler := newHandler(func() error { return nil }, WithShutdownHook(func(context.Context) { ran = true }))
+	endpoint := strings.Split(ts.URL, "://")[1]
+	_ = startRuntimeAPILoop(endpoint, handler)
+
+	assert.Len(t, record.responses, 2)
+	assert.False(t, ran)
+	assert.False(t, exited)
+}
+
+func TestShutdownHooksAfterPanic(t *testing.T) {
+	defer signal.Reset(syscall.SIGTERM)
+	defer func() { osExit = os.Exit }()
+	var events []string
+	osExit = func(code int) { events = append(events, "exit") }
+
+	ts, _ := runtimeAPIServer(``, 1)
+	defer ts.Close()
+
+	handler := newHandler(func() error { panic("a fatal error") },
+		WithPanicPolicy(PanicPolicyReportAndExit),
+		WithShutdownHook(func(context.Context) { events = append(events, "hook") }),
+	)
+	endpoint := strings.Split(ts.URL, "://")[1]
+	_ = startRuntimeAPILoop(endpoint, handler)
+
+	assert.Equal(t, []string{"hook", "exit"}, events)
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// StreamingResponse is a handler response streamed to the caller as Body is read, with the content type ContentType,
+// or application/octet-stream when empty. Body is closed after the response is sent if it is an io.Closer.
+//
+// When reading Body fails after the response started streaming, the error is reported in the trailers of the
+// response, as the function error, so that the caller can tell the truncated response from a complete one.
+// The stream is also aborted with the error of the invocation's context once it is done, such as when the deadline
+// of the invoke is reached, or when the context set with WithContext is cancelled.
+//
+// Note: Streamed responses require compiling with `-tags lambda.norpc`, or choosing the `provided` or `provided.al2` runtime.
+type StreamingResponse struct {
+	ContentType string
+	Body        io.Reader
+}
+
+func (r StreamingResponse) Read(p []byte) (int, error) {
+	if r.Body == nil {
+		return 0, io.EOF
+	}
+	return r.Body.Read(p)
+}
+
+func (r StreamingResponse) Close() error {
+	if closer, ok := r.Body.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// MarshalJSON fails, so that the response is returned as a reader rather than serialized.
+func (r StreamingResponse) MarshalJSON() ([]byte, error) {
+	return nil, errors.New("not json")
+}
+
+func (r StreamingResponse) contentType() string {
+	if r.ContentType == "" {
+		return contentTypeBytes
+	}
+	return r.ContentType
+}
+
+// withContext returns the reader of the response, which fails with the error of ctx once it is done.
+func (r StreamingResponse) withContext(ctx context.Context) io.Reader {
+	return &contextReader{ctx: ctx, reader: r}
+}
+
+type contextReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.reader.Read(p)
+}
+
+// responseContentType returns the content type of the response returned by a handler.
+func responseContentType(response io.Reader) string {
+	type ContentType interface{ ContentType() string }
+	switch response := response.(type) {
+	case StreamingResponse:
+		return response.contentType()
+	case *StreamingResponse:
+		return response.contentType()
+	case ContentType:
+		return response.ContentType()
+	}
+	return contentTypeBytes
+}
+
+// streamResponse returns the reader of the response sent to the caller, aborted once ctx is done for a
+// StreamingResponse.
+func streamResponse(ctx context.Context, response io.Reader) io.Reader {
+	switch r := response.(type) {
+	case StreamingResponse:
+		return r.withContext(ctx)
+	case *StreamingResponse:
+		return r.withContext(ctx)
+	}
+	return response
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambda/messages"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// streamTestError is read by the handlers after part of their response.
+type streamTestError struct{}
+
+func (streamTestError) Error() string { return "the upstream connection reset" }
+
+// repeatReader reads b repeatedly.
+type repeatReader struct{ b byte }
+
+func (r repeatReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = r.b
+	}
+	return len(p), nil
+}
+
+// invokeStreaming invokes handler once with the runtime API loop, with a deadline a minute away, and returns the
+// request record.
+func invokeStreaming(t *testing.T, handler interface{}, options ...Option) *requestRecord {
+	metadata := defaultInvokeMetadata()
+	metadata.deadline = strconv.FormatInt(time.Now().Add(time.Minute).UnixNano()/nsPerMS, 10)
+	ts, record := runtimeAPIServer(`{}`, 1, metadata)
+	defer ts.Close()
+	endpoint := strings.Split(ts.URL, "://")[1]
+	_ = startRuntimeAPILoop(endpoint, newHandler(handler, options...))
+	require.Len(t, record.responses, 1)
+	return record
+}
+
+func assertStreamError(t *testing.T, record *requestRecord, errorType, message string) {
+	assert.Equal(t, errorType, record.errorTypes[0])
+	errorBody, err := base64.StdEncoding.DecodeString(record.errorBodies[0])
+	require.NoError(t, err)
+	var invokeErr messages.InvokeResponse_Error
+	require.NoError(t, json.Unmarshal(errorBody, &invokeErr))
+	assert.Equal(t, message, invokeErr.Message)
+	assert.Empty(t, record.responseSHA256s[0])
+}
+
+func TestStreamingResponse(t *testing.T) {
+	const size = 16 << 20
+	body := &readCloser{reader: strings.NewReader(strings.Repeat("a", size))}
+	record := invokeStreaming(t, func() (StreamingResponse, error) {
+		return StreamingResponse{ContentType: "text/plain", Body: body}, nil
+	})
+
+	assert.Equal(t, "text/plain", record.contentTypes[0])
+	assert.Len(t, record.responses[0], size)
+	sum := sha256.Sum256(record.responses[0])
+	assert.Equal(t, hex.EncodeToString(sum[:]), record.responseSHA256s[0])
+	assert.Empty(t, record.errorTypes[0])
+	assert.True(t, body.closed)
+}
+
+func TestStreamingResponseDefaultContentType(t *testing.T) {
+	record := invokeStreaming(t, func() (*StreamingResponse, error) {
+		return &StreamingResponse{Body: strings.NewReader("raw")}, nil
+	})
+	assert.Equal(t, contentTypeBytes, record.contentTypes[0])
+	assert.Equal(t, "raw", string(record.responses[0]))
+
+	record = invokeStreaming(t, func() (StreamingResponse, error) { return StreamingResponse{}, nil })
+	assert.Empty(t, record.responses[0])
+}
+
+func TestStreamingResponseReadError(t *testing.T) {
+	record := invokeStreaming(t, func() (StreamingResponse, error) {
+		return StreamingResponse{
+			ContentType: "text/csv",
+			Body:        io.MultiReader(strings.NewReader("id,name\n1,gopher\n"), failingReader{streamTestError{}}),
+		}, nil
+	})
+	assert.Equal(t, "id,name\n1,gopher\n", string(record.responses[0]))
+	assertStreamError(t, record, "streamTestError", "the upstream connection reset")
+}
+
+func TestStreamingResponseContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reads := 0
+	body := io.LimitReader(readFunc(func(p []byte) (int, error) {
+		reads++
+		if reads == 3 {
+			cancel()
+		}
+		return repeatReader{'a'}.Read(p[:1024])
+	}), 1<<30)
+
+	record := invokeStreaming(t, func() (StreamingResponse, error) {
+		return StreamingResponse{Body: body}, nil
+	}, WithContext(ctx))
+	assert.Len(t, record.responses[0], 3*1024)
+	assertStreamError(t, record, "errorString", context.Canceled.Error())
+}
+
+type readFunc func(p []byte) (int, error)
+
+func (f readFunc) Read(p []byte) (int, error) { return f(p) }
+
+func TestStreamingResponseDeadline(t *testing.T) {
+	// the deadline of the default invoke is long past
+	ts, record := runtimeAPIServer(`{}`, 1)
+	defer ts.Close()
+	handler := newHandler(func() (StreamingResponse, error) {
+		return StreamingResponse{Body: repeatReader{'a'}}, nil
+	})
+	endpoint := strings.Split(ts.URL, "://")[1]
+	_ = startRuntimeAPILoop(endpoint, handler)
+
+	assert.Empty(t, record.responses[0])
+	assertStreamError(t, record, "deadlineExceededError", context.DeadlineExceeded.Error())
+}
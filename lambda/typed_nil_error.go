@@ -0,0 +1,50 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"log"
+	"reflect"
+	"runtime"
+	"sync"
+)
+
+// WithStrictTypedNilErrors makes the handler report a function error when it returns a nil pointer of a concrete
+// error type as its error, such as a nil *MyError, which is a non-nil error holding nil.
+// By default, such an error is handled as a success, and a warning is logged the first time the handler returns one.
+func WithStrictTypedNilErrors() Option {
+	return Option(func(h *handlerOptions) {
+		h.strictTypedNilErrors = true
+	})
+}
+
+// isTypedNilError reports whether the error value returned by a handler is a non-nil error holding a nil value.
+func isTypedNilError(errVal reflect.Value) bool {
+	if errVal.Kind() == reflect.Interface {
+		if errVal.IsNil() {
+			return false
+		}
+		errVal = errVal.Elem()
+	}
+	switch errVal.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface:
+		return errVal.IsNil()
+	}
+	return false
+}
+
+// typedNilErrorWarning logs a warning the first time the handler returns a typed nil error.
+type typedNilErrorWarning struct {
+	handler reflect.Value
+	once    sync.Once
+}
+
+func (w *typedNilErrorWarning) warn(err error) {
+	w.once.Do(func() {
+		name := "<unknown>"
+		if f := runtime.FuncForPC(w.handler.Pointer()); f != nil {
+			name = f.Name()
+		}
+		log.Printf("lambda: warning: handler %s returned a nil %T as its error, which is handled as a success", name, err)
+	})
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambda
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type typedNilTestError struct{}
+
+func (e *typedNilTestError) Error() string {
+	return "typed nil test error"
+}
+
+func returnsTypedNilError() (string, error) {
+	var err *typedNilTestError
+	return "ok", err
+}
+
+func TestTypedNilErrorIsSuccess(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	handler := NewHandler(returnsTypedNilError)
+	for i := 0; i < 3; i++ {
+		response, err := handler.Invoke(context.Background(), []byte(`{}`))
+		require.NoError(t, err)
+		assert.Equal(t, `"ok"`, string(response))
+	}
+	assert.Equal(t, 1, strings.Count(logs.String(), "lambda: warning:"), "the warning should be logged once")
+	assert.Contains(t, logs.String(), "handler github.com/aws/aws-lambda-go/lambda.returnsTypedNilError returned a nil *lambda.typedNilTestError as its error")
+
+	// a handler returning the concrete error type
+	logs.Reset()
+	handler = NewHandler(func() *typedNilTestError { return nil })
+	response, err := handler.Invoke(context.Background(), []byte(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, "null", string(response))
+	assert.Contains(t, logs.String(), "returned a nil *lambda.typedNilTestError as its error")
+}
+
+func TestTypedNilErrorStrict(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	handler := NewHandlerWithOptions(returnsTypedNilError, WithStrictTypedNilErrors())
+	_, err := handler.Invoke(context.Background(), []byte(`{}`))
+	var typedErr *typedNilTestError
+	assert.True(t, errors.As(err, &typedErr))
+	assert.Nil(t, typedErr)
+	assert.Empty(t, logs.String())
+}
+
+func TestNilAndNonNilErrors(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	handler := NewHandler(func() (string, error) { return "ok", nil })
+	response, err := handler.Invoke(context.Background(), []byte(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, `"ok"`, string(response))
+
+	handler = NewHandler(func() (string, error) { return "", &typedNilTestError{} })
+	_, err = handler.Invoke(context.Background(), []byte(`{}`))
+	assert.EqualError(t, err, "typed nil test error")
+
+	handler = NewHandler(func() error { return errors.New("failed") })
+	_, err = handler.Invoke(context.Background(), []byte(`{}`))
+	assert.EqualError(t, err, "failed")
+	assert.Empty(t, logs.String())
+}
@@ -0,0 +1,50 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambdacontext
+
+import (
+	"context"
+	"sync"
+)
+
+// canonicalFields collects the fields of an invocation's canonical log entry.
+type canonicalFields struct {
+	mu     sync.Mutex
+	fields map[string]interface{}
+}
+
+type canonicalKey struct{}
+
+// NewCanonicalContext returns a new Context that collects the fields added with AddCanonicalField.
+// The lambda package does this for every invocation when lambda.WithCanonicalLog is used.
+func NewCanonicalContext(parent context.Context) context.Context {
+	return context.WithValue(parent, canonicalKey{}, &canonicalFields{fields: map[string]interface{}{}})
+}
+
+// AddCanonicalField adds a field to the canonical log entry of the invocation ctx belongs to.
+// Adding a key again replaces the previous value. It is safe to call from multiple goroutines,
+// and is a no-op when ctx was not created by NewCanonicalContext.
+func AddCanonicalField(ctx context.Context, key string, value interface{}) {
+	c, ok := ctx.Value(canonicalKey{}).(*canonicalFields)
+	if !ok {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fields[key] = value
+}
+
+// CanonicalFieldsFromContext returns a copy of the fields added to ctx with AddCanonicalField.
+func CanonicalFieldsFromContext(ctx context.Context) (map[string]interface{}, bool) {
+	c, ok := ctx.Value(canonicalKey{}).(*canonicalFields)
+	if !ok {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fields := make(map[string]interface{}, len(c.fields))
+	for k, v := range c.fields {
+		fields[k] = v
+	}
+	return fields, true
+}
@@ -0,0 +1,51 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambdacontext
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalFields(t *testing.T) {
+	ctx := NewCanonicalContext(context.Background())
+	AddCanonicalField(ctx, "orderId", "order-123")
+	AddCanonicalField(ctx, "items", 3)
+	AddCanonicalField(ctx, "items", 4)
+
+	fields, ok := CanonicalFieldsFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, map[string]interface{}{"orderId": "order-123", "items": 4}, fields)
+
+	// the returned map is a copy
+	fields["orderId"] = "changed"
+	fields, _ = CanonicalFieldsFromContext(ctx)
+	assert.Equal(t, "order-123", fields["orderId"])
+}
+
+func TestCanonicalFieldsNotEnabled(t *testing.T) {
+	ctx := context.Background()
+	AddCanonicalField(ctx, "orderId", "order-123")
+	fields, ok := CanonicalFieldsFromContext(ctx)
+	assert.False(t, ok)
+	assert.Nil(t, fields)
+}
+
+func TestCanonicalFieldsConcurrencySafe(t *testing.T) {
+	ctx := NewCanonicalContext(context.Background())
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			AddCanonicalField(ctx, fmt.Sprintf("field-%d", i), i)
+		}(i)
+	}
+	wg.Wait()
+	fields, _ := CanonicalFieldsFromContext(ctx)
+	assert.Len(t, fields, 10)
+}
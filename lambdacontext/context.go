@@ -11,6 +11,9 @@ package lambdacontext
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
 	"os"
 	"strconv"
 )
@@ -57,6 +60,7 @@ func MaxConcurrency() int {
 type ClientApplication struct {
 	InstallationID string `json:"installation_id"`
 	AppTitle       string `json:"app_title"`
+	AppVersionName string `json:"app_version_name,omitempty"`
 	AppVersionCode string `json:"app_version_code"`
 	AppPackageName string `json:"app_package_name"`
 }
@@ -68,6 +72,24 @@ type ClientContext struct {
 	Custom map[string]string `json:"custom"`
 }
 
+// ParseClientContext decodes the client context of an Invoke request, which is base64 encoded JSON, as in the
+// X-Amz-Client-Context header of the Invoke API or the ClientContext parameter of the AWS SDKs.
+// An empty header is an empty ClientContext.
+func ParseClientContext(base64Header string) (ClientContext, error) {
+	var clientContext ClientContext
+	if base64Header == "" {
+		return clientContext, nil
+	}
+	clientContextJSON, err := base64.StdEncoding.DecodeString(base64Header)
+	if err != nil {
+		return clientContext, fmt.Errorf("failed to decode base64 client context: %v", err)
+	}
+	if err := json.Unmarshal(clientContextJSON, &clientContext); err != nil {
+		return clientContext, fmt.Errorf("failed to unmarshal client context json: %v", err)
+	}
+	return clientContext, nil
+}
+
 // CognitoIdentity is the cognito identity used by the calling application.
 type CognitoIdentity struct {
 	CognitoIdentityID     string
@@ -83,6 +105,18 @@ type LambdaContext struct {
 	TenantID           string `json:",omitempty"`
 }
 
+// ClientContextCustomValue returns the value of key in the custom map of the client context, and whether it is set.
+func (lc *LambdaContext) ClientContextCustomValue(key string) (string, bool) {
+	value, ok := lc.ClientContext.Custom[key]
+	return value, ok
+}
+
+// ClientContextEnvValue returns the value of key in the env map of the client context, and whether it is set.
+func (lc *LambdaContext) ClientContextEnvValue(key string) (string, bool) {
+	value, ok := lc.ClientContext.Env[key]
+	return value, ok
+}
+
 // An unexported type to be used as the key for types in this package.
 // This prevents collisions with keys defined in other packages.
 type key struct{}
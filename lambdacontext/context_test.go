@@ -0,0 +1,81 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambdacontext
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseClientContext(t *testing.T) {
+	mobileSDK := base64.StdEncoding.EncodeToString([]byte(`{
+		"client": {
+			"installation_id": "a1b2c3d4-e5f6-47a8-b9c0-d1e2f3a4b5c6",
+			"app_title": "Tacos",
+			"app_version_name": "2.1.0",
+			"app_version_code": "210",
+			"app_package_name": "com.example.tacos"
+		},
+		"env": {
+			"platform": "Android",
+			"model": "Pixel 8",
+			"make": "Google",
+			"platform_version": "14",
+			"locale": "en_US"
+		},
+		"custom": {
+			"customerId": "customer-abc",
+			"experiment": "spicy"
+		}
+	}`))
+
+	clientContext, err := ParseClientContext(mobileSDK)
+	require.NoError(t, err)
+	assert.Equal(t, ClientApplication{
+		InstallationID: "a1b2c3d4-e5f6-47a8-b9c0-d1e2f3a4b5c6",
+		AppTitle:       "Tacos",
+		AppVersionName: "2.1.0",
+		AppVersionCode: "210",
+		AppPackageName: "com.example.tacos",
+	}, clientContext.Client)
+	assert.Equal(t, "Android", clientContext.Env["platform"])
+	assert.Len(t, clientContext.Env, 5)
+
+	lc := &LambdaContext{ClientContext: clientContext}
+	value, ok := lc.ClientContextCustomValue("customerId")
+	assert.True(t, ok)
+	assert.Equal(t, "customer-abc", value)
+	_, ok = lc.ClientContextCustomValue("missing")
+	assert.False(t, ok)
+	value, ok = lc.ClientContextEnvValue("locale")
+	assert.True(t, ok)
+	assert.Equal(t, "en_US", value)
+}
+
+func TestParseClientContextCustomOnly(t *testing.T) {
+	clientContext, err := ParseClientContext(base64.StdEncoding.EncodeToString([]byte(`{"custom": {"tenant": "acme"}}`)))
+	require.NoError(t, err)
+	assert.Equal(t, ClientContext{Custom: map[string]string{"tenant": "acme"}}, clientContext)
+
+	// the accessors are safe with nil maps
+	lc := &LambdaContext{}
+	_, ok := lc.ClientContextCustomValue("tenant")
+	assert.False(t, ok)
+	_, ok = lc.ClientContextEnvValue("platform")
+	assert.False(t, ok)
+}
+
+func TestParseClientContextErrors(t *testing.T) {
+	clientContext, err := ParseClientContext("")
+	require.NoError(t, err)
+	assert.Equal(t, ClientContext{}, clientContext)
+
+	_, err = ParseClientContext("not base64!")
+	assert.EqualError(t, err, "failed to decode base64 client context: illegal base64 data at input byte 3")
+
+	_, err = ParseClientContext(base64.StdEncoding.EncodeToString([]byte(`{"custom": ["not", "a", "map"]}`)))
+	assert.Error(t, err)
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambdacontext
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// InitializationType is how the execution environment was initialized, from AWS_LAMBDA_INITIALIZATION_TYPE.
+type InitializationType string
+
+const (
+	InitializationTypeOnDemand               InitializationType = "on-demand"
+	InitializationTypeProvisionedConcurrency InitializationType = "provisioned-concurrency"
+	InitializationTypeSnapStart              InitializationType = "snap-start"
+)
+
+// initializationType is read once at init, like the other package values.
+var initializationType = InitializationType(os.Getenv("AWS_LAMBDA_INITIALIZATION_TYPE"))
+
+// Environment describes the function and execution environment, as reported by the Lambda environment variables.
+type Environment struct {
+	FunctionName       string
+	FunctionVersion    string
+	MemoryLimitInMB    int
+	LogGroupName       string
+	LogStreamName      string
+	InitializationType InitializationType
+
+	// Timeout is the configured function timeout, from AWS_LAMBDA_FUNCTION_TIMEOUT. The Lambda service
+	// does not set this variable, so it is only known when running in an emulator or when set by the function configuration.
+	// Prefer the deadline of the invocation context.
+	Timeout time.Duration
+}
+
+// EnvironmentInfo reads the Lambda environment variables at the time of the call.
+// Unlike the package variables, which are read once at init and are zero when a value is malformed,
+// EnvironmentInfo returns an error describing the first malformed value, along with every value it could read.
+// Unset variables are not an error.
+func EnvironmentInfo() (Environment, error) {
+	env := Environment{
+		FunctionName:       os.Getenv("AWS_LAMBDA_FUNCTION_NAME"),
+		FunctionVersion:    os.Getenv("AWS_LAMBDA_FUNCTION_VERSION"),
+		LogGroupName:       os.Getenv("AWS_LAMBDA_LOG_GROUP_NAME"),
+		LogStreamName:      os.Getenv("AWS_LAMBDA_LOG_STREAM_NAME"),
+		InitializationType: InitializationType(os.Getenv("AWS_LAMBDA_INITIALIZATION_TYPE")),
+	}
+	var firstErr error
+	if v := os.Getenv("AWS_LAMBDA_FUNCTION_MEMORY_SIZE"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			firstErr = fmt.Errorf("parsing AWS_LAMBDA_FUNCTION_MEMORY_SIZE: %w", err)
+		}
+		env.MemoryLimitInMB = limit
+	}
+	if v := os.Getenv("AWS_LAMBDA_FUNCTION_TIMEOUT"); v != "" {
+		seconds, err := strconv.Atoi(v)
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("parsing AWS_LAMBDA_FUNCTION_TIMEOUT: %w", err)
+		}
+		env.Timeout = time.Duration(seconds) * time.Second
+	}
+	return env, firstErr
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+//go:build go1.17
+// +build go1.17
+
+package lambdacontext
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvironmentInfo(t *testing.T) {
+	t.Setenv("AWS_LAMBDA_FUNCTION_NAME", "my-function")
+	t.Setenv("AWS_LAMBDA_FUNCTION_VERSION", "$LATEST")
+	t.Setenv("AWS_LAMBDA_FUNCTION_MEMORY_SIZE", "512")
+	t.Setenv("AWS_LAMBDA_FUNCTION_TIMEOUT", "30")
+	t.Setenv("AWS_LAMBDA_LOG_GROUP_NAME", "/aws/lambda/my-function")
+	t.Setenv("AWS_LAMBDA_LOG_STREAM_NAME", "2026/03/14/[$LATEST]abcdef")
+	t.Setenv("AWS_LAMBDA_INITIALIZATION_TYPE", "on-demand")
+
+	env, err := EnvironmentInfo()
+	require.NoError(t, err)
+	assert.Equal(t, Environment{
+		FunctionName:       "my-function",
+		FunctionVersion:    "$LATEST",
+		MemoryLimitInMB:    512,
+		LogGroupName:       "/aws/lambda/my-function",
+		LogStreamName:      "2026/03/14/[$LATEST]abcdef",
+		InitializationType: InitializationTypeOnDemand,
+		Timeout:            30 * time.Second,
+	}, env)
+}
+
+func TestEnvironmentInfoInitializationTypes(t *testing.T) {
+	for value, expected := range map[string]InitializationType{
+		"on-demand":               InitializationTypeOnDemand,
+		"provisioned-concurrency": InitializationTypeProvisionedConcurrency,
+		"snap-start":              InitializationTypeSnapStart,
+		"":                        "",
+	} {
+		t.Setenv("AWS_LAMBDA_INITIALIZATION_TYPE", value)
+		env, err := EnvironmentInfo()
+		require.NoError(t, err)
+		assert.Equal(t, expected, env.InitializationType)
+	}
+}
+
+func TestEnvironmentInfoParseErrors(t *testing.T) {
+	t.Setenv("AWS_LAMBDA_FUNCTION_NAME", "my-function")
+	t.Setenv("AWS_LAMBDA_FUNCTION_MEMORY_SIZE", "lots")
+	t.Setenv("AWS_LAMBDA_FUNCTION_TIMEOUT", "30")
+
+	env, err := EnvironmentInfo()
+	assert.EqualError(t, err, `parsing AWS_LAMBDA_FUNCTION_MEMORY_SIZE: strconv.Atoi: parsing "lots": invalid syntax`)
+	assert.Equal(t, "my-function", env.FunctionName)
+	assert.Equal(t, 30*time.Second, env.Timeout)
+
+	t.Setenv("AWS_LAMBDA_FUNCTION_MEMORY_SIZE", "128")
+	t.Setenv("AWS_LAMBDA_FUNCTION_TIMEOUT", "30s")
+	env, err = EnvironmentInfo()
+	assert.EqualError(t, err, `parsing AWS_LAMBDA_FUNCTION_TIMEOUT: strconv.Atoi: parsing "30s": invalid syntax`)
+	assert.Equal(t, 128, env.MemoryLimitInMB)
+}
+
+func TestEnvironmentInfoUnset(t *testing.T) {
+	t.Setenv("AWS_LAMBDA_FUNCTION_MEMORY_SIZE", "")
+	t.Setenv("AWS_LAMBDA_FUNCTION_TIMEOUT", "")
+	env, err := EnvironmentInfo()
+	require.NoError(t, err)
+	assert.Zero(t, env.MemoryLimitInMB)
+	assert.Zero(t, env.Timeout)
+}
@@ -6,6 +6,7 @@ package lambdacontext_test
 import (
 	"context"
 	"log/slog"
+	"os"
 
 	"github.com/aws/aws-lambda-go/lambda"
 	"github.com/aws/aws-lambda-go/lambdacontext"
@@ -63,3 +64,49 @@ func ExampleWithFunctionARN() {
 		return "success", nil
 	})
 }
+
+// ExampleWithField demonstrates using WithField to include custom fields derived from the Lambda context.
+func ExampleWithField() {
+	slog.SetDefault(lambdacontext.NewLogger(
+		lambdacontext.WithField("cognitoIdentityId", func(lc *lambdacontext.LambdaContext) string {
+			return lc.Identity.CognitoIdentityID
+		}),
+		lambdacontext.WithField("customerId", func(lc *lambdacontext.LambdaContext) string {
+			return lc.ClientContext.Custom["customerId"]
+		}),
+	))
+
+	lambda.Start(func(ctx context.Context) (string, error) {
+		// Log output will include "cognitoIdentityId" and "customerId" fields when set
+		slog.InfoContext(ctx, "function invoked")
+		return "success", nil
+	})
+}
+
+// ExampleWrapLogHandler demonstrates adding Lambda context to an existing slog.Handler.
+func ExampleWrapLogHandler() {
+	base := slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug})
+	slog.SetDefault(slog.New(lambdacontext.WrapLogHandler(base, lambdacontext.WithFunctionARN())))
+
+	lambda.Start(func(ctx context.Context) (string, error) {
+		slog.DebugContext(ctx, "function invoked")
+		return "success", nil
+	})
+}
+
+// ExampleLoggerFromContext demonstrates binding the Lambda fields to a logger once per invocation,
+// so that log calls without a context still include requestId.
+func ExampleLoggerFromContext() {
+	logger := lambdacontext.NewLogger()
+
+	lambda.Start(func(ctx context.Context) (string, error) {
+		ctx = lambdacontext.NewContextWithInvocationLogger(ctx, logger)
+		process(ctx)
+		return "success", nil
+	})
+}
+
+func process(ctx context.Context) {
+	log := lambdacontext.LoggerFromContext(ctx)
+	log.Info("processing request", "action", "example")
+}
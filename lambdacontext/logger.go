@@ -7,8 +7,10 @@ package lambdacontext
 
 import (
 	"context"
+	"io"
 	"log/slog"
 	"os"
+	"time"
 )
 
 // logFormat is the log format from AWS_LAMBDA_LOG_FORMAT (TEXT or JSON)
@@ -21,11 +23,32 @@ var logLevel = os.Getenv("AWS_LAMBDA_LOG_LEVEL")
 type field struct {
 	key   string
 	value func(*LambdaContext) string
+	// static is the value of a field that does not depend on the invocation, used when value is nil
+	static slog.Value
+}
+
+// staticFields appends the attributes to fields, except those with an empty string or zero value.
+// Their values are read when the options are applied, at the construction of the handler.
+func staticFields(fields []field, attrs ...slog.Attr) []field {
+	for _, attr := range attrs {
+		switch {
+		case attr.Value.Kind() == slog.KindString && attr.Value.String() == "":
+		case attr.Value.Kind() == slog.KindInt64 && attr.Value.Int64() == 0:
+		default:
+			fields = append(fields, field{key: attr.Key, static: attr.Value})
+		}
+	}
+	return fields
 }
 
 // logOptions holds configuration for the Lambda log handler.
 type logOptions struct {
-	fields []field
+	fields         []field
+	group          string
+	addSource      bool
+	remainingTime  bool
+	flushBufferMax int
+	format         string
 }
 
 // LogOption is a functional option for configuring the Lambda log handler.
@@ -34,43 +57,180 @@ type LogOption func(*logOptions)
 // WithFunctionARN includes the invoked function ARN in log records.
 func WithFunctionARN() LogOption {
 	return func(o *logOptions) {
-		o.fields = append(o.fields, field{"functionArn", func(lc *LambdaContext) string { return lc.InvokedFunctionArn }})
+		o.fields = append(o.fields, field{key: "functionArn", value: func(lc *LambdaContext) string { return lc.InvokedFunctionArn }})
 	}
 }
 
 // WithTenantID includes the tenant ID in log records (for multi-tenant functions).
 func WithTenantID() LogOption {
 	return func(o *logOptions) {
-		o.fields = append(o.fields, field{"tenantId", func(lc *LambdaContext) string { return lc.TenantID }})
+		o.fields = append(o.fields, field{key: "tenantId", value: func(lc *LambdaContext) string { return lc.TenantID }})
+	}
+}
+
+// WithInitializationType includes how the execution environment was initialized, as initializationType,
+// so that invocations of provisioned-concurrency or SnapStart environments can be told apart from on-demand ones.
+// The value is read from AWS_LAMBDA_INITIALIZATION_TYPE at init, and is omitted when unset.
+func WithInitializationType() LogOption {
+	return func(o *logOptions) {
+		o.fields = staticFields(o.fields, slog.String("initializationType", string(initializationType)))
+	}
+}
+
+// WithFunctionVersion includes the version of the function, from FunctionVersion, as functionVersion.
+// The value is read when the handler is created, and is omitted when empty.
+func WithFunctionVersion() LogOption {
+	return func(o *logOptions) {
+		o.fields = staticFields(o.fields, slog.String("functionVersion", FunctionVersion))
+	}
+}
+
+// WithLogStream includes the log group and log stream the function logs to, from LogGroupName and LogStreamName,
+// as logGroupName and logStreamName, to find the stream of a log line exported elsewhere.
+// The values are read when the handler is created, and are omitted when empty.
+func WithLogStream() LogOption {
+	return func(o *logOptions) {
+		o.fields = staticFields(o.fields, slog.String("logGroupName", LogGroupName), slog.String("logStreamName", LogStreamName))
+	}
+}
+
+// WithMemoryLimit includes the memory limit of the function, from MemoryLimitInMB, as the integer memoryLimitInMB.
+// The value is read when the handler is created, and is omitted when zero.
+func WithMemoryLimit() LogOption {
+	return func(o *logOptions) {
+		o.fields = staticFields(o.fields, slog.Int("memoryLimitInMB", MemoryLimitInMB))
+	}
+}
+
+// WithClientContext includes the calling mobile application's title, version code, and installation ID
+// in log records, as clientAppTitle, clientAppVersionCode, and clientInstallationId.
+// Empty values are omitted, so invocations without a ClientContext are unaffected.
+func WithClientContext() LogOption {
+	return func(o *logOptions) {
+		o.fields = append(o.fields,
+			field{key: "clientAppTitle", value: func(lc *LambdaContext) string { return lc.ClientContext.Client.AppTitle }},
+			field{key: "clientAppVersionCode", value: func(lc *LambdaContext) string { return lc.ClientContext.Client.AppVersionCode }},
+			field{key: "clientInstallationId", value: func(lc *LambdaContext) string { return lc.ClientContext.Client.InstallationID }},
+		)
+	}
+}
+
+// WithField includes a custom attribute in log records, computed from the LambdaContext by fn.
+// As with the built-in fields, the attribute is omitted when fn returns an empty string.
+// Multiple fields may be registered; they are added in registration order.
+//
+// fn is called for every log record, and must not retain the *LambdaContext passed to it.
+func WithField(key string, fn func(*LambdaContext) string) LogOption {
+	return func(o *logOptions) {
+		o.fields = append(o.fields, field{key: key, value: fn})
+	}
+}
+
+// WithFieldsGroup nests all injected Lambda fields, including requestId, under a group with the given name.
+// For example, WithFieldsGroup("lambda") produces "lambda": {"requestId": "...", "functionArn": "..."} in JSON output,
+// keeping the Lambda fields separate from application attributes with the same names.
+func WithFieldsGroup(name string) LogOption {
+	return func(o *logOptions) {
+		o.group = name
+	}
+}
+
+// WithSource includes the source file, line, and function of the logging call site in log records,
+// under the "source" key. This is equivalent to setting AddSource in [slog.HandlerOptions].
+func WithSource() LogOption {
+	return func(o *logOptions) {
+		o.addSource = true
+	}
+}
+
+// WithRemainingTime includes the time left before the invocation deadline, in milliseconds, as remainingTimeMs.
+// The value is computed from the deadline of the context passed to the logging call and the time of the record,
+// so use the context-aware logging methods such as slog.InfoContext. It is omitted when the context has no deadline.
+func WithRemainingTime() LogOption {
+	return func(o *logOptions) {
+		o.remainingTime = true
+	}
+}
+
+// WithErrorFlushBuffer holds log records below the configured log level, up to maxRecords per invocation,
+// instead of discarding them. When a record at ERROR level or above is logged for the same request ID,
+// the held records are written first, in order. Otherwise they are dropped when the next invocation logs,
+// so verbose output is only paid for on failing invocations. When more than maxRecords are held, the oldest are dropped.
+//
+// Only one invocation is buffered at a time, so functions with multi-concurrency enabled will lose buffered records
+// when invocations interleave. Records without a Lambda context in the logging context are never buffered.
+func WithErrorFlushBuffer(maxRecords int) LogOption {
+	return func(o *logOptions) {
+		o.flushBufferMax = maxRecords
+	}
+}
+
+// WithFormat sets the output format, "JSON" or "TEXT", overriding AWS_LAMBDA_LOG_FORMAT.
+func WithFormat(format string) LogOption {
+	return func(o *logOptions) {
+		o.format = format
 	}
 }
 
 // NewLogHandler returns a [slog.Handler] for AWS Lambda structured logging.
 // It reads AWS_LAMBDA_LOG_FORMAT and AWS_LAMBDA_LOG_LEVEL from environment,
 // and injects requestId from Lambda context into each log record.
+// In JSON format the time and msg keys are renamed by ReplaceAttr, in TEXT format slog's standard keys are kept.
 //
-// By default, only requestId is injected. Use WithFunctionARN or WithTenantID to include more.
+// By default, only requestId is injected. Use WithFunctionARN, WithTenantID, WithFunctionVersion, WithLogStream,
+// WithMemoryLimit, or WithField to include more.
 // See the package examples for usage.
 func NewLogHandler(opts ...LogOption) slog.Handler {
+	return newLogHandler(os.Stdout, opts...)
+}
+
+func newLogHandler(w io.Writer, opts ...LogOption) slog.Handler {
 	options := &logOptions{}
 	for _, opt := range opts {
 		opt(options)
 	}
 
-	level := parseLogLevel()
+	format := options.format
+	if format == "" {
+		format = logFormat
+	}
+
 	handlerOpts := &slog.HandlerOptions{
-		Level:       level,
-		ReplaceAttr: ReplaceAttr,
+		Level:     parseLogLevel(),
+		AddSource: options.addSource,
 	}
 
 	var h slog.Handler
-	if logFormat == "JSON" {
-		h = slog.NewJSONHandler(os.Stdout, handlerOpts)
+	if format == "JSON" {
+		handlerOpts.ReplaceAttr = ReplaceAttr
+		h = slog.NewJSONHandler(w, handlerOpts)
 	} else {
-		h = slog.NewTextHandler(os.Stdout, handlerOpts)
+		h = slog.NewTextHandler(w, handlerOpts)
 	}
 
-	return &lambdaHandler{handler: h, fields: options.fields}
+	return wrapLogHandler(h, options)
+}
+
+// WrapLogHandler returns a [slog.Handler] that injects requestId, and any fields selected by opts,
+// from the Lambda context into each log record before passing it to base.
+// Use it to add Lambda context to a handler other than the standard library JSON or text handlers.
+//
+// Options that configure the underlying handler, such as WithSource, have no effect here;
+// configure base directly instead.
+func WrapLogHandler(base slog.Handler, opts ...LogOption) slog.Handler {
+	options := &logOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return wrapLogHandler(base, options)
+}
+
+func wrapLogHandler(base slog.Handler, options *logOptions) *lambdaHandler {
+	h := &lambdaHandler{handler: base, fields: options.fields, group: options.group, remainingTime: options.remainingTime}
+	if options.flushBufferMax > 0 {
+		h.buffer = &logBuffer{max: options.flushBufferMax}
+	}
+	return h
 }
 
 // NewLogger returns a [*slog.Logger] configured for AWS Lambda structured logging.
@@ -96,23 +256,88 @@ func ReplaceAttr(groups []string, attr slog.Attr) slog.Attr {
 
 // lambdaHandler wraps a slog.Handler to inject Lambda context fields.
 type lambdaHandler struct {
-	handler slog.Handler
-	fields  []field
+	handler       slog.Handler
+	fields        []field
+	group         string
+	remainingTime bool
+	buffer        *logBuffer
+	// bound is the Lambda context bound by NewContextWithInvocationLogger, used when the logging context has none
+	bound *LambdaContext
 }
 
 // Enabled implements slog.Handler.
 func (h *lambdaHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	// when buffering, records of an invocation below the level are still handled, to be held until an error is
+	// logged. Records without a Lambda context are never buffered.
+	if h.buffer != nil && h.hasLambdaContext(ctx) {
+		return true
+	}
 	return h.handler.Enabled(ctx, level)
 }
 
+// hasLambdaContext reports whether the records logged with ctx carry a Lambda context.
+func (h *lambdaHandler) hasLambdaContext(ctx context.Context) bool {
+	if h.bound != nil {
+		return true
+	}
+	_, ok := FromContext(ctx)
+	return ok
+}
+
 // Handle implements slog.Handler.
 func (h *lambdaHandler) Handle(ctx context.Context, r slog.Record) error {
-	if lc, ok := FromContext(ctx); ok {
-		r.AddAttrs(slog.String("requestId", lc.AwsRequestID))
+	var attrs []slog.Attr
+	lc, hasLambdaContext := FromContext(ctx)
+	if !hasLambdaContext && h.bound != nil {
+		lc, hasLambdaContext = h.bound, true
+	}
+	if hasLambdaContext {
+		attrs = make([]slog.Attr, 0, len(h.fields)+2)
+		attrs = append(attrs, slog.String("requestId", lc.AwsRequestID))
+	}
+	for _, field := range h.fields {
+		// the static fields do not depend on the invocation, and are added to every record
+		if field.value == nil {
+			attrs = append(attrs, slog.Attr{Key: field.key, Value: field.static})
+			continue
+		}
+		if !hasLambdaContext {
+			continue
+		}
+		if v := field.value(lc); v != "" {
+			attrs = append(attrs, slog.String(field.key, v))
+		}
+	}
+	if h.remainingTime {
+		if deadline, ok := ctx.Deadline(); ok {
+			now := r.Time
+			if now.IsZero() {
+				now = time.Now()
+			}
+			attrs = append(attrs, slog.Int64("remainingTimeMs", deadline.Sub(now).Milliseconds()))
+		}
+	}
 
-		for _, field := range h.fields {
-			if v := field.value(lc); v != "" {
-				r.AddAttrs(slog.String(field.key, v))
+	if len(attrs) > 0 {
+		if h.group != "" {
+			r.AddAttrs(slog.Attr{Key: h.group, Value: slog.GroupValue(attrs...)})
+		} else {
+			r.AddAttrs(attrs...)
+		}
+	}
+
+	if h.buffer != nil {
+		if !h.handler.Enabled(ctx, r.Level) {
+			if hasLambdaContext {
+				h.buffer.add(lc.AwsRequestID, bufferedRecord{handler: h.handler, ctx: ctx, record: r.Clone()})
+			}
+			return nil
+		}
+		if r.Level >= slog.LevelError && hasLambdaContext {
+			for _, b := range h.buffer.take(lc.AwsRequestID) {
+				if err := b.handler.Handle(b.ctx, b.record); err != nil {
+					return err
+				}
 			}
 		}
 	}
@@ -121,18 +346,19 @@ func (h *lambdaHandler) Handle(ctx context.Context, r slog.Record) error {
 
 // WithAttrs implements slog.Handler.
 func (h *lambdaHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
-	return &lambdaHandler{
-		handler: h.handler.WithAttrs(attrs),
-		fields:  h.fields,
-	}
+	return h.clone(h.handler.WithAttrs(attrs))
 }
 
 // WithGroup implements slog.Handler.
 func (h *lambdaHandler) WithGroup(name string) slog.Handler {
-	return &lambdaHandler{
-		handler: h.handler.WithGroup(name),
-		fields:  h.fields,
-	}
+	return h.clone(h.handler.WithGroup(name))
+}
+
+// clone returns a copy of h wrapping handler. The copy shares the buffer of h, if any.
+func (h *lambdaHandler) clone(handler slog.Handler) *lambdaHandler {
+	c := *h
+	c.handler = handler
+	return &c
 }
 
 func parseLogLevel() slog.Level {
@@ -0,0 +1,57 @@
+//go:build go1.21
+// +build go1.21
+
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambdacontext
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// logBuffer holds the records below the log level for the current invocation, see WithErrorFlushBuffer.
+// It is shared by a handler and all of its WithAttrs and WithGroup clones.
+type logBuffer struct {
+	mu        sync.Mutex
+	max       int
+	requestID string
+	records   []bufferedRecord
+}
+
+// bufferedRecord is a record waiting to be written to the handler it was logged with.
+type bufferedRecord struct {
+	handler slog.Handler
+	ctx     context.Context
+	record  slog.Record
+}
+
+// add appends a record for requestID, dropping records held for any other request, and the oldest record if full.
+func (b *logBuffer) add(requestID string, record bufferedRecord) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.reset(requestID)
+	if len(b.records) >= b.max {
+		b.records[0] = bufferedRecord{}
+		b.records = b.records[1:]
+	}
+	b.records = append(b.records, record)
+}
+
+// take removes and returns the records held for requestID.
+func (b *logBuffer) take(requestID string) []bufferedRecord {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.reset(requestID)
+	records := b.records
+	b.records = nil
+	return records
+}
+
+func (b *logBuffer) reset(requestID string) {
+	if b.requestID != requestID {
+		b.requestID = requestID
+		b.records = nil
+	}
+}
@@ -0,0 +1,50 @@
+//go:build go1.21
+// +build go1.21
+
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambdacontext
+
+import (
+	"context"
+	"log/slog"
+)
+
+type loggerKey struct{}
+
+// NewContextWithLogger returns a new Context that carries logger, to be retrieved with LoggerFromContext.
+// The logger is stored as is; use NewContextWithInvocationLogger to bind the Lambda fields first.
+func NewContextWithLogger(parent context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(parent, loggerKey{}, logger)
+}
+
+// LoggerFromContext returns the logger stored in ctx by NewContextWithLogger or NewContextWithInvocationLogger,
+// or slog.Default() if there is none.
+func LoggerFromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok && logger != nil {
+		return logger
+	}
+	return slog.Default()
+}
+
+// NewContextWithInvocationLogger binds the Lambda fields of the invocation in ctx to base, and returns a new Context
+// carrying the resulting logger. Call it at the start of each invocation, so that plain logger.Info calls on the
+// logger returned by LoggerFromContext include requestId without passing ctx. A nil base means slog.Default().
+//
+// If base uses a handler created by NewLogHandler or WrapLogHandler, the fields selected by its options are bound.
+// Otherwise only requestId is bound. If ctx has no Lambda context, base is stored unchanged.
+func NewContextWithInvocationLogger(ctx context.Context, base *slog.Logger) context.Context {
+	if base == nil {
+		base = slog.Default()
+	}
+	lc, ok := FromContext(ctx)
+	if !ok {
+		return NewContextWithLogger(ctx, base)
+	}
+	if h, ok := base.Handler().(*lambdaHandler); ok {
+		bound := h.clone(h.handler)
+		bound.bound = lc
+		return NewContextWithLogger(ctx, slog.New(bound))
+	}
+	return NewContextWithLogger(ctx, base.With("requestId", lc.AwsRequestID))
+}
@@ -0,0 +1,72 @@
+//go:build go1.21
+// +build go1.21
+
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambdacontext
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoggerFromContextFallback(t *testing.T) {
+	assert.Same(t, slog.Default(), LoggerFromContext(context.Background()))
+}
+
+func TestNewContextWithLogger(t *testing.T) {
+	logger := slog.New(newRecordingHandler())
+	ctx := NewContextWithLogger(context.Background(), logger)
+	assert.Same(t, logger, LoggerFromContext(ctx))
+}
+
+func TestNewContextWithInvocationLogger(t *testing.T) {
+	var buf bytes.Buffer
+	handler := &lambdaHandler{
+		handler: slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: ReplaceAttr}),
+		fields:  []field{{key: "functionArn", value: func(lc *LambdaContext) string { return lc.InvokedFunctionArn }}},
+	}
+	lc := &LambdaContext{AwsRequestID: "test-request-123", InvokedFunctionArn: "arn:aws:lambda:us-east-1:123456789:function:test"}
+	ctx := NewContextWithInvocationLogger(NewContext(context.Background(), lc), slog.New(handler))
+
+	// plain Info, without ctx, still carries the Lambda fields exactly once
+	LoggerFromContext(ctx).Info("test message")
+
+	var logOutput map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &logOutput))
+	assert.Equal(t, "test message", logOutput["message"])
+	assert.Equal(t, "test-request-123", logOutput["requestId"])
+	assert.Equal(t, "arn:aws:lambda:us-east-1:123456789:function:test", logOutput["functionArn"])
+	assert.Equal(t, 1, bytes.Count(buf.Bytes(), []byte(`"requestId"`)))
+
+	// the base logger is not affected
+	buf.Reset()
+	slog.New(handler).Info("unbound message")
+	assert.NotContains(t, buf.String(), "requestId")
+}
+
+func TestNewContextWithInvocationLoggerOtherHandler(t *testing.T) {
+	base := newRecordingHandler()
+	lc := &LambdaContext{AwsRequestID: "test-request-123"}
+	ctx := NewContextWithInvocationLogger(NewContext(context.Background(), lc), slog.New(base))
+
+	LoggerFromContext(ctx).Info("test message")
+
+	require.Len(t, *base.records, 1)
+	assert.Equal(t, "test-request-123", (*base.records)[0]["requestId"].String())
+}
+
+func TestNewContextWithInvocationLoggerNoLambdaContext(t *testing.T) {
+	logger := slog.New(newRecordingHandler())
+	ctx := NewContextWithInvocationLogger(context.Background(), logger)
+	assert.Same(t, logger, LoggerFromContext(ctx))
+
+	ctx = NewContextWithInvocationLogger(context.Background(), nil)
+	assert.Same(t, slog.Default(), LoggerFromContext(ctx))
+}
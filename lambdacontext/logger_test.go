@@ -9,8 +9,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"log/slog"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -41,6 +47,12 @@ func TestReplaceAttr(t *testing.T) {
 			attr:     slog.String(slog.LevelKey, "INFO"),
 			expected: slog.String(slog.LevelKey, "INFO"),
 		},
+		{
+			name:     "source unchanged",
+			groups:   nil,
+			attr:     slog.String(slog.SourceKey, "main.go:12"),
+			expected: slog.String(slog.SourceKey, "main.go:12"),
+		},
 		{
 			name:     "custom key unchanged",
 			groups:   nil,
@@ -385,6 +397,469 @@ func TestWithTenantID(t *testing.T) {
 	assert.Equal(t, "tenant-abc", options.fields[0].value(lc))
 }
 
+func TestWithInitializationType(t *testing.T) {
+	defer func(v InitializationType) { initializationType = v }(initializationType)
+	initializationType = InitializationTypeProvisionedConcurrency
+
+	var buf bytes.Buffer
+	logger := slog.New(newLogHandler(&buf, WithFormat("JSON"), WithInitializationType()))
+	logger.InfoContext(NewContext(context.Background(), &LambdaContext{AwsRequestID: "test-request-123"}), "test message")
+
+	var logOutput map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &logOutput))
+	assert.Equal(t, "provisioned-concurrency", logOutput["initializationType"])
+
+	// the value is added to the records logged outside of an invocation
+	buf.Reset()
+	logger.Info("outside of an invocation")
+	logOutput = nil
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &logOutput))
+	assert.Equal(t, "provisioned-concurrency", logOutput["initializationType"])
+
+	initializationType = ""
+	buf.Reset()
+	logger = slog.New(newLogHandler(&buf, WithFormat("JSON"), WithInitializationType()))
+	logger.InfoContext(NewContext(context.Background(), &LambdaContext{AwsRequestID: "test-request-123"}), "test message")
+	logOutput = nil
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &logOutput))
+	assert.NotContains(t, logOutput, "initializationType")
+}
+
+// setStaticGlobals sets the package values of the static log fields, and returns a function restoring them.
+func setStaticGlobals(version, group, stream string, memory int) func() {
+	savedVersion, savedGroup, savedStream, savedMemory := FunctionVersion, LogGroupName, LogStreamName, MemoryLimitInMB
+	FunctionVersion, LogGroupName, LogStreamName, MemoryLimitInMB = version, group, stream, memory
+	return func() {
+		FunctionVersion, LogGroupName, LogStreamName, MemoryLimitInMB = savedVersion, savedGroup, savedStream, savedMemory
+	}
+}
+
+func TestLogHandler_WithStaticFields(t *testing.T) {
+	defer setStaticGlobals("7", "/aws/lambda/test-function", "2026/10/16/[7]0123456789abcdef", 1024)()
+
+	var buf bytes.Buffer
+	logger := slog.New(newLogHandler(&buf, WithFormat("JSON"), WithFunctionVersion(), WithLogStream(), WithMemoryLimit()))
+
+	// the values are read when the handler is created
+	setStaticGlobals("8", "", "", 0)
+	logger.InfoContext(NewContext(context.Background(), &LambdaContext{AwsRequestID: "test-request-123"}), "test message")
+
+	assert.Contains(t, buf.String(), `"memoryLimitInMB":1024`)
+	var logOutput map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &logOutput))
+	assert.Equal(t, "test-request-123", logOutput["requestId"])
+	assert.Equal(t, "7", logOutput["functionVersion"])
+	assert.Equal(t, "/aws/lambda/test-function", logOutput["logGroupName"])
+	assert.Equal(t, "2026/10/16/[7]0123456789abcdef", logOutput["logStreamName"])
+	assert.Equal(t, float64(1024), logOutput["memoryLimitInMB"])
+
+	// unlike the fields of the Lambda context, they are added to the records logged outside of an invocation
+	buf.Reset()
+	logger.Info("outside of an invocation")
+	logOutput = nil
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &logOutput))
+	assert.NotContains(t, logOutput, "requestId")
+	assert.Equal(t, "7", logOutput["functionVersion"])
+	assert.Equal(t, float64(1024), logOutput["memoryLimitInMB"])
+}
+
+func TestLogHandler_WithStaticFieldsAttributes(t *testing.T) {
+	defer setStaticGlobals("$LATEST", "/aws/lambda/test-function", "stream", 512)()
+
+	base := newRecordingHandler()
+	handler := WrapLogHandler(base, WithMemoryLimit(), WithFunctionVersion())
+	slog.New(handler).InfoContext(NewContext(context.Background(), &LambdaContext{AwsRequestID: "test-request-123"}), "test message")
+
+	require.Len(t, *base.records, 1)
+	record := (*base.records)[0]
+	assert.Equal(t, slog.KindInt64, record["memoryLimitInMB"].Kind())
+	assert.Equal(t, int64(512), record["memoryLimitInMB"].Int64())
+	assert.Equal(t, slog.KindString, record["functionVersion"].Kind())
+	assert.Equal(t, "$LATEST", record["functionVersion"].String())
+	assert.NotContains(t, record, "logStreamName")
+}
+
+func TestLogHandler_WithStaticFieldsEmpty(t *testing.T) {
+	defer setStaticGlobals("", "", "", 0)()
+
+	var buf bytes.Buffer
+	logger := slog.New(newLogHandler(&buf, WithFormat("JSON"), WithFunctionVersion(), WithLogStream(), WithMemoryLimit()))
+	logger.InfoContext(NewContext(context.Background(), &LambdaContext{AwsRequestID: "test-request-123"}), "test message")
+
+	var logOutput map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &logOutput))
+	assert.Equal(t, "test-request-123", logOutput["requestId"])
+	for _, key := range []string{"functionVersion", "logGroupName", "logStreamName", "memoryLimitInMB"} {
+		assert.NotContains(t, logOutput, key)
+	}
+}
+
+func TestLogHandler_WithClientContext(t *testing.T) {
+	for name, tt := range map[string]struct {
+		client   ClientApplication
+		expected map[string]string
+	}{
+		"populated": {
+			client: ClientApplication{
+				InstallationID: "install-123",
+				AppTitle:       "MyApp",
+				AppVersionCode: "1.2.3",
+				AppPackageName: "com.example.myapp",
+			},
+			expected: map[string]string{
+				"clientAppTitle":       "MyApp",
+				"clientAppVersionCode": "1.2.3",
+				"clientInstallationId": "install-123",
+			},
+		},
+		"partial": {
+			client: ClientApplication{AppTitle: "MyApp"},
+			expected: map[string]string{
+				"clientAppTitle": "MyApp",
+			},
+		},
+		"zero value": {
+			expected: map[string]string{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			opts := &slog.HandlerOptions{
+				Level:       slog.LevelInfo,
+				ReplaceAttr: ReplaceAttr,
+			}
+			baseHandler := slog.NewJSONHandler(&buf, opts)
+
+			options := &logOptions{}
+			WithClientContext()(options)
+
+			handler := &lambdaHandler{
+				handler: baseHandler,
+				fields:  options.fields,
+			}
+
+			lc := &LambdaContext{
+				AwsRequestID:  "test-request-123",
+				ClientContext: ClientContext{Client: tt.client},
+			}
+			ctx := NewContext(context.Background(), lc)
+
+			logger := slog.New(handler)
+			logger.InfoContext(ctx, "test message")
+
+			var logOutput map[string]interface{}
+			err := json.Unmarshal(buf.Bytes(), &logOutput)
+			require.NoError(t, err)
+
+			assert.Equal(t, "test-request-123", logOutput["requestId"])
+			for _, key := range []string{"clientAppTitle", "clientAppVersionCode", "clientInstallationId"} {
+				if v, ok := tt.expected[key]; ok {
+					assert.Equal(t, v, logOutput[key])
+				} else {
+					assert.NotContains(t, logOutput, key)
+				}
+			}
+		})
+	}
+}
+
+func TestWithField(t *testing.T) {
+	options := &logOptions{}
+	WithField("cognitoIdentityId", func(lc *LambdaContext) string { return lc.Identity.CognitoIdentityID })(options)
+
+	assert.Len(t, options.fields, 1)
+	assert.Equal(t, "cognitoIdentityId", options.fields[0].key)
+
+	lc := &LambdaContext{Identity: CognitoIdentity{CognitoIdentityID: "identity-123"}}
+	assert.Equal(t, "identity-123", options.fields[0].value(lc))
+}
+
+func TestLogHandler_WithCustomFields(t *testing.T) {
+	var buf bytes.Buffer
+
+	opts := &slog.HandlerOptions{
+		Level:       slog.LevelInfo,
+		ReplaceAttr: ReplaceAttr,
+	}
+	baseHandler := slog.NewJSONHandler(&buf, opts)
+
+	options := &logOptions{}
+	WithField("cognitoIdentityId", func(lc *LambdaContext) string { return lc.Identity.CognitoIdentityID })(options)
+	WithField("customerId", func(lc *LambdaContext) string { return lc.ClientContext.Custom["customerId"] })(options)
+	WithField("empty", func(lc *LambdaContext) string { return "" })(options)
+
+	handler := &lambdaHandler{
+		handler: baseHandler,
+		fields:  options.fields,
+	}
+
+	lc := &LambdaContext{
+		AwsRequestID:  "test-request-123",
+		Identity:      CognitoIdentity{CognitoIdentityID: "identity-123"},
+		ClientContext: ClientContext{Custom: map[string]string{"customerId": "customer-abc"}},
+	}
+	ctx := NewContext(context.Background(), lc)
+
+	logger := slog.New(handler).With("service", "test-service").WithGroup("app")
+	logger.InfoContext(ctx, "test message")
+
+	var logOutput map[string]interface{}
+	err := json.Unmarshal(buf.Bytes(), &logOutput)
+	require.NoError(t, err)
+
+	assert.Equal(t, "test-service", logOutput["service"])
+	app, ok := logOutput["app"].(map[string]interface{})
+	require.True(t, ok, "expected 'app' group in output: %s", buf.String())
+	assert.Equal(t, "test-request-123", app["requestId"])
+	assert.Equal(t, "identity-123", app["cognitoIdentityId"])
+	assert.Equal(t, "customer-abc", app["customerId"])
+	assert.NotContains(t, app, "empty")
+}
+
+func TestLogHandler_WithFieldsGroup(t *testing.T) {
+	var buf bytes.Buffer
+
+	opts := &slog.HandlerOptions{
+		Level:       slog.LevelInfo,
+		ReplaceAttr: ReplaceAttr,
+	}
+	baseHandler := slog.NewJSONHandler(&buf, opts)
+
+	options := &logOptions{}
+	WithFunctionARN()(options)
+	WithFieldsGroup("lambda")(options)
+
+	handler := &lambdaHandler{
+		handler: baseHandler,
+		fields:  options.fields,
+		group:   options.group,
+	}
+
+	lc := &LambdaContext{
+		AwsRequestID:       "test-request-123",
+		InvokedFunctionArn: "arn:aws:lambda:us-east-1:123456789:function:test",
+	}
+	ctx := NewContext(context.Background(), lc)
+
+	logger := slog.New(handler)
+	logger.InfoContext(ctx, "test message", "requestId", "application-value")
+
+	var logOutput map[string]interface{}
+	err := json.Unmarshal(buf.Bytes(), &logOutput)
+	require.NoError(t, err)
+
+	assert.Equal(t, "application-value", logOutput["requestId"])
+	assert.NotContains(t, logOutput, "functionArn")
+	group, ok := logOutput["lambda"].(map[string]interface{})
+	require.True(t, ok, "expected 'lambda' group in output: %s", buf.String())
+	assert.Equal(t, "test-request-123", group["requestId"])
+	assert.Equal(t, "arn:aws:lambda:us-east-1:123456789:function:test", group["functionArn"])
+}
+
+func TestLogHandler_WithFieldsGroupAndWithGroup(t *testing.T) {
+	var buf bytes.Buffer
+
+	opts := &slog.HandlerOptions{
+		Level:       slog.LevelInfo,
+		ReplaceAttr: ReplaceAttr,
+	}
+	baseHandler := slog.NewJSONHandler(&buf, opts)
+
+	options := &logOptions{}
+	WithFieldsGroup("lambda")(options)
+
+	handler := &lambdaHandler{
+		handler: baseHandler,
+		fields:  options.fields,
+		group:   options.group,
+	}
+
+	lc := &LambdaContext{AwsRequestID: "test-request-123"}
+	ctx := NewContext(context.Background(), lc)
+
+	logger := slog.New(handler).WithGroup("app").With("version", "1.0")
+	logger.InfoContext(ctx, "test message")
+
+	var logOutput map[string]interface{}
+	err := json.Unmarshal(buf.Bytes(), &logOutput)
+	require.NoError(t, err)
+
+	app, ok := logOutput["app"].(map[string]interface{})
+	require.True(t, ok, "expected 'app' group in output: %s", buf.String())
+	assert.Equal(t, "1.0", app["version"])
+	assert.NotContains(t, app, "requestId")
+	group, ok := app["lambda"].(map[string]interface{})
+	require.True(t, ok, "expected 'lambda' group in output: %s", buf.String())
+	assert.Equal(t, "test-request-123", group["requestId"])
+}
+
+func TestLogHandler_WithSource(t *testing.T) {
+	var buf bytes.Buffer
+
+	defer func(format string) { logFormat = format }(logFormat)
+	logFormat = "JSON"
+
+	handler := newLogHandler(&buf, WithSource())
+
+	lc := &LambdaContext{AwsRequestID: "test-request-123"}
+	ctx := NewContext(context.Background(), lc)
+
+	logger := slog.New(handler)
+	logger.InfoContext(ctx, "test message")
+
+	var logOutput map[string]interface{}
+	err := json.Unmarshal(buf.Bytes(), &logOutput)
+	require.NoError(t, err)
+
+	assert.Equal(t, "test-request-123", logOutput["requestId"])
+	source, ok := logOutput["source"].(map[string]interface{})
+	require.True(t, ok, "expected 'source' in output: %s", buf.String())
+	assert.Equal(t, "logger_test.go", filepath.Base(source["file"].(string)))
+	assert.Contains(t, source["function"], "TestLogHandler_WithSource")
+}
+
+func TestLogHandler_WithoutSource(t *testing.T) {
+	var buf bytes.Buffer
+
+	defer func(format string) { logFormat = format }(logFormat)
+	logFormat = "JSON"
+
+	logger := slog.New(newLogHandler(&buf))
+	logger.Info("test message")
+
+	var logOutput map[string]interface{}
+	err := json.Unmarshal(buf.Bytes(), &logOutput)
+	require.NoError(t, err)
+
+	assert.NotContains(t, logOutput, "source")
+}
+
+// parseTextLine parses a line of slog text handler output into its keys and values.
+func parseTextLine(t *testing.T, line string) map[string]string {
+	values := map[string]string{}
+	for line = strings.TrimSpace(line); line != ""; line = strings.TrimLeft(line, " ") {
+		eq := strings.IndexByte(line, '=')
+		require.Positive(t, eq, "malformed text output: %s", line)
+		key := line[:eq]
+		line = line[eq+1:]
+		var value string
+		if strings.HasPrefix(line, `"`) {
+			quoted, err := strconv.QuotedPrefix(line)
+			require.NoError(t, err)
+			value, err = strconv.Unquote(quoted)
+			require.NoError(t, err)
+			line = line[len(quoted):]
+		} else {
+			end := strings.IndexByte(line, ' ')
+			if end < 0 {
+				end = len(line)
+			}
+			value, line = line[:end], line[end:]
+		}
+		values[key] = value
+	}
+	return values
+}
+
+func TestLogHandler_TextFormat(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(newLogHandler(&buf, WithFormat("TEXT"), WithFunctionARN()))
+
+	lc := &LambdaContext{
+		AwsRequestID:       "test-request-123",
+		InvokedFunctionArn: "arn:aws:lambda:us-east-1:123456789:function:test",
+	}
+	logger.InfoContext(NewContext(context.Background(), lc), "test message", "key", "a value")
+
+	logOutput := parseTextLine(t, buf.String())
+	assert.Equal(t, "INFO", logOutput["level"])
+	assert.Equal(t, "test message", logOutput["msg"])
+	assert.Equal(t, "test-request-123", logOutput["requestId"])
+	assert.Equal(t, "arn:aws:lambda:us-east-1:123456789:function:test", logOutput["functionArn"])
+	assert.Equal(t, "a value", logOutput["key"])
+	assert.Contains(t, logOutput, "time")
+	assert.NotContains(t, logOutput, "message")
+	assert.NotContains(t, logOutput, "timestamp")
+}
+
+func TestLogHandler_WithFormatOverridesEnv(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(newLogHandler(&buf, WithFormat("JSON")))
+
+	lc := &LambdaContext{AwsRequestID: "test-request-123"}
+	logger.InfoContext(NewContext(context.Background(), lc), "test message")
+
+	var logOutput map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &logOutput))
+	assert.Equal(t, "test message", logOutput["message"])
+	assert.Equal(t, "test-request-123", logOutput["requestId"])
+	assert.Contains(t, logOutput, "timestamp")
+}
+
+func TestLogHandler_WithRemainingTime(t *testing.T) {
+	var buf bytes.Buffer
+
+	opts := &slog.HandlerOptions{
+		Level:       slog.LevelInfo,
+		ReplaceAttr: ReplaceAttr,
+	}
+	baseHandler := slog.NewJSONHandler(&buf, opts)
+
+	options := &logOptions{}
+	WithRemainingTime()(options)
+
+	handler := &lambdaHandler{
+		handler:       baseHandler,
+		fields:        options.fields,
+		remainingTime: options.remainingTime,
+	}
+
+	lc := &LambdaContext{AwsRequestID: "test-request-123"}
+	ctx, cancel := context.WithDeadline(NewContext(context.Background(), lc), time.Now().Add(3*time.Second))
+	defer cancel()
+
+	logger := slog.New(handler)
+	logger.InfoContext(ctx, "test message")
+
+	var logOutput map[string]interface{}
+	err := json.Unmarshal(buf.Bytes(), &logOutput)
+	require.NoError(t, err)
+
+	assert.Equal(t, "test-request-123", logOutput["requestId"])
+	remaining, ok := logOutput["remainingTimeMs"].(float64)
+	require.True(t, ok, "expected 'remainingTimeMs' in output: %s", buf.String())
+	assert.InDelta(t, 3000, remaining, 250)
+}
+
+func TestLogHandler_WithRemainingTimeNoDeadline(t *testing.T) {
+	var buf bytes.Buffer
+
+	opts := &slog.HandlerOptions{
+		Level:       slog.LevelInfo,
+		ReplaceAttr: ReplaceAttr,
+	}
+	baseHandler := slog.NewJSONHandler(&buf, opts)
+
+	handler := &lambdaHandler{
+		handler:       baseHandler,
+		remainingTime: true,
+	}
+
+	lc := &LambdaContext{AwsRequestID: "test-request-123"}
+	ctx := NewContext(context.Background(), lc)
+
+	logger := slog.New(handler)
+	logger.InfoContext(ctx, "test message")
+
+	var logOutput map[string]interface{}
+	err := json.Unmarshal(buf.Bytes(), &logOutput)
+	require.NoError(t, err)
+
+	assert.Equal(t, "test-request-123", logOutput["requestId"])
+	assert.NotContains(t, logOutput, "remainingTimeMs")
+}
+
 func TestNewLogger(t *testing.T) {
 	logger := NewLogger()
 	assert.NotNil(t, logger)
@@ -397,3 +872,180 @@ func TestNewLogHandler(t *testing.T) {
 	handlerWithOpts := NewLogHandler(WithFunctionARN(), WithTenantID())
 	assert.NotNil(t, handlerWithOpts)
 }
+
+func newBufferedTestLogger(t *testing.T, buf *bytes.Buffer, maxRecords int) *slog.Logger {
+	defer func(format, level string) { logFormat, logLevel = format, level }(logFormat, logLevel)
+	logFormat, logLevel = "JSON", "INFO"
+	return slog.New(newLogHandler(buf, WithErrorFlushBuffer(maxRecords)))
+}
+
+func logMessages(t *testing.T, buf *bytes.Buffer) []string {
+	var messages []string
+	for _, line := range bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		var logOutput map[string]interface{}
+		require.NoError(t, json.Unmarshal(line, &logOutput))
+		messages = append(messages, logOutput["message"].(string))
+	}
+	return messages
+}
+
+func TestLogHandler_ErrorFlushBuffer(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newBufferedTestLogger(t, &buf, 10)
+
+	ctx := NewContext(context.Background(), &LambdaContext{AwsRequestID: "request-aaa"})
+	logger.DebugContext(ctx, "debug 1")
+	logger.With("key", "value").DebugContext(ctx, "debug 2")
+	logger.InfoContext(ctx, "info 1")
+	assert.Equal(t, []string{"info 1"}, logMessages(t, &buf))
+
+	logger.ErrorContext(ctx, "error 1")
+	assert.Equal(t, []string{"info 1", "debug 1", "debug 2", "error 1"}, logMessages(t, &buf))
+	assert.Contains(t, buf.String(), `"key":"value"`)
+
+	// the buffer is emptied by the flush
+	logger.ErrorContext(ctx, "error 2")
+	assert.Equal(t, []string{"info 1", "debug 1", "debug 2", "error 1", "error 2"}, logMessages(t, &buf))
+}
+
+func TestLogHandler_ErrorFlushBufferDropOnNewRequest(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newBufferedTestLogger(t, &buf, 10)
+
+	ctx1 := NewContext(context.Background(), &LambdaContext{AwsRequestID: "request-aaa"})
+	ctx2 := NewContext(context.Background(), &LambdaContext{AwsRequestID: "request-bbb"})
+	logger.DebugContext(ctx1, "debug from aaa")
+	logger.DebugContext(ctx2, "debug from bbb")
+	logger.ErrorContext(ctx2, "error from bbb")
+	logger.ErrorContext(ctx1, "error from aaa")
+
+	assert.Equal(t, []string{"debug from bbb", "error from bbb", "error from aaa"}, logMessages(t, &buf))
+}
+
+func TestLogHandler_ErrorFlushBufferEviction(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newBufferedTestLogger(t, &buf, 2)
+
+	ctx := NewContext(context.Background(), &LambdaContext{AwsRequestID: "request-aaa"})
+	logger.DebugContext(ctx, "debug 1")
+	logger.DebugContext(ctx, "debug 2")
+	logger.DebugContext(ctx, "debug 3")
+	logger.ErrorContext(ctx, "error 1")
+
+	assert.Equal(t, []string{"debug 2", "debug 3", "error 1"}, logMessages(t, &buf))
+}
+
+func TestLogHandler_ErrorFlushBufferNoLambdaContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newBufferedTestLogger(t, &buf, 10)
+
+	logger.Debug("debug 1")
+	logger.Error("error 1")
+
+	assert.Equal(t, []string{"error 1"}, logMessages(t, &buf))
+}
+
+func TestLogHandler_ErrorFlushBufferEnabled(t *testing.T) {
+	var buf bytes.Buffer
+	handler := newBufferedTestLogger(t, &buf, 10).Handler()
+
+	ctx := NewContext(context.Background(), &LambdaContext{AwsRequestID: "request-aaa"})
+	assert.True(t, handler.Enabled(ctx, slog.LevelDebug))
+	assert.False(t, handler.Enabled(context.Background(), slog.LevelDebug))
+	assert.True(t, handler.Enabled(context.Background(), slog.LevelInfo))
+}
+
+func TestLogHandler_ErrorFlushBufferConcurrencySafe(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newBufferedTestLogger(t, &buf, 5)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ctx := NewContext(context.Background(), &LambdaContext{AwsRequestID: fmt.Sprintf("request-%d", i)})
+			for j := 0; j < 10; j++ {
+				logger.DebugContext(ctx, "debug")
+			}
+			logger.ErrorContext(ctx, "error")
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 10, strings.Count(buf.String(), `"message":"error"`))
+}
+
+// recordingHandler is a slog.Handler that records the attributes of the records it handles.
+type recordingHandler struct {
+	mu      *sync.Mutex
+	records *[]map[string]slog.Value
+	attrs   []slog.Attr
+	groups  []string
+}
+
+func newRecordingHandler() *recordingHandler {
+	return &recordingHandler{mu: &sync.Mutex{}, records: &[]map[string]slog.Value{}}
+}
+
+func (h *recordingHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *recordingHandler) Handle(_ context.Context, r slog.Record) error {
+	prefix := strings.Join(h.groups, ".")
+	if prefix != "" {
+		prefix += "."
+	}
+	record := map[string]slog.Value{}
+	for _, a := range h.attrs {
+		record[a.Key] = a.Value
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		record[prefix+a.Key] = a.Value
+		return true
+	})
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	*h.records = append(*h.records, record)
+	return nil
+}
+
+func (h *recordingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c := *h
+	c.attrs = append(append([]slog.Attr{}, h.attrs...), attrs...)
+	return &c
+}
+
+func (h *recordingHandler) WithGroup(name string) slog.Handler {
+	c := *h
+	c.groups = append(append([]string{}, h.groups...), name)
+	return &c
+}
+
+func TestWrapLogHandler(t *testing.T) {
+	base := newRecordingHandler()
+	handler := WrapLogHandler(base, WithFunctionARN(), WithTenantID())
+
+	lc := &LambdaContext{
+		AwsRequestID:       "test-request-123",
+		InvokedFunctionArn: "arn:aws:lambda:us-east-1:123456789:function:test",
+	}
+	ctx := NewContext(context.Background(), lc)
+
+	logger := slog.New(handler)
+	logger.InfoContext(ctx, "test message")
+	logger.With("service", "test-service").WithGroup("app").InfoContext(ctx, "grouped message")
+
+	require.Len(t, *base.records, 2)
+	record := (*base.records)[0]
+	assert.Equal(t, "test-request-123", record["requestId"].String())
+	assert.Equal(t, "arn:aws:lambda:us-east-1:123456789:function:test", record["functionArn"].String())
+	assert.NotContains(t, record, "tenantId")
+
+	grouped := (*base.records)[1]
+	assert.Equal(t, "test-service", grouped["service"].String())
+	assert.Equal(t, "test-request-123", grouped["app.requestId"].String())
+	assert.Equal(t, "arn:aws:lambda:us-east-1:123456789:function:test", grouped["app.functionArn"].String())
+}
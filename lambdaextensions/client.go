@@ -0,0 +1,221 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambdaextensions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil" //nolint: staticcheck
+	"net/http"
+	"os"
+)
+
+const (
+	headerExtensionName       = "Lambda-Extension-Name"
+	headerExtensionIdentifier = "Lambda-Extension-Identifier"
+	headerFunctionErrorType   = "Lambda-Extension-Function-Error-Type"
+	apiVersion                = "2020-01-01"
+)
+
+// Client calls the Lambda Extensions API on behalf of one extension.
+type Client struct {
+	name        string
+	baseURL     string
+	httpClient  *http.Client
+	extensionID string
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithRuntimeAPI sets the address of the Extensions API, instead of the AWS_LAMBDA_RUNTIME_API environment variable.
+func WithRuntimeAPI(address string) Option {
+	return Option(func(c *Client) {
+		c.baseURL = baseURL(address)
+	})
+}
+
+// WithHTTPClient sets the HTTP client of the requests to the Extensions API.
+// The client must not time out, as the requests for the next event block until there is one.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return Option(func(c *Client) {
+		c.httpClient = httpClient
+	})
+}
+
+// NewClient returns the client of the extension name, which must match the file name of an external extension.
+// The Extensions API is at the address of the AWS_LAMBDA_RUNTIME_API environment variable, unless set with
+// WithRuntimeAPI.
+func NewClient(name string, options ...Option) *Client {
+	c := &Client{
+		name:       name,
+		baseURL:    baseURL(os.Getenv("AWS_LAMBDA_RUNTIME_API")),
+		httpClient: &http.Client{Timeout: 0}, // the next event is long-polled
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+func baseURL(address string) string {
+	return "http://" + address + "/" + apiVersion + "/extension/"
+}
+
+// RegisterResponse describes the function the extension is registered with.
+type RegisterResponse struct {
+	FunctionName    string `json:"functionName"`
+	FunctionVersion string `json:"functionVersion"`
+	Handler         string `json:"handler"`
+}
+
+// Register registers the extension for events, which must be done during the init phase of the execution
+// environment, before calling NextEvent.
+func (c *Client) Register(ctx context.Context, events ...EventType) (*RegisterResponse, error) {
+	if events == nil {
+		events = []EventType{}
+	}
+	body, err := json.Marshal(struct {
+		Events []EventType `json:"events"`
+	}{events})
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.do(ctx, http.MethodPost, "register", bytes.NewReader(body), map[string]string{headerExtensionName: c.name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to register extension: %w", err)
+	}
+	defer res.Body.Close()
+
+	var registered RegisterResponse
+	if err := json.NewDecoder(res.Body).Decode(&registered); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to decode the register response: %v", err)
+	}
+	c.extensionID = res.Header.Get(headerExtensionIdentifier)
+	if c.extensionID == "" {
+		return nil, errors.New("failed to register extension: the response has no extension identifier")
+	}
+	return &registered, nil
+}
+
+// ExtensionID returns the identifier of the registered extension, or an empty string before Register.
+func (c *Client) ExtensionID() string {
+	return c.extensionID
+}
+
+// NextEvent blocks until the next event the extension is registered for. Calling it also signals that the extension
+// is done processing the previous event, or with its initialization.
+func (c *Client) NextEvent(ctx context.Context) (*Event, error) {
+	res, err := c.do(ctx, http.MethodGet, "event/next", nil, c.identifier())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the next extension event: %w", err)
+	}
+	defer res.Body.Close()
+
+	var event Event
+	if err := json.NewDecoder(res.Body).Decode(&event); err != nil {
+		return nil, fmt.Errorf("failed to decode the next extension event: %v", err)
+	}
+	return &event, nil
+}
+
+// InitError reports that the extension failed to initialize, after which the extension should exit.
+// errorType is a category of the error, such as "Extension.ConfigInvalid".
+func (c *Client) InitError(ctx context.Context, errorType string, err error) error {
+	return c.reportError(ctx, "init/error", errorType, err)
+}
+
+// ExitError reports that the extension failed, before it exits.
+// errorType is a category of the error, such as "Extension.UnknownReason".
+func (c *Client) ExitError(ctx context.Context, errorType string, err error) error {
+	return c.reportError(ctx, "exit/error", errorType, err)
+}
+
+func (c *Client) reportError(ctx context.Context, path, errorType string, err error) error {
+	body, marshalErr := json.Marshal(struct {
+		ErrorMessage string `json:"errorMessage"`
+		ErrorType    string `json:"errorType"`
+	}{err.Error(), errorType})
+	if marshalErr != nil {
+		return marshalErr
+	}
+	headers := c.identifier()
+	headers[headerFunctionErrorType] = errorType
+	res, err := c.do(ctx, http.MethodPost, path, bytes.NewReader(body), headers)
+	if err != nil {
+		return fmt.Errorf("failed to report the extension error: %w", err)
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, res.Body)
+	return nil
+}
+
+// Run registers the extension, unless already registered, for the events with a callback, and calls the
+// callbacks with each event until the shutdown event. It returns nil after the shutdown event, or the error of the
+// Extensions API, or of ctx once it is done.
+func (c *Client) Run(ctx context.Context, onInvoke func(InvokeEvent), onShutdown func(ShutdownEvent)) error {
+	if c.extensionID == "" {
+		var events []EventType
+		if onInvoke != nil {
+			events = append(events, EventTypeInvoke)
+		}
+		if onShutdown != nil {
+			events = append(events, EventTypeShutdown)
+		}
+		if _, err := c.Register(ctx, events...); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+	}
+	for {
+		event, err := c.NextEvent(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		switch event.EventType {
+		case EventTypeInvoke:
+			if onInvoke != nil {
+				onInvoke(event.InvokeEvent())
+			}
+		case EventTypeShutdown:
+			if onShutdown != nil {
+				onShutdown(event.ShutdownEvent())
+			}
+			return nil
+		}
+	}
+}
+
+func (c *Client) identifier() map[string]string {
+	return map[string]string{headerExtensionIdentifier: c.extensionID}
+}
+
+// do sends a request to the Extensions API, and returns the response if its status is 200 OK.
+func (c *Client) do(ctx context.Context, method, path string, body io.Reader, headers map[string]string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		message, _ := ioutil.ReadAll(io.LimitReader(res.Body, 1024))
+		return nil, fmt.Errorf("got response status: %d %s: %s", res.StatusCode, http.StatusText(res.StatusCode), bytes.TrimSpace(message))
+	}
+	return res, nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambdaextensions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil" //nolint: staticcheck
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// extensionsAPI simulates the Extensions API, sending the events in order.
+type extensionsAPI struct {
+	lock       sync.Mutex
+	events     []string
+	registered []EventType
+	names      []string
+	ids        []string // extension identifiers of the next event and error requests
+	errors     []string // type and body of the reported errors
+}
+
+func (api *extensionsAPI) server(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		api.lock.Lock()
+		defer api.lock.Unlock()
+		switch r.Method + " " + r.URL.Path {
+		case "POST /2020-01-01/extension/register":
+			var body struct{ Events []EventType }
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			api.registered = body.Events
+			api.names = append(api.names, r.Header.Get(headerExtensionName))
+			w.Header().Set(headerExtensionIdentifier, "extension-id")
+			_, _ = w.Write([]byte(`{"functionName": "thumbnails", "functionVersion": "$LATEST", "handler": "bootstrap"}`))
+		case "GET /2020-01-01/extension/event/next":
+			api.ids = append(api.ids, r.Header.Get(headerExtensionIdentifier))
+			if len(api.events) == 0 {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"errorMessage": "no more events"}`))
+				return
+			}
+			_, _ = w.Write([]byte(api.events[0]))
+			api.events = api.events[1:]
+		case "POST /2020-01-01/extension/init/error", "POST /2020-01-01/extension/exit/error":
+			api.ids = append(api.ids, r.Header.Get(headerExtensionIdentifier))
+			body, _ := ioutil.ReadAll(r.Body)
+			api.errors = append(api.errors, r.URL.Path+" "+r.Header.Get(headerFunctionErrorType)+" "+string(body))
+			_, _ = w.Write([]byte(`{"status": "OK"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+const (
+	invokeEvent1  = `{"eventType": "INVOKE", "deadlineMs": 1676051057000, "requestId": "request-1", "invokedFunctionArn": "arn:aws:lambda:us-east-1:123456789012:function:thumbnails", "tracing": {"type": "X-Amzn-Trace-Id", "value": "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1"}}`
+	invokeEvent2  = `{"eventType": "INVOKE", "deadlineMs": 1676051058500, "requestId": "request-2", "invokedFunctionArn": "arn:aws:lambda:us-east-1:123456789012:function:thumbnails:live", "tracing": {"type": "X-Amzn-Trace-Id", "value": "Root=1-5759e988-bd862e3fe1be46a994272794"}}`
+	shutdownEvent = `{"eventType": "SHUTDOWN", "shutdownReason": "spindown", "deadlineMs": 1676051060000}`
+)
+
+func TestRun(t *testing.T) {
+	api := &extensionsAPI{events: []string{invokeEvent1, invokeEvent2, shutdownEvent, invokeEvent1}}
+	ts := api.server(t)
+	defer ts.Close()
+
+	var invokes []InvokeEvent
+	var shutdowns []ShutdownEvent
+	client := NewClient("metrics-flusher", WithRuntimeAPI(strings.TrimPrefix(ts.URL, "http://")))
+	err := client.Run(context.Background(),
+		func(e InvokeEvent) { invokes = append(invokes, e) },
+		func(e ShutdownEvent) { shutdowns = append(shutdowns, e) },
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"metrics-flusher"}, api.names)
+	assert.Equal(t, []EventType{EventTypeInvoke, EventTypeShutdown}, api.registered)
+	assert.Equal(t, []string{"extension-id", "extension-id", "extension-id"}, api.ids)
+	assert.Equal(t, "extension-id", client.ExtensionID())
+	// the events after the shutdown are not requested
+	assert.Len(t, api.events, 1)
+
+	require.Len(t, invokes, 2)
+	assert.Equal(t, InvokeEvent{
+		DeadlineMs:         1676051057000,
+		RequestID:          "request-1",
+		InvokedFunctionArn: "arn:aws:lambda:us-east-1:123456789012:function:thumbnails",
+		Tracing:            Tracing{Type: "X-Amzn-Trace-Id", Value: "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1"},
+	}, invokes[0])
+	assert.Equal(t, "request-2", invokes[1].RequestID)
+	assert.Equal(t, time.Date(2023, 2, 10, 17, 44, 18, 500000000, time.UTC), invokes[1].Deadline().UTC())
+
+	assert.Equal(t, []ShutdownEvent{{DeadlineMs: 1676051060000, ShutdownReason: ShutdownReasonSpindown}}, shutdowns)
+	assert.Equal(t, time.Date(2023, 2, 10, 17, 44, 20, 0, time.UTC), shutdowns[0].Deadline().UTC())
+}
+
+func TestRunShutdownOnly(t *testing.T) {
+	api := &extensionsAPI{events: []string{shutdownEvent}}
+	ts := api.server(t)
+	defer ts.Close()
+
+	client := NewClient("cleanup", WithRuntimeAPI(strings.TrimPrefix(ts.URL, "http://")))
+	var reason string
+	require.NoError(t, client.Run(context.Background(), nil, func(e ShutdownEvent) { reason = e.ShutdownReason }))
+	assert.Equal(t, []EventType{EventTypeShutdown}, api.registered)
+	assert.Equal(t, ShutdownReasonSpindown, reason)
+}
+
+func TestRunErrors(t *testing.T) {
+	api := &extensionsAPI{events: []string{invokeEvent1}}
+	ts := api.server(t)
+	defer ts.Close()
+	address := strings.TrimPrefix(ts.URL, "http://")
+
+	client := NewClient("metrics-flusher", WithRuntimeAPI(address))
+	invokes := 0
+	err := client.Run(context.Background(), func(InvokeEvent) { invokes++ }, nil)
+	assert.EqualError(t, err, `failed to get the next extension event: got response status: 500 Internal Server Error: {"errorMessage": "no more events"}`)
+	assert.Equal(t, 1, invokes)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = NewClient("metrics-flusher", WithRuntimeAPI(address)).Run(ctx, func(InvokeEvent) {}, nil)
+	assert.Equal(t, context.Canceled, err)
+
+	_, err = NewClient("metrics-flusher", WithRuntimeAPI(address+"/not-found")).Register(context.Background(), EventTypeInvoke)
+	assert.EqualError(t, err, "failed to register extension: got response status: 404 Not Found: ")
+}
+
+func TestReportErrors(t *testing.T) {
+	api := &extensionsAPI{}
+	ts := api.server(t)
+	defer ts.Close()
+
+	client := NewClient("metrics-flusher", WithRuntimeAPI(strings.TrimPrefix(ts.URL, "http://")))
+	_, err := client.Register(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []EventType{}, api.registered)
+	require.NoError(t, client.InitError(context.Background(), "Extension.ConfigInvalid", errors.New("missing API key")))
+	require.NoError(t, client.ExitError(context.Background(), "Extension.UnknownReason", errors.New("buffer overflow")))
+
+	assert.Equal(t, []string{"extension-id", "extension-id"}, api.ids)
+	assert.Equal(t, []string{
+		`/2020-01-01/extension/init/error Extension.ConfigInvalid {"errorMessage":"missing API key","errorType":"Extension.ConfigInvalid"}`,
+		`/2020-01-01/extension/exit/error Extension.UnknownReason {"errorMessage":"buffer overflow","errorType":"Extension.UnknownReason"}`,
+	}, api.errors)
+}
+
+func TestNewClientRuntimeAPIFromEnvironment(t *testing.T) {
+	previous, ok := os.LookupEnv("AWS_LAMBDA_RUNTIME_API")
+	defer func() {
+		if ok {
+			os.Setenv("AWS_LAMBDA_RUNTIME_API", previous)
+		} else {
+			os.Unsetenv("AWS_LAMBDA_RUNTIME_API")
+		}
+	}()
+	require.NoError(t, os.Setenv("AWS_LAMBDA_RUNTIME_API", "127.0.0.1:9001"))
+	assert.Equal(t, "http://127.0.0.1:9001/2020-01-01/extension/", NewClient("extension").baseURL)
+	assert.Equal(t, "http://localhost:1234/2020-01-01/extension/", NewClient("extension", WithRuntimeAPI("localhost:1234")).baseURL)
+}
@@ -0,0 +1,9 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+// Package lambdaextensions is a client of the Lambda Extensions API, to write external or internal Lambda extensions.
+//
+// An extension registers for the events it handles, then long-polls the next event until the execution environment
+// shuts down. Client.Run does this with callbacks for the invoke and shutdown events.
+//
+// See https://docs.aws.amazon.com/lambda/latest/dg/runtimes-extensions-api.html
+package lambdaextensions
@@ -0,0 +1,84 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambdaextensions
+
+import "time"
+
+// EventType is the type of an event sent to extensions.
+type EventType string
+
+const (
+	EventTypeInvoke   EventType = "INVOKE"
+	EventTypeShutdown EventType = "SHUTDOWN"
+)
+
+// The ShutdownReason values of ShutdownEvent.
+const (
+	ShutdownReasonSpindown = "spindown"
+	ShutdownReasonTimeout  = "timeout"
+	ShutdownReasonFailure  = "failure"
+)
+
+// Event is an event returned by Client.NextEvent, with the fields of either an InvokeEvent or a ShutdownEvent
+// depending on its EventType.
+type Event struct {
+	EventType          EventType `json:"eventType"`
+	DeadlineMs         int64     `json:"deadlineMs"`
+	RequestID          string    `json:"requestId,omitempty"`
+	InvokedFunctionArn string    `json:"invokedFunctionArn,omitempty"`
+	Tracing            *Tracing  `json:"tracing,omitempty"`
+	ShutdownReason     string    `json:"shutdownReason,omitempty"`
+}
+
+// Tracing is the tracing header of an invoke, such as the X-Ray trace ID.
+type Tracing struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// InvokeEvent is sent to the extensions registered for EventTypeInvoke when the function is invoked.
+type InvokeEvent struct {
+	DeadlineMs         int64
+	RequestID          string
+	InvokedFunctionArn string
+	Tracing            Tracing
+}
+
+// Deadline returns the time the invoke times out.
+func (e InvokeEvent) Deadline() time.Time {
+	return unixMS(e.DeadlineMs)
+}
+
+// ShutdownEvent is sent to the extensions registered for EventTypeShutdown when the execution environment shuts down.
+// The extension is expected to exit before the deadline.
+type ShutdownEvent struct {
+	DeadlineMs     int64
+	ShutdownReason string
+}
+
+// Deadline returns the time the extension is stopped by.
+func (e ShutdownEvent) Deadline() time.Time {
+	return unixMS(e.DeadlineMs)
+}
+
+// InvokeEvent returns the fields of an invoke event.
+func (e *Event) InvokeEvent() InvokeEvent {
+	invoke := InvokeEvent{
+		DeadlineMs:         e.DeadlineMs,
+		RequestID:          e.RequestID,
+		InvokedFunctionArn: e.InvokedFunctionArn,
+	}
+	if e.Tracing != nil {
+		invoke.Tracing = *e.Tracing
+	}
+	return invoke
+}
+
+// ShutdownEvent returns the fields of a shutdown event.
+func (e *Event) ShutdownEvent() ShutdownEvent {
+	return ShutdownEvent{DeadlineMs: e.DeadlineMs, ShutdownReason: e.ShutdownReason}
+}
+
+func unixMS(ms int64) time.Time {
+	return time.Unix(ms/1000, (ms%1000)*int64(time.Millisecond))
+}
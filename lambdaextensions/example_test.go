@@ -0,0 +1,25 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package lambdaextensions_test
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-lambda-go/lambdaextensions"
+)
+
+func ExampleClient_Run() {
+	client := lambdaextensions.NewClient("metrics-flusher")
+	err := client.Run(context.Background(),
+		func(event lambdaextensions.InvokeEvent) {
+			log.Printf("invoke %s", event.RequestID)
+		},
+		func(event lambdaextensions.ShutdownEvent) {
+			log.Printf("shutting down: %s", event.ShutdownReason)
+		},
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
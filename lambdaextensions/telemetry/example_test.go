@@ -0,0 +1,50 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package telemetry_test
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambdaextensions"
+	"github.com/aws/aws-lambda-go/lambdaextensions/telemetry"
+)
+
+func ExampleSubscribe() {
+	ctx := context.Background()
+	client := lambdaextensions.NewClient("telemetry-shipper")
+	if _, err := client.Register(ctx, lambdaextensions.EventTypeInvoke, lambdaextensions.EventTypeShutdown); err != nil {
+		log.Fatal(err)
+	}
+
+	listener, err := telemetry.Listen(4243, func(record telemetry.Record) {
+		v, err := record.Decode()
+		if err != nil {
+			return
+		}
+		if report, ok := v.(*telemetry.PlatformReport); ok {
+			log.Printf("request %s billed %dms", report.RequestID, report.Metrics.BilledDurationMs)
+		}
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = telemetry.Subscribe(client.ExtensionID(), []telemetry.EventType{telemetry.EventTypePlatform, telemetry.EventTypeFunction}, telemetry.ListenerConfig{
+		URI:       listener.URI(),
+		Buffering: telemetry.BufferingConfig{TimeoutMs: 100},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := client.Run(ctx, nil, func(event lambdaextensions.ShutdownEvent) {
+		// wait for the last batch of telemetry
+		time.Sleep(200 * time.Millisecond)
+	}); err != nil {
+		log.Fatal(err)
+	}
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(300*time.Millisecond))
+	defer cancel()
+	_ = listener.Shutdown(ctx)
+}
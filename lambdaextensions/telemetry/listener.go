@@ -0,0 +1,88 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// sandboxHostname is the hostname of the execution environment, which the Telemetry API can reach.
+const sandboxHostname = "sandbox.localdomain"
+
+// Listener receives the batches of telemetry posted by the Telemetry API.
+type Listener struct {
+	uri      string
+	server   *http.Server
+	handle   func(Record)
+	lock     sync.Mutex
+	serveErr chan error
+}
+
+// Listen starts a listener of telemetry on port, or on a free port when 0, which calls handle with each record of the
+// batches in order. The records of a batch are all handled before the batch is acknowledged.
+//
+// In the execution environment, the listener is on the sandbox hostname, as the Telemetry API cannot reach localhost.
+// Elsewhere, it is on localhost.
+func Listen(port int, handle func(Record)) (*Listener, error) {
+	host := "localhost"
+	if os.Getenv("AWS_LAMBDA_RUNTIME_API") != "" {
+		host = sandboxHostname
+	}
+	ln, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for telemetry: %w", err)
+	}
+	l := &Listener{
+		uri:      fmt.Sprintf("http://%s:%d", host, ln.Addr().(*net.TCPAddr).Port),
+		handle:   handle,
+		serveErr: make(chan error, 1),
+	}
+	l.server = &http.Server{Handler: l}
+	go func() {
+		l.serveErr <- l.server.Serve(ln)
+	}()
+	return l, nil
+}
+
+// URI returns the address of the listener, which is the URI of the ListenerConfig of the subscription.
+func (l *Listener) URI() string {
+	return l.uri
+}
+
+func (l *Listener) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var batch []Record
+	if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode the telemetry batch: %v", err), http.StatusBadRequest)
+		return
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	for _, record := range batch {
+		l.handle(record)
+	}
+}
+
+// Shutdown stops the listener once the batches being received are handled, or returns the error of ctx once it is
+// done. After the shutdown event, the Telemetry API sends the records it buffered within the TimeoutMs of the
+// buffering, so an extension should wait for them before calling Shutdown, such as until a platform.report record of
+// the last invoke is handled.
+func (l *Listener) Shutdown(ctx context.Context) error {
+	if err := l.server.Shutdown(ctx); err != nil {
+		return err
+	}
+	if err := <-l.serveErr; err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
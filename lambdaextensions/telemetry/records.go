@@ -0,0 +1,178 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package telemetry
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// The Type values of Record.
+const (
+	TypePlatformInitStart       = "platform.initStart"
+	TypePlatformInitRuntimeDone = "platform.initRuntimeDone"
+	TypePlatformInitReport      = "platform.initReport"
+	TypePlatformStart           = "platform.start"
+	TypePlatformRuntimeDone     = "platform.runtimeDone"
+	TypePlatformReport          = "platform.report"
+	TypePlatformLogsDropped     = "platform.logsDropped"
+	TypeFunction                = "function"
+	TypeExtension               = "extension"
+)
+
+// Record is a telemetry record of a batch. Decode returns its typed content.
+type Record struct {
+	Time   time.Time       `json:"time"`
+	Type   string          `json:"type"`
+	Record json.RawMessage `json:"record"`
+}
+
+// Decode decodes the content of the record according to its type, into a *PlatformInitStart,
+// *PlatformInitRuntimeDone, *PlatformInitReport, *PlatformStart, *PlatformRuntimeDone, *PlatformReport,
+// *PlatformLogsDropped, or a *LogLine for the function and extension logs. The content of the other types is
+// returned as its json.RawMessage.
+func (r Record) Decode() (interface{}, error) {
+	var v interface{}
+	switch r.Type {
+	case TypePlatformInitStart:
+		v = &PlatformInitStart{}
+	case TypePlatformInitRuntimeDone:
+		v = &PlatformInitRuntimeDone{}
+	case TypePlatformInitReport:
+		v = &PlatformInitReport{}
+	case TypePlatformStart:
+		v = &PlatformStart{}
+	case TypePlatformRuntimeDone:
+		v = &PlatformRuntimeDone{}
+	case TypePlatformReport:
+		v = &PlatformReport{}
+	case TypePlatformLogsDropped:
+		v = &PlatformLogsDropped{}
+	case TypeFunction, TypeExtension:
+		v = &LogLine{}
+	default:
+		return r.Record, nil
+	}
+	if err := json.Unmarshal(r.Record, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// TraceContext is the tracing header of an invoke.
+type TraceContext struct {
+	SpanID string `json:"spanId,omitempty"`
+	Type   string `json:"type"`
+	Value  string `json:"value"`
+}
+
+// Span is a part of a phase of the execution environment, such as the response latency of an invoke.
+type Span struct {
+	Name       string    `json:"name"`
+	Start      time.Time `json:"start"`
+	DurationMs float64   `json:"durationMs"`
+}
+
+type PlatformInitStart struct {
+	InitializationType string `json:"initializationType"`
+	Phase              string `json:"phase"`
+	RuntimeVersion     string `json:"runtimeVersion,omitempty"`
+	RuntimeVersionARN  string `json:"runtimeVersionArn,omitempty"`
+	FunctionName       string `json:"functionName,omitempty"`
+	FunctionVersion    string `json:"functionVersion,omitempty"`
+	InstanceID         string `json:"instanceId,omitempty"`
+	InstanceMaxMemory  int64  `json:"instanceMaxMemory,omitempty"`
+}
+
+type PlatformInitRuntimeDone struct {
+	InitializationType string `json:"initializationType"`
+	Phase              string `json:"phase"`
+	Status             string `json:"status"`
+	ErrorType          string `json:"errorType,omitempty"`
+	Spans              []Span `json:"spans,omitempty"`
+}
+
+type PlatformInitReport struct {
+	InitializationType string            `json:"initializationType"`
+	Phase              string            `json:"phase"`
+	Status             string            `json:"status"`
+	ErrorType          string            `json:"errorType,omitempty"`
+	Metrics            InitReportMetrics `json:"metrics"`
+	Spans              []Span            `json:"spans,omitempty"`
+}
+
+type InitReportMetrics struct {
+	DurationMs float64 `json:"durationMs"`
+}
+
+type PlatformStart struct {
+	RequestID string        `json:"requestId"`
+	Version   string        `json:"version,omitempty"`
+	Tracing   *TraceContext `json:"tracing,omitempty"`
+}
+
+type PlatformRuntimeDone struct {
+	RequestID string              `json:"requestId"`
+	Status    string              `json:"status"`
+	ErrorType string              `json:"errorType,omitempty"`
+	Metrics   *RuntimeDoneMetrics `json:"metrics,omitempty"`
+	Tracing   *TraceContext       `json:"tracing,omitempty"`
+	Spans     []Span              `json:"spans,omitempty"`
+}
+
+type RuntimeDoneMetrics struct {
+	DurationMs    float64 `json:"durationMs"`
+	ProducedBytes int64   `json:"producedBytes,omitempty"`
+}
+
+// PlatformReport reports the metrics of an invoke once it is done.
+type PlatformReport struct {
+	RequestID string        `json:"requestId"`
+	Status    string        `json:"status"`
+	ErrorType string        `json:"errorType,omitempty"`
+	Metrics   ReportMetrics `json:"metrics"`
+	Tracing   *TraceContext `json:"tracing,omitempty"`
+	Spans     []Span        `json:"spans,omitempty"`
+}
+
+type ReportMetrics struct {
+	DurationMs       float64 `json:"durationMs"`
+	BilledDurationMs int64   `json:"billedDurationMs"`
+	MemorySizeMB     int64   `json:"memorySizeMB"`
+	MaxMemoryUsedMB  int64   `json:"maxMemoryUsedMB"`
+	// InitDurationMs is only set for the first invoke of an execution environment.
+	InitDurationMs          *float64 `json:"initDurationMs,omitempty"`
+	RestoreDurationMs       *float64 `json:"restoreDurationMs,omitempty"`
+	BilledRestoreDurationMs *int64   `json:"billedRestoreDurationMs,omitempty"`
+}
+
+type PlatformLogsDropped struct {
+	DroppedBytes   int64  `json:"droppedBytes"`
+	DroppedRecords int64  `json:"droppedRecords"`
+	Reason         string `json:"reason"`
+}
+
+// LogLine is a log line of the function or of an extension. With the text log format, the line is in Text. With the
+// JSON log format, the fields of the line are decoded, and all of them are kept in Fields.
+type LogLine struct {
+	Text      string
+	Timestamp string
+	Level     string
+	RequestID string
+	Message   string
+	Fields    map[string]interface{}
+}
+
+func (l *LogLine) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &l.Text)
+	}
+	if err := json.Unmarshal(data, &l.Fields); err != nil {
+		return err
+	}
+	l.Timestamp, _ = l.Fields["timestamp"].(string)
+	l.Level, _ = l.Fields["level"].(string)
+	l.RequestID, _ = l.Fields["requestId"].(string)
+	l.Message, _ = l.Fields["message"].(string)
+	return nil
+}
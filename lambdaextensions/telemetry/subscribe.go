@@ -0,0 +1,93 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+// Package telemetry subscribes a Lambda extension to the Lambda Telemetry API, and receives the platform, function
+// and extension telemetry with a Listener.
+//
+// See https://docs.aws.amazon.com/lambda/latest/dg/telemetry-api.html
+package telemetry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil" //nolint: staticcheck
+	"net/http"
+	"os"
+)
+
+const (
+	headerExtensionIdentifier = "Lambda-Extension-Identifier"
+	apiVersion                = "2022-07-01"
+	schemaVersion             = "2022-12-13"
+)
+
+// EventType is a type of telemetry to subscribe to.
+type EventType string
+
+const (
+	EventTypePlatform  EventType = "platform"
+	EventTypeFunction  EventType = "function"
+	EventTypeExtension EventType = "extension"
+)
+
+// ListenerConfig is the destination of the telemetry of a subscription.
+type ListenerConfig struct {
+	// URI is the HTTP address the telemetry is posted to, such as the URI of a Listener.
+	URI string
+
+	// Buffering configures the batches of telemetry. The zero values are replaced by the defaults of the Telemetry API.
+	Buffering BufferingConfig
+}
+
+// BufferingConfig configures when a batch of telemetry is sent: once it has MaxItems records or MaxBytes, or after
+// TimeoutMs milliseconds.
+type BufferingConfig struct {
+	MaxItems  int `json:"maxItems,omitempty"`
+	MaxBytes  int `json:"maxBytes,omitempty"`
+	TimeoutMs int `json:"timeoutMs,omitempty"`
+}
+
+type subscribeRequest struct {
+	SchemaVersion string          `json:"schemaVersion"`
+	Types         []EventType     `json:"types"`
+	Buffering     BufferingConfig `json:"buffering"`
+	Destination   destination     `json:"destination"`
+}
+
+type destination struct {
+	Protocol string `json:"protocol"`
+	URI      string `json:"URI"`
+}
+
+// Subscribe subscribes the extension extensionID to the telemetry types, sent to dest. The extension must be
+// registered, such as with lambdaextensions.Client, and subscribe before it requests its first event.
+// The Telemetry API is at the address of the AWS_LAMBDA_RUNTIME_API environment variable.
+func Subscribe(extensionID string, types []EventType, dest ListenerConfig) error {
+	body, err := json.Marshal(subscribeRequest{
+		SchemaVersion: schemaVersion,
+		Types:         types,
+		Buffering:     dest.Buffering,
+		Destination:   destination{Protocol: "HTTP", URI: dest.URI},
+	})
+	if err != nil {
+		return err
+	}
+	url := "http://" + os.Getenv("AWS_LAMBDA_RUNTIME_API") + "/" + apiVersion + "/telemetry"
+	req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(headerExtensionIdentifier, extensionID)
+	req.Header.Set("Content-Type", "application/json")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to telemetry: %w", err)
+	}
+	defer res.Body.Close()
+	message, _ := ioutil.ReadAll(io.LimitReader(res.Body, 1024))
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to subscribe to telemetry, got response status: %d %s: %s", res.StatusCode, http.StatusText(res.StatusCode), bytes.TrimSpace(message))
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+// Copyright 2026 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil" //nolint: staticcheck
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func postBatch(t *testing.T, uri string, batch []byte) *http.Response {
+	res, err := http.Post(uri, "application/json", bytes.NewReader(batch))
+	require.NoError(t, err)
+	res.Body.Close()
+	return res
+}
+
+func TestListener(t *testing.T) {
+	var lock sync.Mutex
+	var records []Record
+	listener, err := Listen(0, func(r Record) {
+		lock.Lock()
+		defer lock.Unlock()
+		records = append(records, r)
+	})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(listener.URI(), "http://localhost:"))
+
+	batch, err := ioutil.ReadFile("./testdata/telemetry-batch.json")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, postBatch(t, listener.URI(), batch).StatusCode)
+	assert.Equal(t, http.StatusBadRequest, postBatch(t, listener.URI(), []byte(`{"not": "a batch"}`)).StatusCode)
+	require.NoError(t, listener.Shutdown(context.Background()))
+
+	require.Len(t, records, 11)
+	assert.Equal(t, time.Date(2022, 10, 12, 0, 0, 15, 64000000, time.UTC), records[0].Time)
+
+	var decoded []interface{}
+	for _, record := range records {
+		v, err := record.Decode()
+		require.NoError(t, err)
+		decoded = append(decoded, v)
+	}
+
+	initStart := decoded[0].(*PlatformInitStart)
+	assert.Equal(t, "on-demand", initStart.InitializationType)
+	assert.Equal(t, "thumbnails", initStart.FunctionName)
+	assert.Equal(t, int64(134217728), initStart.InstanceMaxMemory)
+	assert.Equal(t, "success", decoded[1].(*PlatformInitRuntimeDone).Status)
+	assert.Equal(t, 201.2, decoded[2].(*PlatformInitReport).Metrics.DurationMs)
+	assert.Equal(t, "54565fb41ac79632", decoded[3].(*PlatformStart).Tracing.SpanID)
+
+	assert.Equal(t, &LogLine{Text: "resizing HappyFace.jpg\n"}, decoded[4])
+	jsonLine := decoded[5].(*LogLine)
+	assert.Equal(t, "INFO", jsonLine.Level)
+	assert.Equal(t, "resized", jsonLine.Message)
+	assert.Equal(t, "6d68ca91-49c9-448d-89b8-7ca3e6dc66aa", jsonLine.RequestID)
+	assert.Equal(t, "2022-10-12T00:01:15.020Z", jsonLine.Timestamp)
+	assert.Equal(t, float64(128), jsonLine.Fields["width"])
+	assert.Equal(t, TypeExtension, records[6].Type)
+	assert.Equal(t, "metrics-flusher: flushed 3 metrics\n", decoded[6].(*LogLine).Text)
+
+	runtimeDone := decoded[7].(*PlatformRuntimeDone)
+	assert.Equal(t, &RuntimeDoneMetrics{DurationMs: 45.2, ProducedBytes: 1024}, runtimeDone.Metrics)
+	require.Len(t, runtimeDone.Spans, 2)
+	assert.Equal(t, Span{Name: "responseLatency", Start: time.Date(2022, 10, 12, 0, 1, 15, 0, time.UTC), DurationMs: 23.02}, runtimeDone.Spans[0])
+
+	report := decoded[8].(*PlatformReport)
+	assert.Equal(t, "6d68ca91-49c9-448d-89b8-7ca3e6dc66aa", report.RequestID)
+	assert.Equal(t, int64(46), report.Metrics.BilledDurationMs)
+	assert.Equal(t, 45.87, report.Metrics.DurationMs)
+	assert.Equal(t, int64(128), report.Metrics.MemorySizeMB)
+	assert.Equal(t, int64(31), report.Metrics.MaxMemoryUsedMB)
+	require.NotNil(t, report.Metrics.InitDurationMs)
+	assert.Equal(t, 201.2, *report.Metrics.InitDurationMs)
+	assert.Nil(t, report.Metrics.RestoreDurationMs)
+
+	assert.Equal(t, int64(12), decoded[9].(*PlatformLogsDropped).DroppedRecords)
+	assert.JSONEq(t, `{"runtimeVersion": "provided:al2023.v21"}`, string(decoded[10].(json.RawMessage)))
+}
+
+func TestListenerShutdownHandlesBatchInFlight(t *testing.T) {
+	received := make(chan struct{})
+	release := make(chan struct{})
+	var handled []string
+	listener, err := Listen(0, func(r Record) {
+		if len(handled) == 0 {
+			close(received)
+			<-release
+		}
+		handled = append(handled, r.Type)
+	})
+	require.NoError(t, err)
+
+	posted := make(chan int)
+	go func() {
+		res, err := http.Post(listener.URI(), "application/json", strings.NewReader(`[
+			{"time": "2022-10-12T00:01:15.050Z", "type": "function", "record": "last line\n"},
+			{"time": "2022-10-12T00:01:15.060Z", "type": "platform.report", "record": {"requestId": "r", "status": "success", "metrics": {"billedDurationMs": 1}}}
+		]`))
+		if err != nil {
+			posted <- 0
+			return
+		}
+		res.Body.Close()
+		posted <- res.StatusCode
+	}()
+	<-received
+
+	shutdown := make(chan error)
+	go func() { shutdown <- listener.Shutdown(context.Background()) }()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+
+	require.NoError(t, <-shutdown)
+	assert.Equal(t, http.StatusOK, <-posted)
+	assert.Equal(t, []string{TypeFunction, TypePlatformReport}, handled)
+}
+
+func TestSubscribe(t *testing.T) {
+	var request struct {
+		method, path, extensionID string
+		body                      map[string]interface{}
+	}
+	status := http.StatusOK
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request.method = r.Method
+		request.path = r.URL.Path
+		request.extensionID = r.Header.Get(headerExtensionIdentifier)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request.body))
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"errorMessage": "invalid destination"}`))
+	}))
+	defer ts.Close()
+	previous, ok := os.LookupEnv("AWS_LAMBDA_RUNTIME_API")
+	defer func() {
+		if ok {
+			os.Setenv("AWS_LAMBDA_RUNTIME_API", previous)
+		} else {
+			os.Unsetenv("AWS_LAMBDA_RUNTIME_API")
+		}
+	}()
+	require.NoError(t, os.Setenv("AWS_LAMBDA_RUNTIME_API", strings.TrimPrefix(ts.URL, "http://")))
+
+	err := Subscribe("extension-id", []EventType{EventTypePlatform, EventTypeFunction}, ListenerConfig{
+		URI:       "http://sandbox.localdomain:4243",
+		Buffering: BufferingConfig{MaxItems: 1000, MaxBytes: 262144, TimeoutMs: 100},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodPut, request.method)
+	assert.Equal(t, "/2022-07-01/telemetry", request.path)
+	assert.Equal(t, "extension-id", request.extensionID)
+	body, err := json.Marshal(request.body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"schemaVersion": "2022-12-13",
+		"types": ["platform", "function"],
+		"buffering": {"maxItems": 1000, "maxBytes": 262144, "timeoutMs": 100},
+		"destination": {"protocol": "HTTP", "URI": "http://sandbox.localdomain:4243"}
+	}`, string(body))
+
+	// the defaults of the Telemetry API apply to the buffering not set
+	require.NoError(t, Subscribe("extension-id", []EventType{EventTypeExtension}, ListenerConfig{URI: "http://sandbox.localdomain:4243"}))
+	assert.Equal(t, map[string]interface{}{}, request.body["buffering"])
+
+	status = http.StatusBadRequest
+	err = Subscribe("extension-id", []EventType{EventTypePlatform}, ListenerConfig{URI: "http://localhost:4243"})
+	assert.EqualError(t, err, `failed to subscribe to telemetry, got response status: 400 Bad Request: {"errorMessage": "invalid destination"}`)
+}
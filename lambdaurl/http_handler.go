@@ -9,8 +9,10 @@ package lambdaurl
 import (
 	"context"
 	"encoding/base64"
+	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"sync"
 
@@ -43,7 +45,7 @@ func WithDetectContentType(detectContentType bool) lambda.Option {
 type httpResponseWriter struct {
 	detectContentType bool
 	header            http.Header
-	writer            io.Writer
+	writer            *io.PipeWriter
 	once              sync.Once
 	ready             chan<- header
 }
@@ -51,6 +53,16 @@ type httpResponseWriter struct {
 type header struct {
 	code   int
 	header http.Header
+	err    error // the handler panicked before writing the header
+}
+
+// panicError reports a panic of the http.Handler.
+type panicError struct {
+	value interface{}
+}
+
+func (e panicError) Error() string {
+	return fmt.Sprintf("http.Handler panicked: %v", e.value)
 }
 
 func (w *httpResponseWriter) Header() http.Header {
@@ -69,6 +81,11 @@ func (w *httpResponseWriter) WriteHeader(statusCode int) {
 	w.writeHeader(statusCode, nil)
 }
 
+// Flush sends the status and headers, if not already sent. The body is always sent as it is written.
+func (w *httpResponseWriter) Flush() {
+	w.writeHeader(http.StatusOK, nil)
+}
+
 func (w *httpResponseWriter) writeHeader(statusCode int, initialPayload []byte) {
 	w.once.Do(func() {
 		if w.detectContentType {
@@ -76,10 +93,24 @@ func (w *httpResponseWriter) writeHeader(statusCode int, initialPayload []byte)
 				w.Header().Set("Content-Type", detectContentType(initialPayload))
 			}
 		}
-		w.ready <- header{code: statusCode, header: w.header}
+		// the handler may keep changing the header map after it is sent
+		w.ready <- header{code: statusCode, header: w.header.Clone()}
 	})
 }
 
+// fail reports the error err of the handler, as the error of the handler if the header is not sent yet, or else as
+// the error reading the body, which the runtime reports after the part of the response already sent.
+func (w *httpResponseWriter) fail(err error) {
+	sent := true
+	w.once.Do(func() {
+		sent = false
+		w.ready <- header{err: err}
+	})
+	if sent {
+		_ = w.writer.CloseWithError(err)
+	}
+}
+
 func detectContentType(p []byte) string {
 	// http.DetectContentType returns "text/plain; charset=utf-8" for nil and zero-length byte slices.
 	// This is a weird behavior, since otherwise it defaults to "application/octet-stream"! So we'll do that.
@@ -102,6 +133,11 @@ func RequestFromContext(ctx context.Context) (*events.LambdaFunctionURLRequest,
 //
 // Only Lambda Function URLs configured with `InvokeMode: RESPONSE_STREAM` are supported with the returned handler.
 // The response body of the handler will conform to the content-type `application/vnd.awslambda.http-integration-response`.
+//
+// The response is streamed as the handler writes it: the status and headers are sent on the first call to Write,
+// WriteHeader or Flush, and each Write is sent before it returns, which suits server-sent events and large downloads.
+// The http.ResponseWriter implements http.Flusher. When the handler panics after the response started streaming,
+// the panic is reported as the function error after the part of the response already sent.
 func Wrap(handler http.Handler) func(context.Context, *events.LambdaFunctionURLRequest) (*events.LambdaFunctionURLStreamingResponse, error) {
 	return func(ctx context.Context, request *events.LambdaFunctionURLRequest) (*events.LambdaFunctionURLStreamingResponse, error) {
 
@@ -110,8 +146,8 @@ func Wrap(handler http.Handler) func(context.Context, *events.LambdaFunctionURLR
 			body = base64.NewDecoder(base64.StdEncoding, body)
 		}
 		url := "https://" + request.RequestContext.DomainName + request.RawPath
-		if request.RawQueryString != "" {
-			url += "?" + request.RawQueryString
+		if query := requestQuery(request); query != "" {
+			url += "?" + query
 		}
 		ctx = context.WithValue(ctx, requestContextKey{}, request)
 		httpRequest, err := http.NewRequestWithContext(ctx, request.RequestContext.HTTP.Method, url, body)
@@ -122,6 +158,10 @@ func Wrap(handler http.Handler) func(context.Context, *events.LambdaFunctionURLR
 		for k, v := range request.Headers {
 			httpRequest.Header.Add(k, v)
 		}
+		if len(request.Cookies) > 0 {
+			// the cookies of the request are sent separately, and may not all be in the cookie header
+			httpRequest.Header.Set("Cookie", strings.Join(request.Cookies, "; "))
+		}
 
 		ready := make(chan header) // Signals when it's OK to start returning the response body to Lambda
 		r, w := io.Pipe()
@@ -131,12 +171,21 @@ func Wrap(handler http.Handler) func(context.Context, *events.LambdaFunctionURLR
 		}
 		go func() {
 			defer close(ready)
-			defer w.Close() // TODO: recover and CloseWithError the any panic value once the runtime API client supports plumbing fatal errors through the reader
-			//nolint:errcheck
-			defer responseWriter.Write(nil) // force default status, headers, content type detection, if none occured during the execution of the handler
+			defer w.Close()
+			defer func() {
+				if v := recover(); v != nil {
+					responseWriter.fail(panicError{v})
+					return
+				}
+				// force default status, headers, content type detection, if none occured during the execution of the handler
+				_, _ = responseWriter.Write(nil)
+			}()
 			handler.ServeHTTP(responseWriter, httpRequest)
 		}()
 		header := <-ready
+		if header.err != nil {
+			return nil, header.err
+		}
 		response := &events.LambdaFunctionURLStreamingResponse{
 			Body:       r,
 			StatusCode: header.code,
@@ -144,8 +193,8 @@ func Wrap(handler http.Handler) func(context.Context, *events.LambdaFunctionURLR
 		if len(header.header) > 0 {
 			response.Headers = make(map[string]string, len(header.header))
 			for k, v := range header.header {
-				if k == "Set-Cookie" {
-					response.Cookies = v
+				if http.CanonicalHeaderKey(k) == "Set-Cookie" {
+					response.Cookies = append(response.Cookies, v...)
 				} else {
 					response.Headers[k] = strings.Join(v, ",")
 				}
@@ -155,6 +204,19 @@ func Wrap(handler http.Handler) func(context.Context, *events.LambdaFunctionURLR
 	}
 }
 
+// requestQuery returns the query string of the request. QueryStringParameters is only used without RawQueryString,
+// as it joins the values of repeated parameters with commas.
+func requestQuery(request *events.LambdaFunctionURLRequest) string {
+	if request.RawQueryString != "" || len(request.QueryStringParameters) == 0 {
+		return request.RawQueryString
+	}
+	query := url.Values{}
+	for k, v := range request.QueryStringParameters {
+		query.Set(k, v)
+	}
+	return query.Encode()
+}
+
 // Start wraps a http.Handler and calls lambda.StartHandlerFunc
 // Only supports:
 //   - Lambda Function URLs configured with `InvokeMode: RESPONSE_STREAM`
@@ -162,6 +162,45 @@ func TestWrap(t *testing.T) {
 				"Content-Type": "text/html; charset=utf-8",
 			},
 		},
+		"repeated query parameters": {
+			input: []byte(`{"rawPath": "/search", "rawQueryString": "tag=a&tag=b%2Cc&q=x,y", "queryStringParameters": {"tag": "a,b,c", "q": "x,y"}, "requestContext": {"domainName": "example.com", "http": {"method": "GET"}}}`),
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewEncoder(w).Encode(r.URL.Query())
+			},
+			expectStatus: http.StatusOK,
+			expectBody:   `{"q":["x,y"],"tag":["a","b,c"]}` + "\n",
+		},
+		"query parameters without raw query string": {
+			input: []byte(`{"rawPath": "/search", "queryStringParameters": {"q": "x,y", "page": "2"}, "requestContext": {"domainName": "example.com", "http": {"method": "GET"}}}`),
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(r.URL.RawQuery))
+			},
+			expectStatus: http.StatusOK,
+			expectBody:   "page=2&q=x%2Cy",
+		},
+		"request cookies without cookie header": {
+			input: []byte(`{"rawPath": "/", "cookies": ["session=abc", "theme=dark; lang=en"], "requestContext": {"domainName": "example.com", "http": {"method": "GET"}}}`),
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				for _, c := range r.Cookies() {
+					_, _ = w.Write([]byte(c.Name + "=" + c.Value + "\n"))
+				}
+			},
+			expectStatus: http.StatusOK,
+			expectBody:   "session=abc\ntheme=dark\nlang=en\n",
+		},
+		"multiple set-cookie writes": {
+			input: helloRequest,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/plain")
+				w.Header().Add("Set-Cookie", "a=1; Path=/")
+				w.Header().Add("Set-Cookie", "b=2, with a comma")
+				http.SetCookie(w, &http.Cookie{Name: "c", Value: "3", HttpOnly: true})
+				w.WriteHeader(http.StatusCreated)
+			},
+			expectStatus:  http.StatusCreated,
+			expectHeaders: map[string]string{"Content-Type": "text/plain"},
+			expectCookies: []string{"a=1; Path=/", "b=2, with a comma", "c=3; HttpOnly"},
+		},
 		"detect content type: writes zeros": {
 			input: helloRequest,
 			handler: func(w http.ResponseWriter, r *http.Request) {
@@ -200,6 +239,81 @@ func TestWrap(t *testing.T) {
 	}
 }
 
+func TestWrapStreamsServerSentEvents(t *testing.T) {
+	sse := []string{"data: one\n\n", "data: two\n\n", "data: three\n\n"}
+	next := make(chan struct{})
+	handler := Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc"})
+		flusher, ok := w.(http.Flusher)
+		require.True(t, ok)
+		flusher.Flush()
+		// set after the headers are sent, so not part of the response
+		w.Header().Set("X-Too-Late", "true")
+		for _, event := range sse {
+			<-next
+			_, _ = io.WriteString(w, event)
+			flusher.Flush()
+		}
+	}))
+	var req events.LambdaFunctionURLRequest
+	require.NoError(t, json.Unmarshal(helloRequest, &req))
+
+	// the prelude is sent on Flush, before any of the body is written
+	res, err := handler(context.Background(), &req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, map[string]string{"Content-Type": "text/event-stream", "Cache-Control": "no-cache"}, res.Headers)
+	assert.Equal(t, []string{"session=abc"}, res.Cookies)
+	prelude := make([]byte, 512)
+	n, err := res.Read(prelude)
+	require.NoError(t, err)
+	_, _, ok := bytes.Cut(prelude[:n], []byte{0, 0, 0, 0, 0, 0, 0, 0})
+	require.True(t, ok)
+
+	// each event is read as soon as it is written, before the next one
+	for _, event := range sse {
+		next <- struct{}{}
+		chunk := make([]byte, 512)
+		n, err := res.Read(chunk)
+		require.NoError(t, err)
+		assert.Equal(t, event, string(chunk[:n]))
+	}
+	rest, err := ioutil.ReadAll(res)
+	require.NoError(t, err)
+	assert.Empty(t, rest)
+}
+
+func TestWrapPanic(t *testing.T) {
+	var req events.LambdaFunctionURLRequest
+	require.NoError(t, json.Unmarshal(helloRequest, &req))
+
+	t.Run("before the response", func(t *testing.T) {
+		handler := Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("no database")
+		}))
+		res, err := handler(context.Background(), &req)
+		assert.Nil(t, res)
+		assert.EqualError(t, err, "http.Handler panicked: no database")
+	})
+	t.Run("mid-stream", func(t *testing.T) {
+		handler := Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte("partial"))
+			panic("lost the upstream")
+		}))
+		res, err := handler(context.Background(), &req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, res.StatusCode)
+		body, err := ioutil.ReadAll(res)
+		assert.EqualError(t, err, "http.Handler panicked: lost the upstream")
+		_, body, ok := bytes.Cut(body, []byte{0, 0, 0, 0, 0, 0, 0, 0})
+		require.True(t, ok)
+		assert.Equal(t, "partial", string(body))
+	})
+}
+
 func TestRequestContext(t *testing.T) {
 	var req *events.LambdaFunctionURLRequest
 	require.NoError(t, json.Unmarshal(helloRequest, &req))